@@ -0,0 +1,285 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+	"tasklog/internal/timeparse"
+)
+
+// approvalDateFormat is the date format of Tempo periods and schedules
+const approvalDateFormat = "2006-01-02"
+
+var (
+	approvalPeriod   string
+	approvalReviewer string
+	approvalComment  string
+	approvalForce    bool
+)
+
+var approvalCmd = &cobra.Command{
+	Use:   "approval",
+	Short: "Check or submit your Tempo timesheet",
+	Long: `Shows the approval status of your Tempo timesheet and submits it for review.
+
+The period is one of: current, previous, or a date (YYYY-MM-DD) inside the period.` + configHelp,
+}
+
+var approvalStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the approval status of a timesheet period",
+	Long: `Shows the approval status, reviewer and logged time of a Tempo timesheet period,
+along with any days below your work schedule and entries not yet synced.
+
+Examples:
+  tasklog approval status                     # Current period
+  tasklog approval status --period previous   # Previous period` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runApprovalStatus,
+}
+
+var approvalSubmitCmd = &cobra.Command{
+	Use:   "submit",
+	Short: "Submit a timesheet period for approval",
+	Long: `Submits a Tempo timesheet period to your reviewer.
+
+Before submitting, tasklog checks that every day meets the required hours of your
+Tempo work schedule and that no local entries are waiting to be synced. Use --force
+to submit anyway.
+
+The reviewer defaults to the one Tempo already assigned to your timesheet.
+
+Examples:
+  tasklog approval submit --period current
+  tasklog approval submit --period previous --comment "Sick on Friday" --force
+  tasklog approval submit --reviewer 5b10ac8d82e05b22cc7d4ef5` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runApprovalSubmit,
+}
+
+func init() {
+	rootCmd.AddCommand(approvalCmd)
+	approvalCmd.AddCommand(approvalStatusCmd)
+	approvalCmd.AddCommand(approvalSubmitCmd)
+
+	approvalCmd.PersistentFlags().StringVar(&approvalPeriod, "period", "current", "Period to use: current, previous, or a date (YYYY-MM-DD)")
+	approvalSubmitCmd.Flags().StringVar(&approvalReviewer, "reviewer", "", "Reviewer account ID (default: the assigned reviewer)")
+	approvalSubmitCmd.Flags().StringVar(&approvalComment, "comment", "", "Comment for the reviewer")
+	approvalSubmitCmd.Flags().BoolVar(&approvalForce, "force", false, "Submit even if days are short or entries are unsynced")
+}
+
+// timesheetCheck is the state of a timesheet period
+type timesheetCheck struct {
+	tempoClient *tempo.Client
+	accountID   string
+	period      tempo.Period
+	approval    *tempo.Approval
+	problems    []string
+}
+
+func runApprovalStatus(cmd *cobra.Command, args []string) error {
+	check, err := checkTimesheet()
+	if err != nil {
+		return err
+	}
+
+	approval := check.approval
+	fmt.Printf("Timesheet %s to %s\n", check.period.From, check.period.To)
+	fmt.Printf("  Status:   %s\n", approval.Status.Key)
+	if approval.Reviewer != nil {
+		fmt.Printf("  Reviewer: %s\n", approval.Reviewer.AccountID)
+	}
+	fmt.Printf("  Logged:   %s of %s\n", timeparse.Format(approval.TimeSpentSeconds), timeparse.Format(approval.RequiredSeconds))
+	if approval.Status.Comment != "" {
+		fmt.Printf("  Comment:  %s\n", approval.Status.Comment)
+	}
+
+	if len(check.problems) == 0 {
+		fmt.Println("\n✓ Ready to submit")
+		return nil
+	}
+
+	fmt.Println("\nNot ready to submit:")
+	for _, problem := range check.problems {
+		fmt.Printf("  ✗ %s\n", problem)
+	}
+	return nil
+}
+
+func runApprovalSubmit(cmd *cobra.Command, args []string) error {
+	check, err := checkTimesheet()
+	if err != nil {
+		return err
+	}
+
+	if len(check.problems) > 0 {
+		fmt.Println("Timesheet is not ready to submit:")
+		for _, problem := range check.problems {
+			fmt.Printf("  ✗ %s\n", problem)
+		}
+		if !approvalForce {
+			return fmt.Errorf("fix the problems above or use --force to submit anyway")
+		}
+		fmt.Println()
+	}
+
+	reviewer := approvalReviewer
+	if reviewer == "" && check.approval.Reviewer != nil {
+		reviewer = check.approval.Reviewer.AccountID
+	}
+	if reviewer == "" {
+		return fmt.Errorf("no reviewer is assigned to your timesheet, use --reviewer")
+	}
+
+	approval, err := check.tempoClient.SubmitApproval(check.accountID, check.period, reviewer, approvalComment)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Submitted timesheet %s to %s (status: %s)\n", check.period.From, check.period.To, approval.Status.Key)
+	return nil
+}
+
+// checkTimesheet loads the approval of the selected period and checks it is ready to submit
+func checkTimesheet() (*timesheetCheck, error) {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if !cfg.Tempo.Enabled || cfg.Tempo.APIToken == "" {
+		return nil, fmt.Errorf("timesheet approvals require tempo.enabled and tempo.api_token")
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	currentUser, err := jiraClient.GetCurrentUser()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	period, err := resolvePeriod(tempoClient, approvalPeriod, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	approval, err := tempoClient.GetApproval(currentUser.AccountID, period)
+	if err != nil {
+		return nil, err
+	}
+
+	from, to, err := periodDates(period)
+	if err != nil {
+		return nil, err
+	}
+	schedule, err := tempoClient.GetUserSchedule(from, to)
+	if err != nil {
+		return nil, err
+	}
+	worklogs, err := tempoClient.GetWorklogs(from, to, currentUser.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	unsynced, err := store.GetUnsyncedEntries()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch unsynced entries: %w", err)
+	}
+
+	return &timesheetCheck{
+		tempoClient: tempoClient,
+		accountID:   currentUser.AccountID,
+		period:      period,
+		approval:    approval,
+		problems:    timesheetProblems(schedule, worklogs, unsynced),
+	}, nil
+}
+
+// resolvePeriod returns the Tempo period named by value: current, previous, or a date inside it
+func resolvePeriod(tempoClient *tempo.Client, value string, now time.Time) (tempo.Period, error) {
+	date := now
+	switch value {
+	case "", "current":
+	case "previous":
+		current, err := resolvePeriod(tempoClient, "current", now)
+		if err != nil {
+			return tempo.Period{}, err
+		}
+		from, err := time.ParseInLocation(approvalDateFormat, current.From, now.Location())
+		if err != nil {
+			return tempo.Period{}, fmt.Errorf("invalid period start %q: %w", current.From, err)
+		}
+		date = from.AddDate(0, 0, -1)
+	default:
+		parsed, err := time.ParseInLocation(approvalDateFormat, value, now.Location())
+		if err != nil {
+			return tempo.Period{}, fmt.Errorf("invalid period %q, use current, previous or YYYY-MM-DD", value)
+		}
+		date = parsed
+	}
+
+	periods, err := tempoClient.GetPeriods(date, date)
+	if err != nil {
+		return tempo.Period{}, err
+	}
+	if len(periods) == 0 {
+		return tempo.Period{}, fmt.Errorf("no timesheet period found for %s", date.Format(approvalDateFormat))
+	}
+	return periods[0], nil
+}
+
+// periodDates returns the first and last day of a period
+func periodDates(period tempo.Period) (time.Time, time.Time, error) {
+	from, err := time.ParseInLocation(approvalDateFormat, period.From, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period start %q: %w", period.From, err)
+	}
+	to, err := time.ParseInLocation(approvalDateFormat, period.To, time.Local)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid period end %q: %w", period.To, err)
+	}
+	return from, to, nil
+}
+
+// timesheetProblems lists the days logged below the work schedule and the entries not yet synced
+func timesheetProblems(schedule []tempo.ScheduleDay, worklogs []tempo.WorklogResponse, unsynced []storage.TimeEntry) []string {
+	logged := make(map[string]int)
+	for _, wl := range worklogs {
+		logged[wl.StartDate] += wl.TimeSpentSeconds
+	}
+
+	days := make([]tempo.ScheduleDay, len(schedule))
+	copy(days, schedule)
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+
+	var problems []string
+	for _, day := range days {
+		if day.RequiredSeconds == 0 || logged[day.Date] >= day.RequiredSeconds {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s: logged %s of %s",
+			day.Date, timeparse.Format(logged[day.Date]), timeparse.Format(day.RequiredSeconds)))
+	}
+
+	if len(unsynced) > 0 {
+		problems = append(problems, fmt.Sprintf("%d entries are not synced, run 'tasklog sync'", len(unsynced)))
+	}
+	return problems
+}
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+)
+
+func TestTimesheetProblems(t *testing.T) {
+	schedule := []tempo.ScheduleDay{
+		{Date: "2025-01-07", RequiredSeconds: 28800, Type: "WORKING_DAY"},
+		{Date: "2025-01-06", RequiredSeconds: 28800, Type: "WORKING_DAY"},
+		{Date: "2025-01-11", RequiredSeconds: 0, Type: "NON_WORKING_DAY"},
+	}
+	worklogs := []tempo.WorklogResponse{
+		{StartDate: "2025-01-06", TimeSpentSeconds: 18000},
+		{StartDate: "2025-01-06", TimeSpentSeconds: 10800},
+		{StartDate: "2025-01-07", TimeSpentSeconds: 21600},
+	}
+
+	problems := timesheetProblems(schedule, worklogs, nil)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "2025-01-07: logged 6h of 8h") {
+		t.Errorf("expected only 2025-01-07 to be short, got %v", problems)
+	}
+
+	problems = timesheetProblems(nil, nil, []storage.TimeEntry{{ID: 1}, {ID: 2}})
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "2 entries are not synced") {
+		t.Errorf("expected unsynced entries to be reported, got %v", problems)
+	}
+}
+
+func TestResolvePeriod(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		period := tempo.Period{From: "2025-01-13", To: "2025-01-19"}
+		if r.URL.Query().Get("from") < "2025-01-13" {
+			period = tempo.Period{From: "2025-01-06", To: "2025-01-12"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"periods": []tempo.Period{period}})
+	}))
+	defer server.Close()
+
+	client := tempo.NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"current", "2025-01-13"},
+		{"previous", "2025-01-06"},
+		{"2025-01-08", "2025-01-06"},
+	}
+	for _, tt := range tests {
+		period, err := resolvePeriod(client, tt.value, now)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.value, err)
+		}
+		if period.From != tt.want {
+			t.Errorf("%s: expected period from %s, got %s", tt.value, tt.want, period.From)
+		}
+	}
+
+	if _, err := resolvePeriod(client, "last week", now); err == nil {
+		t.Error("expected an error for an invalid period")
+	}
+}
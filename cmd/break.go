@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"html"
+	"strings"
 	"time"
 
 	"tasklog/internal/config"
+	"tasklog/internal/notify"
 	"tasklog/internal/slack"
+	"tasklog/internal/storage"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
@@ -18,8 +23,11 @@ var breakCmd = &cobra.Command{
 	Short: "Register a break and update Slack status",
 	Long: `Register a break (e.g., lunch, prayer, coffee) and automatically:
 - Update your Slack status with break emoji
-- Post a message in the configured Slack channel
+- Post a message in the configured Slack channel, and to the notifiers set up for breaks
 - Set status to expire after break duration
+- Pause Slack notifications (Do Not Disturb) for the break
+
+Run 'tasklog back' to end the break early.
 
 Example:
   tasklog break lunch
@@ -31,8 +39,18 @@ Run without arguments to list available breaks.` + configHelp,
 	Run:  runBreak,
 }
 
+var backCmd = &cobra.Command{
+	Use:   "back",
+	Short: "End a break or focus session early",
+	Long: `Ends a break or focus session: turns Slack notifications back on and clears
+your Slack status. A running timer keeps running.` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runBack,
+}
+
 func init() {
 	rootCmd.AddCommand(breakCmd)
+	rootCmd.AddCommand(backCmd)
 }
 
 func runBreak(cmd *cobra.Command, args []string) {
@@ -77,24 +95,21 @@ func runBreak(cmd *cobra.Command, args []string) {
 			Msg("Break not found in configuration. Please add it to your config.yaml")
 	}
 
-	// Check if Slack is configured
-	if cfg.Slack.UserToken == "" || cfg.Slack.ChannelID == "" {
-		log.Warn().Msg("Slack not configured. Break registered but Slack status not updated.")
+	notifiers, err := newNotifiers(cfg, notify.EventBreak, breakEntry.Notifiers)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to create notifiers")
+	}
+
+	// Check if anything is configured to notify
+	if len(notifiers) == 0 && cfg.Slack.UserToken == "" {
+		log.Warn().Msg("No notifiers configured. Break registered but nobody was notified.")
 		fmt.Printf("⏸️  Taking a %s break for %d minutes\n", breakName, breakEntry.Duration)
 		return
 	}
 
-	// Create Slack client
-	slackClient := slack.NewClient(cfg.Slack.UserToken, cfg.Slack.ChannelID)
-
 	// Calculate return time
 	returnTime := time.Now().Add(time.Duration(breakEntry.Duration) * time.Minute)
 
-	// Track what succeeded
-	statusUpdated := false
-	messagePosted := false
-
-	// Set Slack status with 5 extra minutes buffer for auto-clear
 	statusText := fmt.Sprintf("On %s break (back at %s)", breakName, returnTime.Format("3:04 PM"))
 	statusEmoji := breakEntry.Emoji
 	if statusEmoji == "" {
@@ -104,70 +119,174 @@ func runBreak(cmd *cobra.Command, args []string) {
 	// Add 5 minutes buffer to auto-clear the status
 	statusExpirationMinutes := breakEntry.Duration + 5
 
-	err = slackClient.SetStatus(statusText, statusEmoji, statusExpirationMinutes)
-	if err != nil {
-		log.Error().Err(err).Str("emoji", statusEmoji).Msg("Failed to update Slack status")
-
-		// If the error is about invalid emoji and we're not already using the default, retry with default
-		if statusEmoji != defaultBreakEmoji &&
-			(err.Error() == "slack API error: profile_status_set_failed_not_valid_emoji" ||
-				err.Error() == "slack API error: profile_status_set_failed_not_emoji_syntax" ||
-				err.Error() == "slack API error: invalid_emoji") {
-			log.Warn().Msg("Invalid emoji detected, retrying with default emoji")
-			err = slackClient.SetStatus(statusText, defaultBreakEmoji, statusExpirationMinutes)
-			if err != nil {
-				log.Error().Err(err).Msg("Failed to update Slack status with default emoji")
-			} else {
-				log.Info().
-					Str("status", statusText).
-					Str("emoji", defaultBreakEmoji).
-					Int("expiration_minutes", statusExpirationMinutes).
-					Msg("Slack status updated with default emoji")
-				statusUpdated = true
-			}
+	message := notify.Message{Text: fmt.Sprintf("🔔 Taking a %s *%s break* — Back in %d minutes at *%s*",
+		statusEmoji,
+		breakName,
+		breakEntry.Duration,
+		returnTime.Format("3:04 PM"))}
+
+	// Track what succeeded per notifier
+	results := make([]string, 0, len(notifiers))
+	for _, n := range notifiers {
+		statusUpdated, statusSupported := setBreakStatus(n, statusText, statusEmoji, statusExpirationMinutes)
+
+		messagePosted := false
+		if err := n.PostMessage(message); err != nil {
+			log.Error().Err(err).Str("notifier", n.name).Msg("Failed to post break message")
+		} else {
+			log.Info().Str("notifier", n.name).Str("message", message.Text).Msg("Break message posted")
+			messagePosted = true
 		}
-	} else {
+
+		results = append(results, describeNotification(n.name, statusSupported, statusUpdated, messagePosted))
+	}
+
+	// Pause notifications for the break
+	dndSet := false
+	if cfg.Slack.UserToken != "" {
+		slackClient := newSlackClient(cfg, cfg.Slack.ChannelID)
+		if err := slackClient.SetSnooze(breakEntry.Duration); err != nil {
+			log.Error().Err(err).Msg("Failed to turn on Slack Do Not Disturb")
+		} else {
+			log.Info().Int("minutes", breakEntry.Duration).Msg("Slack Do Not Disturb turned on")
+			dndSet = true
+		}
+	}
+
+	// Display success message with accurate status
+	fmt.Printf("✅ Break registered: %s (%d minutes)\n", breakName, breakEntry.Duration)
+	fmt.Printf("📅 Return time: %s\n", returnTime.Format("3:04 PM"))
+	for _, result := range results {
+		fmt.Println(result)
+	}
+
+	if dndSet {
+		fmt.Printf("🔕 Notifications paused until %s\n", returnTime.Format("3:04 PM"))
+	} else if cfg.Slack.UserToken != "" {
+		fmt.Printf("⚠️  Notifications not paused (the Slack token needs the dnd:write scope)\n")
+	}
+}
+
+// setBreakStatus sets the break status on a notifier, retrying with the default emoji when Slack rejects the emoji
+// Returns whether the status was set and whether the notifier supports statuses at all
+func setBreakStatus(n namedNotifier, statusText, statusEmoji string, expirationMinutes int) (bool, bool) {
+	err := n.SetStatus(statusText, statusEmoji, expirationMinutes)
+	if isUnsupported(err) {
+		return false, false
+	}
+	if err == nil {
 		log.Info().
+			Str("notifier", n.name).
 			Str("status", statusText).
 			Str("emoji", statusEmoji).
-			Int("expiration_minutes", statusExpirationMinutes).
-			Msg("Slack status updated")
-		statusUpdated = true
+			Int("expiration_minutes", expirationMinutes).
+			Msg("Status updated")
+		return true, true
 	}
 
-	// Post message to channel
-	emojiForMessage := breakEntry.Emoji
-	if emojiForMessage == "" {
-		emojiForMessage = defaultBreakEmoji
+	log.Error().Err(err).Str("notifier", n.name).Str("emoji", statusEmoji).Msg("Failed to update status")
+
+	// If the error is about invalid emoji and we're not already using the default, retry with default
+	if statusEmoji != defaultBreakEmoji && errors.Is(err, slack.ErrInvalidEmoji) {
+		log.Warn().Msg("Invalid emoji detected, retrying with default emoji")
+		if err := n.SetStatus(statusText, defaultBreakEmoji, expirationMinutes); err != nil {
+			log.Error().Err(err).Msg("Failed to update status with default emoji")
+			return false, true
+		}
+		log.Info().
+			Str("notifier", n.name).
+			Str("status", statusText).
+			Str("emoji", defaultBreakEmoji).
+			Int("expiration_minutes", expirationMinutes).
+			Msg("Status updated with default emoji")
+		return true, true
 	}
-	message := fmt.Sprintf("🔔 Taking a %s *%s break* — Back in %d minutes at *%s*",
-		emojiForMessage,
-		breakName,
-		breakEntry.Duration,
-		returnTime.Format("3:04 PM"))
+	return false, true
+}
 
-	err = slackClient.PostMessage(message)
+// describeNotification summarizes what a notifier did for a break
+func describeNotification(name string, statusSupported, statusUpdated, messagePosted bool) string {
+	switch {
+	case !statusSupported && messagePosted:
+		return fmt.Sprintf("💬 %s updated: Message posted", name)
+	case !statusSupported:
+		return fmt.Sprintf("⚠️  %s update failed", name)
+	case statusUpdated && messagePosted:
+		return fmt.Sprintf("💬 %s updated: Status set and message posted", name)
+	case messagePosted:
+		return fmt.Sprintf("💬 %s updated: Message posted (status not updated)", name)
+	case statusUpdated:
+		return fmt.Sprintf("💬 %s updated: Status set (message failed)", name)
+	default:
+		return fmt.Sprintf("⚠️  %s update failed", name)
+	}
+}
+
+func runBack(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
 	if err != nil {
-		log.Error().Err(err).Msg("Failed to post message to Slack")
-	} else {
-		log.Info().
-			Str("channel", cfg.Slack.ChannelID).
-			Str("message", message).
-			Msg("Message posted to Slack")
-		messagePosted = true
+		return err
 	}
 
-	// Display success message with accurate status
-	fmt.Printf("✅ Break registered: %s (%d minutes)\n", breakName, breakEntry.Duration)
-	fmt.Printf("📅 Return time: %s\n", returnTime.Format("3:04 PM"))
+	if cfg.Slack.UserToken == "" {
+		return fmt.Errorf("slack.user_token is not configured")
+	}
 
-	if statusUpdated && messagePosted {
-		fmt.Printf("💬 Slack updated: Status set and message posted\n")
-	} else if messagePosted {
-		fmt.Printf("💬 Slack updated: Message posted (status not updated)\n")
-	} else if statusUpdated {
-		fmt.Printf("💬 Slack updated: Status set (message failed)\n")
+	slackClient := newSlackClient(cfg, cfg.Slack.ChannelID)
+	if err := slackClient.EndSnooze(); err != nil {
+		log.Error().Err(err).Msg("Failed to turn off Slack Do Not Disturb")
+		fmt.Printf("⚠️  Failed to turn notifications back on: %v\n", err)
 	} else {
-		fmt.Printf("⚠️  Slack update failed\n")
+		fmt.Println("🔔 Notifications back on")
+	}
+
+	// Only clear a break or focus status, never one set by hand
+	// When the status cannot be read (the token lacks users.profile:read), it is cleared regardless
+	clearStatus, statusFree := true, true
+	text, emoji, err := slackClient.GetStatus()
+	switch {
+	case err != nil:
+		log.Debug().Err(err).Msg("Failed to read Slack status, clearing it")
+	case text == "" && emoji == "":
+		clearStatus = false
+	case !isBackStatus(text, emoji):
+		log.Debug().Str("status", text).Msg("Slack status is not a break or focus status, leaving it")
+		clearStatus, statusFree = false, false
+	}
+
+	if clearStatus {
+		if err := slackClient.ClearStatus(); err != nil {
+			log.Error().Err(err).Msg("Failed to clear Slack status")
+			fmt.Printf("⚠️  Failed to clear Slack status: %v\n", err)
+		} else {
+			fmt.Println("✓ Slack status cleared")
+		}
+	}
+
+	// Show the running timer again when the working status is enabled
+	if cfg.Slack.WorkStatus.Enabled && statusFree {
+		store, err := storage.NewStorage(cfg.Database.Path)
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+		defer store.Close()
+
+		if timer, err := store.GetActiveTimer(); err != nil {
+			log.Debug().Err(err).Msg("Failed to read the running timer")
+		} else if timer != nil {
+			setWorkStatus(cfg, timer.IssueKey, timer.IssueSummary, 0)
+		}
+	}
+	return nil
+}
+
+// isBackStatus reports whether a Slack status is a break or focus status that 'tasklog back' ends
+// Slack returns status texts HTML-escaped
+func isBackStatus(text, emoji string) bool {
+	text = html.UnescapeString(text)
+	if strings.HasPrefix(text, "On ") && strings.Contains(text, " break (back at ") {
+		return true
 	}
+	return emoji == focusEmoji && strings.HasPrefix(text, "Focusing until ")
 }
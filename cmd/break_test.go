@@ -0,0 +1,200 @@
+package cmd
+
+import (
+	"encoding/json"
+	"html"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"tasklog/internal/config"
+)
+
+// fakeSlack is a Slack Web API recording the calls made to it and keeping the user's status
+type fakeSlack struct {
+	mu          sync.Mutex
+	calls       []fakeSlackCall
+	statusText  string
+	statusEmoji string
+}
+
+type fakeSlackCall struct {
+	method string
+	body   string
+}
+
+func (f *fakeSlack) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	method := filepath.Base(r.URL.Path)
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls = append(f.calls, fakeSlackCall{method: method, body: string(body)})
+
+	if r.Header.Get("Authorization") != "Bearer xoxp-test" {
+		json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": "invalid_auth"})
+		return
+	}
+
+	switch method {
+	case "users.profile.set":
+		var payload struct {
+			Profile struct {
+				StatusText  string `json:"status_text"`
+				StatusEmoji string `json:"status_emoji"`
+			} `json:"profile"`
+		}
+		json.Unmarshal(body, &payload)
+		// Reject custom emoji like Slack does when they do not exist in the workspace
+		if strings.HasPrefix(payload.Profile.StatusEmoji, ":custom") {
+			json.NewEncoder(w).Encode(map[string]any{"ok": false, "error": "profile_status_set_failed_not_valid_emoji"})
+			return
+		}
+		f.statusText, f.statusEmoji = payload.Profile.StatusText, payload.Profile.StatusEmoji
+	case "users.profile.get":
+		json.NewEncoder(w).Encode(map[string]any{"ok": true, "profile": map[string]string{
+			"status_text":  html.EscapeString(f.statusText),
+			"status_emoji": f.statusEmoji,
+		}})
+		return
+	}
+	json.NewEncoder(w).Encode(map[string]any{"ok": true})
+}
+
+// methods returns the API methods called, in order
+func (f *fakeSlack) methods() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	methods := make([]string, len(f.calls))
+	for i, call := range f.calls {
+		methods[i] = call.method
+	}
+	return methods
+}
+
+// status returns the user's current status text and emoji
+func (f *fakeSlack) status() (string, string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.statusText, f.statusEmoji
+}
+
+// newFakeSlack starts a fake Slack and points the config at it
+func newFakeSlack(t *testing.T, cfg *config.Config) *fakeSlack {
+	t.Helper()
+	fake := &fakeSlack{}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	cfg.Slack.UserToken = "xoxp-test"
+	cfg.Slack.ChannelID = "C123"
+	cfg.Slack.BaseURL = server.URL
+	return fake
+}
+
+func TestRunBreak_Slack(t *testing.T) {
+	fake := &fakeSlack{}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := `jira:
+  url: "https://example.atlassian.net"
+  username: "me@example.com"
+  api_token: "token"
+  project_key: "PROJ"
+database:
+  path: "` + filepath.Join(dir, "tasklog.db") + `"
+slack:
+  user_token: "xoxp-test"
+  channel_id: "C123"
+  base_url: "` + server.URL + `"
+  breaks:
+    - name: "lunch"
+      duration: 60
+      emoji: ":custom_lunch:"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("TASKLOG_CONFIG", configPath)
+
+	runBreak(breakCmd, []string{"lunch"})
+
+	methods := fake.methods()
+	want := []string{"users.profile.set", "users.profile.set", "chat.postMessage", "dnd.setSnooze"}
+	if len(methods) != len(want) {
+		t.Fatalf("calls = %v, want %v", methods, want)
+	}
+	for i := range want {
+		if methods[i] != want[i] {
+			t.Fatalf("calls = %v, want %v", methods, want)
+		}
+	}
+
+	var message map[string]any
+	if err := json.Unmarshal([]byte(fake.calls[2].body), &message); err != nil {
+		t.Fatalf("invalid message payload: %v", err)
+	}
+	if message["channel"] != "C123" {
+		t.Errorf("message posted to %v, want C123", message["channel"])
+	}
+
+	snooze, err := url.ParseQuery(fake.calls[3].body)
+	if err != nil || snooze.Get("num_minutes") != "60" {
+		t.Errorf("snooze = %q, want 60 minutes", fake.calls[3].body)
+	}
+}
+
+func TestRunBack_StatusSetByHand(t *testing.T) {
+	cfg := &config.Config{}
+	fake := newFakeSlack(t, cfg)
+
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.yaml")
+	config := `jira:
+  url: "https://example.atlassian.net"
+  username: "me@example.com"
+  api_token: "token"
+  project_key: "PROJ"
+database:
+  path: "` + filepath.Join(dir, "tasklog.db") + `"
+slack:
+  user_token: "xoxp-test"
+  channel_id: "C123"
+  base_url: "` + cfg.Slack.BaseURL + `"
+`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("TASKLOG_CONFIG", configPath)
+
+	slackClient := newSlackClient(cfg, cfg.Slack.ChannelID)
+	if err := slackClient.SetStatus("OOO", ":palm_tree:", 0); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+	if err := runBack(backCmd, nil); err != nil {
+		t.Fatalf("runBack failed: %v", err)
+	}
+	if text, emoji := fake.status(); text != "OOO" || emoji != ":palm_tree:" {
+		t.Errorf("expected the status set by hand to be kept, got %q %q", text, emoji)
+	}
+
+	// A break status is cleared
+	if err := slackClient.SetStatus("On lunch break (back at 1:30 PM)", ":fork_and_knife:", 65); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+	if err := runBack(backCmd, nil); err != nil {
+		t.Fatalf("runBack failed: %v", err)
+	}
+	if text, emoji := fake.status(); text != "" || emoji != "" {
+		t.Errorf("expected the break status to be cleared, got %q %q", text, emoji)
+	}
+}
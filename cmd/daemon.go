@@ -0,0 +1,390 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	str2duration "github.com/xhit/go-str2duration/v2"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/lock"
+	"tasklog/internal/schedule"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+// daemonTick is how often the daemon checks for new entries and due reminders
+const daemonTick = 30 * time.Second
+
+// reminderGrace is how late a reminder may still fire, e.g. after the machine wakes up from sleep
+const reminderGrace = 15 * time.Minute
+
+var (
+	daemonLogFile     string
+	daemonUnitInstall bool
+)
+
+var daemonCmd = &cobra.Command{
+	Use:   "daemon",
+	Short: "Sync entries, refresh the issue cache and fire reminders in the background",
+	Long: `Runs until stopped, and in the background:
+
+  - retries unsynced entries every daemon.sync_interval, and as soon as new
+    entries are added (for example, entries logged offline)
+  - refreshes the issue cache every daemon.refresh_interval
+  - fires the desktop reminders configured in daemon.reminders
+
+Only one daemon runs at a time, and it never sends entries while 'tasklog sync'
+or 'tasklog log' is sending them. It logs to daemon.log_file
+(default: ~/.tasklog/daemon.log).
+
+To start the daemon with your session, install the systemd user unit:
+  tasklog daemon unit --install
+
+Examples:
+  tasklog daemon                  # Run in the foreground
+  tasklog daemon --log-file -     # Log to stderr instead of the log file` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runDaemon,
+}
+
+var daemonUnitCmd = &cobra.Command{
+	Use:   "unit",
+	Short: "Print or install a systemd user unit that runs the daemon",
+	Long: `Prints a systemd user unit that starts 'tasklog daemon' with your session.
+
+With --install, the unit is written to ~/.config/systemd/user/tasklog.service.
+
+Examples:
+  tasklog daemon unit             # Print the unit
+  tasklog daemon unit --install   # Install it, then enable it with systemctl` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runDaemonUnit,
+}
+
+func init() {
+	rootCmd.AddCommand(daemonCmd)
+	daemonCmd.AddCommand(daemonUnitCmd)
+
+	daemonCmd.Flags().StringVar(&daemonLogFile, "log-file", "", "Log file (default: daemon.log_file), - for stderr")
+	daemonUnitCmd.Flags().BoolVar(&daemonUnitInstall, "install", false, "Write the unit to ~/.config/systemd/user")
+}
+
+// daemon holds the state of a running 'tasklog daemon'
+type daemon struct {
+	cfg             *config.Config
+	store           *storage.Storage
+	jiraClient      *jira.Client
+	syncInterval    time.Duration
+	refreshInterval time.Duration
+	reminders       []reminder
+	notify          func(title, message string) error
+
+	lastEntryID int64
+	lastSync    time.Time
+	lastRefresh time.Time
+	lastCheck   time.Time
+}
+
+// reminder is a parsed daemon.reminders entry
+type reminder struct {
+	config.ReminderEntry
+	sched     *schedule.Schedule
+	minLogged int // Seconds, 0 to always remind
+}
+
+func runDaemon(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	daemonLock, err := lock.Acquire(filepath.Join(filepath.Dir(cfg.Database.Path), "daemon.lock"))
+	if errors.Is(err, lock.ErrLocked) {
+		return fmt.Errorf("tasklog daemon is already running")
+	}
+	if err != nil {
+		return err
+	}
+	defer daemonLock.Release()
+
+	d, err := newDaemon(cfg, store, jiraClient, time.Now())
+	if err != nil {
+		return err
+	}
+
+	logFile := cfg.Daemon.LogFile
+	if daemonLogFile != "" {
+		logFile = daemonLogFile
+	}
+	if logFile != "-" {
+		closeLog, err := logToFile(logFile)
+		if err != nil {
+			return err
+		}
+		defer closeLog()
+		fmt.Printf("tasklog daemon running, logging to %s\n", logFile)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	log.Info().
+		Str("sync_interval", d.syncInterval.String()).
+		Str("refresh_interval", d.refreshInterval.String()).
+		Int("reminders", len(d.reminders)).
+		Msg("Daemon started")
+
+	d.tick(time.Now())
+
+	ticker := time.NewTicker(daemonTick)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Info().Msg("Daemon stopped")
+			return nil
+		case now := <-ticker.C:
+			d.tick(now)
+		}
+	}
+}
+
+// newDaemon parses the daemon settings; reminders are due from now on
+func newDaemon(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, now time.Time) (*daemon, error) {
+	syncInterval, err := str2duration.ParseDuration(cfg.Daemon.SyncInterval)
+	if err != nil || syncInterval <= 0 {
+		return nil, fmt.Errorf("invalid daemon.sync_interval %q", cfg.Daemon.SyncInterval)
+	}
+	refreshInterval, err := str2duration.ParseDuration(cfg.Daemon.RefreshInterval)
+	if err != nil || refreshInterval <= 0 {
+		return nil, fmt.Errorf("invalid daemon.refresh_interval %q", cfg.Daemon.RefreshInterval)
+	}
+
+	reminders := make([]reminder, 0, len(cfg.Daemon.Reminders))
+	for _, entry := range cfg.Daemon.Reminders {
+		sched, err := parseSchedule(&entry.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("reminder '%s': %w", entry.Name, err)
+		}
+		r := reminder{ReminderEntry: entry, sched: sched}
+		if entry.MinLogged != "" {
+			if r.minLogged, err = timeparse.Parse(entry.MinLogged); err != nil {
+				return nil, fmt.Errorf("reminder '%s': invalid min_logged: %w", entry.Name, err)
+			}
+		}
+		reminders = append(reminders, r)
+	}
+
+	return &daemon{
+		cfg:             cfg,
+		store:           store,
+		jiraClient:      jiraClient,
+		syncInterval:    syncInterval,
+		refreshInterval: refreshInterval,
+		reminders:       reminders,
+		notify:          notifyDesktop,
+		lastCheck:       now,
+	}, nil
+}
+
+// tick syncs when new entries were added or the sync interval passed, refreshes the issue cache
+// when the refresh interval passed, and fires the reminders due since the previous tick
+func (d *daemon) tick(now time.Time) {
+	latestID, err := d.store.LatestEntryID()
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to check for new entries")
+	}
+	if latestID > d.lastEntryID || now.Sub(d.lastSync) >= d.syncInterval {
+		d.sync()
+		d.lastEntryID = latestID
+		d.lastSync = now
+	}
+
+	if now.Sub(d.lastRefresh) >= d.refreshInterval {
+		if count, err := refreshIssueCache(d.cfg, d.store, d.jiraClient); err != nil {
+			log.Error().Err(err).Msg("Failed to refresh issue cache")
+		} else {
+			log.Debug().Int("count", count).Msg("Refreshed issue cache")
+		}
+		d.lastRefresh = now
+	}
+
+	d.fireReminders(d.lastCheck, now)
+	d.lastCheck = now
+}
+
+// sync retries the unsynced entries, skipping this round when another process is sending entries
+func (d *daemon) sync() {
+	synced, unsynced, err := syncAndCount(d.cfg, d.store, d.jiraClient, io.Discard)
+	if errors.Is(err, errSyncRunning) {
+		log.Info().Msg("Another tasklog process is sending entries, skipping this sync")
+		return
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("Sync failed")
+		return
+	}
+
+	if synced > 0 || unsynced > 0 {
+		log.Info().Int("synced", synced).Int("unsynced", unsynced).Msg("Synced entries")
+	}
+}
+
+// fireReminders shows the reminders scheduled in (from, to], skipping holidays, runs older than
+// reminderGrace, and reminders whose min_logged is already reached today
+func (d *daemon) fireReminders(from, to time.Time) {
+	for _, r := range d.reminders {
+		runs := r.sched.Between(from, to)
+		if len(runs) == 0 {
+			continue
+		}
+		runAt := runs[len(runs)-1]
+		if to.Sub(runAt) > reminderGrace {
+			log.Debug().Str("reminder", r.Name).Time("due", runAt).Msg("Skipping missed reminder")
+			continue
+		}
+		if d.cfg.IsHoliday(runAt) {
+			log.Debug().Str("reminder", r.Name).Msg("Skipping reminder on holiday")
+			continue
+		}
+
+		message := r.Message
+		if r.minLogged > 0 {
+			total, err := d.store.GetTodayTotalSeconds()
+			if err != nil {
+				log.Error().Err(err).Str("reminder", r.Name).Msg("Failed to get today's total")
+				continue
+			}
+			if total >= r.minLogged {
+				log.Debug().Str("reminder", r.Name).Msg("Skipping reminder, enough time logged")
+				continue
+			}
+			message = fmt.Sprintf("%s\nLogged today: %s of %s", message, timeparse.Format(total), timeparse.Format(r.minLogged))
+		}
+
+		log.Info().Str("reminder", r.Name).Msg("Firing reminder")
+		if err := d.notify("tasklog", message); err != nil {
+			log.Warn().Err(err).Str("reminder", r.Name).Msg("Failed to show reminder")
+		}
+	}
+}
+
+// logToFile sends all further log output to the given file, appending to it
+// Returns a function that closes the file
+func logToFile(path string) (func(), error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, fmt.Errorf("failed to create log directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+	log.Logger = zerolog.New(file).With().Timestamp().Logger()
+	return func() { _ = file.Close() }, nil
+}
+
+// notifyDesktop shows a desktop notification with notify-send on Linux or osascript on macOS
+func notifyDesktop(title, message string) error {
+	var notifier *exec.Cmd
+	switch runtime.GOOS {
+	case "linux":
+		notifier = exec.Command("notify-send", "--app-name=tasklog", title, message)
+	case "darwin":
+		script := fmt.Sprintf("display notification %s with title %s", appleScriptString(message), appleScriptString(title))
+		notifier = exec.Command("osascript", "-e", script)
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+
+	if out, err := notifier.CombinedOutput(); err != nil {
+		return fmt.Errorf("%s failed: %w: %s", filepath.Base(notifier.Path), err, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
+
+// appleScriptString quotes s as an AppleScript string literal
+func appleScriptString(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+func runDaemonUnit(cmd *cobra.Command, args []string) error {
+	executable, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("failed to locate the tasklog binary: %w", err)
+	}
+	unit := systemdUnit(executable, os.Getenv("TASKLOG_CONFIG"))
+
+	if !daemonUnitInstall {
+		fmt.Print(unit)
+		return nil
+	}
+
+	if runtime.GOOS != "linux" {
+		return fmt.Errorf("systemd units are only supported on Linux")
+	}
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return fmt.Errorf("failed to get config directory: %w", err)
+	}
+	unitDir := filepath.Join(configDir, "systemd", "user")
+	if err := os.MkdirAll(unitDir, 0755); err != nil {
+		return fmt.Errorf("failed to create %s: %w", unitDir, err)
+	}
+	unitPath := filepath.Join(unitDir, "tasklog.service")
+	if err := os.WriteFile(unitPath, []byte(unit), 0644); err != nil {
+		return fmt.Errorf("failed to write unit: %w", err)
+	}
+
+	fmt.Printf("✓ Installed %s\n\n", unitPath)
+	fmt.Println("Start it now and with every login:")
+	fmt.Println("  systemctl --user daemon-reload")
+	fmt.Println("  systemctl --user enable --now tasklog")
+	return nil
+}
+
+// systemdUnit returns a systemd user unit running the daemon, passing TASKLOG_CONFIG along when set
+func systemdUnit(executable, configPath string) string {
+	var b strings.Builder
+	b.WriteString("[Unit]\n")
+	b.WriteString("Description=tasklog background sync and reminders\n")
+	b.WriteString("After=network-online.target\n")
+	b.WriteString("\n[Service]\n")
+	fmt.Fprintf(&b, "ExecStart=%s daemon\n", executable)
+	if configPath != "" {
+		fmt.Fprintf(&b, "Environment=TASKLOG_CONFIG=%s\n", configPath)
+	}
+	b.WriteString("Restart=on-failure\n")
+	b.WriteString("RestartSec=30\n")
+	b.WriteString("\n[Install]\n")
+	b.WriteString("WantedBy=default.target\n")
+	return b.String()
+}
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+)
+
+func testDaemonConfig() *config.Config {
+	return &config.Config{
+		Daemon: config.DaemonConfig{
+			SyncInterval:    "5m",
+			RefreshInterval: "15m",
+			Reminders: []config.ReminderEntry{
+				{
+					Name:      "end-of-day",
+					Message:   "Log your time",
+					MinLogged: "8h",
+					Schedule:  config.ScheduleEntry{At: "17:30"},
+				},
+			},
+		},
+	}
+}
+
+func TestNewDaemon_Invalid(t *testing.T) {
+	cfg := testDaemonConfig()
+	cfg.Daemon.SyncInterval = "often"
+	if _, err := newDaemon(cfg, nil, nil, time.Now()); err == nil {
+		t.Error("expected an error for an invalid sync interval")
+	}
+
+	cfg = testDaemonConfig()
+	cfg.Daemon.Reminders[0].Schedule = config.ScheduleEntry{}
+	if _, err := newDaemon(cfg, nil, nil, time.Now()); err == nil {
+		t.Error("expected an error for a reminder without a schedule")
+	}
+}
+
+func TestDaemon_FireReminders(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	due := time.Date(now.Year(), now.Month(), now.Day(), 17, 30, 0, 0, now.Location())
+
+	d, err := newDaemon(testDaemonConfig(), store, nil, due.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var messages []string
+	d.notify = func(title, message string) error {
+		messages = append(messages, message)
+		return nil
+	}
+
+	// Not due yet
+	d.fireReminders(due.Add(-2*time.Minute), due.Add(-time.Minute))
+	if len(messages) != 0 {
+		t.Fatalf("expected no reminder before it is due, got %v", messages)
+	}
+
+	d.fireReminders(due.Add(-time.Minute), due.Add(time.Minute))
+	if len(messages) != 1 || !strings.HasPrefix(messages[0], "Log your time") {
+		t.Fatalf("expected one reminder, got %v", messages)
+	}
+
+	// Missed while asleep
+	d.fireReminders(due.Add(-time.Minute), due.Add(time.Hour))
+	if len(messages) != 1 {
+		t.Errorf("expected a reminder missed by more than the grace period to be skipped, got %v", messages)
+	}
+}
+
+func TestDaemon_FireReminders_MinLogged(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.AddTimeEntry(&storage.TimeEntry{
+		IssueKey:         "PROJ-1",
+		IssueSummary:     "Task",
+		TimeSpentSeconds: 8 * 3600,
+		TimeSpent:        "8h",
+		Started:          startOfDay(time.Now()).Add(time.Hour),
+	}); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+
+	now := time.Now()
+	due := time.Date(now.Year(), now.Month(), now.Day(), 17, 30, 0, 0, now.Location())
+	d, err := newDaemon(testDaemonConfig(), store, nil, due.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	d.notify = func(title, message string) error {
+		t.Errorf("expected no reminder once min_logged is reached, got %q", message)
+		return nil
+	}
+
+	d.fireReminders(due.Add(-time.Minute), due.Add(time.Minute))
+}
+
+func TestSystemdUnit(t *testing.T) {
+	unit := systemdUnit("/usr/local/bin/tasklog", "")
+	if !strings.Contains(unit, "ExecStart=/usr/local/bin/tasklog daemon\n") {
+		t.Errorf("expected ExecStart to run the daemon, got:\n%s", unit)
+	}
+	if strings.Contains(unit, "TASKLOG_CONFIG") {
+		t.Errorf("expected no TASKLOG_CONFIG when unset, got:\n%s", unit)
+	}
+
+	unit = systemdUnit("/usr/local/bin/tasklog", "/home/me/tasklog.yaml")
+	if !strings.Contains(unit, "Environment=TASKLOG_CONFIG=/home/me/tasklog.yaml\n") {
+		t.Errorf("expected TASKLOG_CONFIG to be passed along, got:\n%s", unit)
+	}
+}
+
+func TestAppleScriptString(t *testing.T) {
+	if got := appleScriptString(`Say "hi" \o/`); got != `"Say \"hi\" \\o/"` {
+		t.Errorf("unexpected quoting: %s", got)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+const focusEmoji = ":headphones:"
+
+var (
+	focusLabel   string
+	focusNoTimer bool
+)
+
+var focusCmd = &cobra.Command{
+	Use:   "focus <duration> [task]",
+	Short: "Start a focus session with Slack notifications paused",
+	Long: `Starts a focus session:
+- Pause Slack notifications (Do Not Disturb) for the duration
+- Set a focus status that expires with the session
+- Start a timer on the task, or a selected one
+
+Run 'tasklog back' to end the session early, and 'tasklog timer stop' to log the time.
+
+Examples:
+  tasklog focus 90m              # Focus for 90 minutes on a selected task
+  tasklog focus 2h PROJ-123      # Focus for 2 hours on PROJ-123
+  tasklog focus 45m --no-timer   # Only pause notifications` + configHelp,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runFocus,
+}
+
+func init() {
+	rootCmd.AddCommand(focusCmd)
+
+	focusCmd.Flags().StringVarP(&focusLabel, "label", "l", "", "Work log label (prompted on stop if not set)")
+	focusCmd.Flags().BoolVar(&focusNoTimer, "no-timer", false, "Do not start a timer")
+}
+
+func runFocus(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	seconds, err := timeparse.Parse(args[0])
+	if err != nil {
+		return fmt.Errorf("invalid duration: %w", err)
+	}
+	minutes := seconds / 60
+	if minutes <= 0 {
+		return fmt.Errorf("the focus session must last at least a minute")
+	}
+	if focusLabel != "" {
+		applyTempoLabels(cfg)
+		if !cfg.IsLabelAllowed(focusLabel) {
+			return fmt.Errorf("label '%s' is not in the allowed labels list", focusLabel)
+		}
+	}
+
+	var timer *storage.Timer
+	if !focusNoTimer {
+		// Initialize clients
+		jiraClient, err := newJiraClient(cfg)
+		if err != nil {
+			return err
+		}
+
+		// Initialize storage
+		store, err := storage.NewStorage(cfg.Database.Path)
+		if err != nil {
+			return fmt.Errorf("failed to initialize storage: %w", err)
+		}
+		defer store.Close()
+
+		if timer, err = startFocusTimer(cfg, store, jiraClient, args[1:]); err != nil {
+			return err
+		}
+		fmt.Printf("⏱️  Timer started on %s - %s\n", timer.IssueKey, timer.IssueSummary)
+	}
+
+	end := time.Now().Add(time.Duration(minutes) * time.Minute)
+
+	if cfg.Slack.UserToken == "" {
+		log.Warn().Msg("Slack not configured. Focus session started but notifications not paused.")
+		fmt.Printf("🎧 Focusing until %s\n", end.Format("3:04 PM"))
+		return nil
+	}
+
+	slackClient := newSlackClient(cfg, cfg.Slack.ChannelID)
+
+	statusSet := true
+	if err := slackClient.SetStatus(fmt.Sprintf("Focusing until %s", end.Format("3:04 PM")), focusEmoji, minutes); err != nil {
+		log.Error().Err(err).Msg("Failed to update Slack status")
+		statusSet = false
+	}
+
+	dndSet := true
+	if err := slackClient.SetSnooze(minutes); err != nil {
+		log.Error().Err(err).Msg("Failed to turn on Slack Do Not Disturb")
+		dndSet = false
+	}
+
+	fmt.Printf("🎧 Focusing until %s\n", end.Format("3:04 PM"))
+	if dndSet {
+		fmt.Printf("🔕 Notifications paused until %s\n", end.Format("3:04 PM"))
+	} else {
+		fmt.Printf("⚠️  Notifications not paused (the Slack token needs the dnd:write scope)\n")
+	}
+	if !statusSet {
+		fmt.Printf("⚠️  Slack status not updated\n")
+	}
+	return nil
+}
+
+// startFocusTimer starts a timer on the task given in args, or a selected one
+func startFocusTimer(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, args []string) (*storage.Timer, error) {
+	// Fail early instead of after task selection
+	if active, err := store.GetActiveTimer(); err != nil {
+		return nil, err
+	} else if active != nil {
+		return nil, fmt.Errorf("timer already running on %s since %s, stop it first or use --no-timer", active.IssueKey, active.Started.Format("15:04"))
+	}
+
+	var issue *jira.Issue
+	var err error
+	if len(args) > 0 {
+		if issue, err = jiraClient.GetIssue(args[0]); err != nil {
+			return nil, fmt.Errorf("failed to fetch task %s: %w", args[0], err)
+		}
+	} else if issue, err = selectTask(cfg, store, jiraClient); err != nil {
+		return nil, err
+	}
+
+	timer := &storage.Timer{
+		IssueKey:     issue.Key,
+		IssueSummary: issue.Fields.Summary,
+		Label:        focusLabel,
+		Started:      time.Now(),
+	}
+	if err := store.StartTimer(timer); err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
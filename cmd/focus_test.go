@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+)
+
+func TestRunFocus_Back(t *testing.T) {
+	fake := &fakeSlack{}
+	slackServer := httptest.NewServer(fake)
+	defer slackServer.Close()
+
+	jiraServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/PROJ-1" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Login fix"}})
+	}))
+	defer jiraServer.Close()
+
+	dir := t.TempDir()
+	dbPath := filepath.Join(dir, "tasklog.db")
+	configPath := filepath.Join(dir, "config.yaml")
+	config := `jira:
+  url: "` + jiraServer.URL + `"
+  username: "me@example.com"
+  api_token: "token"
+  project_key: "PROJ"
+database:
+  path: "` + dbPath + `"
+slack:
+  user_token: "xoxp-test"
+  channel_id: "C123"
+  base_url: "` + slackServer.URL + `"
+  work_status:
+    enabled: true
+`
+	if err := os.WriteFile(configPath, []byte(config), 0600); err != nil {
+		t.Fatalf("failed to write config: %v", err)
+	}
+	t.Setenv("TASKLOG_CONFIG", configPath)
+
+	if err := runFocus(focusCmd, []string{"90m", "PROJ-1"}); err != nil {
+		t.Fatalf("runFocus failed: %v", err)
+	}
+
+	methods := fake.methods()
+	if strings.Join(methods, " ") != "users.profile.set dnd.setSnooze" {
+		t.Fatalf("calls = %v, want users.profile.set and dnd.setSnooze", methods)
+	}
+	if text, emoji := fake.status(); emoji != focusEmoji || !strings.HasPrefix(text, "Focusing until") {
+		t.Errorf("status = %q %q, want the focus status", text, emoji)
+	}
+
+	store, err := storage.NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open storage: %v", err)
+	}
+	timer, err := store.GetActiveTimer()
+	store.Close()
+	if err != nil || timer == nil || timer.IssueKey != "PROJ-1" {
+		t.Fatalf("expected a timer on PROJ-1, got %+v (%v)", timer, err)
+	}
+
+	if err := runBack(backCmd, nil); err != nil {
+		t.Fatalf("runBack failed: %v", err)
+	}
+
+	methods = fake.methods()[2:]
+	if strings.Join(methods, " ") != "dnd.endSnooze users.profile.get users.profile.set users.profile.set" {
+		t.Fatalf("calls = %v, want dnd.endSnooze, then the focus status read, cleared and replaced", methods)
+	}
+	if text, emoji := fake.status(); text != "PROJ-1 Login fix" || emoji != ":computer:" {
+		t.Errorf("status = %q %q, want the working status restored", text, emoji)
+	}
+}
+
+func TestStartFocusTimer_TimerRunning(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.StartTimer(&storage.Timer{IssueKey: "PROJ-2", IssueSummary: "Payments", Started: time.Now()}); err != nil {
+		t.Fatalf("StartTimer failed: %v", err)
+	}
+
+	// Jira is not called when a timer is already running
+	jiraClient := jira.NewClient("http://127.0.0.1:0", "me@example.com", "token", "PROJ")
+	_, err = startFocusTimer(&config.Config{}, store, jiraClient, []string{"PROJ-1"})
+	if err == nil || !strings.Contains(err.Error(), "timer already running on PROJ-2") {
+		t.Errorf("expected an error for the running timer, got %v", err)
+	}
+}
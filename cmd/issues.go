@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+)
+
+// maxRecentSuggestions limits how many recently used issues are offered in the task picker
+const maxRecentSuggestions = 5
+
+// maxSearchResults limits how many cached issues are offered when Jira search is unavailable
+const maxSearchResults = 20
+
+var issuesCmd = &cobra.Command{
+	Use:   "issues",
+	Short: "Show the local issue cache",
+	Long: `Shows the issues cached locally for task selection: your in-progress issues and
+the issues you logged time to most recently.
+
+'tasklog log' serves the task picker from this cache instantly and refreshes it in
+the background, so task selection also works offline.
+
+Examples:
+  tasklog issues           # Show cached issues
+  tasklog issues refresh   # Fetch in-progress issues from Jira now` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runIssues,
+}
+
+var issuesRefreshCmd = &cobra.Command{
+	Use:   "refresh",
+	Short: "Refresh the local issue cache from Jira",
+	Long:  `Fetches your in-progress issues from Jira and updates the local issue cache.` + configHelp,
+	Args:  cobra.NoArgs,
+	RunE:  runIssuesRefresh,
+}
+
+func init() {
+	rootCmd.AddCommand(issuesCmd)
+	issuesCmd.AddCommand(issuesRefreshCmd)
+}
+
+func runIssues(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	inProgress, err := store.GetInProgressCachedIssues()
+	if err != nil {
+		return err
+	}
+	recent, err := store.GetRecentIssues(maxRecentSuggestions)
+	if err != nil {
+		return err
+	}
+
+	if len(inProgress) == 0 && len(recent) == 0 {
+		fmt.Println("The issue cache is empty, run 'tasklog issues refresh'")
+		return nil
+	}
+
+	fmt.Println("In progress:")
+	if len(inProgress) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, issue := range inProgress {
+		fmt.Printf("  %-12s %-50.50s %s\n", issue.Key, issue.Summary, issue.Status)
+	}
+
+	fmt.Println("\nRecently used:")
+	if len(recent) == 0 {
+		fmt.Println("  (none)")
+	}
+	for _, issue := range recent {
+		fmt.Printf("  %-12s %-50.50s %s\n", issue.Key, issue.Summary, issue.LastUsed.Local().Format("Mon Jan 2 15:04"))
+	}
+
+	if updated := lastCacheUpdate(inProgress); !updated.IsZero() {
+		fmt.Printf("\nLast refreshed %s\n", updated.Local().Format("Mon Jan 2 15:04"))
+	}
+	return nil
+}
+
+func runIssuesRefresh(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	count, err := refreshIssueCache(cfg, store, jiraClient)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Cached %d in-progress issues\n", count)
+	return nil
+}
+
+// refreshIssueCache fetches the in-progress issues from Jira and stores them in the issue cache
+// Returns the number of issues cached
+func refreshIssueCache(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client) (int, error) {
+	log.Debug().Msg("Refreshing issue cache")
+	issues, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch in-progress tasks: %w", err)
+	}
+
+	cached := make([]storage.CachedIssue, len(issues))
+	for i, issue := range issues {
+		cached[i] = toCachedIssue(issue)
+	}
+	if err := store.SetInProgressIssues(cached); err != nil {
+		return 0, err
+	}
+	return len(issues), nil
+}
+
+// toCachedIssue converts a Jira issue for the issue cache
+func toCachedIssue(issue jira.Issue) storage.CachedIssue {
+	return storage.CachedIssue{
+		Key:     issue.Key,
+		Summary: issue.Fields.Summary,
+		Status:  issue.Fields.Status.Name,
+		Project: issueProject(issue),
+	}
+}
+
+// fromCachedIssue converts a cached issue back to the Jira issue shown by the task picker
+func fromCachedIssue(issue storage.CachedIssue) jira.Issue {
+	return jira.Issue{
+		Key: issue.Key,
+		Fields: jira.IssueFields{
+			Summary: issue.Summary,
+			Status:  jira.IssueStatus{Name: issue.Status},
+		},
+	}
+}
+
+// fromCachedIssues converts cached issues back to Jira issues
+func fromCachedIssues(cached []storage.CachedIssue) []jira.Issue {
+	issues := make([]jira.Issue, len(cached))
+	for i, issue := range cached {
+		issues[i] = fromCachedIssue(issue)
+	}
+	return issues
+}
+
+// issueProject returns the project key of an issue, from its fields or else from its key
+func issueProject(issue jira.Issue) string {
+	if issue.Fields.Project != nil && issue.Fields.Project.Key != "" {
+		return issue.Fields.Project.Key
+	}
+	project, _, _ := strings.Cut(issue.Key, "-")
+	return project
+}
+
+// lastCacheUpdate returns the most recent time one of the issues was fetched from Jira
+func lastCacheUpdate(issues []storage.CachedIssue) (updated time.Time) {
+	for _, issue := range issues {
+		if issue.UpdatedAt.After(updated) {
+			updated = issue.UpdatedAt
+		}
+	}
+	return updated
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"tasklog/internal/jira"
+)
+
+func TestIssueProject(t *testing.T) {
+	withProject := jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Project: &jira.IssueProject{ID: "10000", Key: "OTHER"}}}
+	if got := issueProject(withProject); got != "OTHER" {
+		t.Errorf("expected project from fields, got %q", got)
+	}
+	if got := issueProject(jira.Issue{Key: "PROJ-1"}); got != "PROJ" {
+		t.Errorf("expected project from key, got %q", got)
+	}
+}
+
+func TestCachedIssueRoundTrip(t *testing.T) {
+	issue := jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Fix login", Status: jira.IssueStatus{Name: "In Progress"}}}
+
+	got := fromCachedIssue(toCachedIssue(issue))
+	if got.Key != issue.Key || got.Fields.Summary != issue.Fields.Summary || got.Fields.Status.Name != issue.Fields.Status.Name {
+		t.Errorf("expected %+v, got %+v", issue, got)
+	}
+}
@@ -0,0 +1,438 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	str2duration "github.com/xhit/go-str2duration/v2"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+	"tasklog/internal/ui"
+)
+
+// defaultLabelCacheTTL is used when labels.cache_ttl cannot be parsed
+const defaultLabelCacheTTL = 24 * time.Hour
+
+// labelPropertyKey is the worklog entity property that holds the label when labels.propagate is property
+const labelPropertyKey = "tasklog"
+
+var (
+	labelsAttribute string
+	labelsDryRun    bool
+)
+
+var labelsCmd = &cobra.Command{
+	Use:   "labels",
+	Short: "Show or sync the allowed labels",
+	Long: `Shows the labels offered when logging time.
+
+Use 'tasklog labels sync' to copy the values of a Tempo work attribute (such as
+"Work Type") into labels.allowed_labels, or set labels.from_tempo to fetch them
+live, cached for labels.cache_ttl.` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runLabelsShow,
+}
+
+var labelsSyncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Set the allowed labels from a Tempo work attribute",
+	Long: `Fetches a static list work attribute from Tempo and writes its values to
+labels.allowed_labels in your config file, keeping comments and other settings.
+
+The attribute is taken from --attribute, then tempo.label_attribute. If neither is
+set, you can pick one of your static list attributes, and it is saved as
+tempo.label_attribute.
+
+Examples:
+  tasklog labels sync                          # Sync from tempo.label_attribute
+  tasklog labels sync --attribute _WorkType_   # Sync from a specific attribute
+  tasklog labels sync --dry-run                # Show the values without saving` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runLabelsSync,
+}
+
+func init() {
+	rootCmd.AddCommand(labelsCmd)
+	labelsCmd.AddCommand(labelsSyncCmd)
+
+	labelsSyncCmd.Flags().StringVar(&labelsAttribute, "attribute", "", "Tempo work attribute key (e.g., _WorkType_)")
+	labelsSyncCmd.Flags().BoolVar(&labelsDryRun, "dry-run", false, "Show the values without updating the config file")
+}
+
+func runLabelsShow(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	applyTempoLabels(cfg)
+	if len(cfg.Labels.AllowedLabels) == 0 {
+		fmt.Println("No allowed labels configured, any label can be entered.")
+		return nil
+	}
+
+	source := "config"
+	if cfg.Labels.FromTempo {
+		source = fmt.Sprintf("Tempo attribute %s", cfg.Tempo.LabelAttribute)
+	}
+	fmt.Printf("Allowed labels (%s):\n", source)
+	for _, label := range cfg.Labels.AllowedLabels {
+		fmt.Printf("  %s\n", label)
+	}
+	return nil
+}
+
+func runLabelsSync(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Tempo.APIToken == "" {
+		return fmt.Errorf("tempo.api_token is required to fetch work attributes")
+	}
+
+	// Initialize clients
+	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+
+	attributes, err := tempoClient.GetWorkAttributes()
+	if err != nil {
+		return err
+	}
+
+	key := labelsAttribute
+	if key == "" {
+		key = cfg.Tempo.LabelAttribute
+	}
+	if key == "" {
+		if key, err = selectLabelAttribute(attributes); err != nil {
+			return err
+		}
+	}
+
+	values, err := staticListValues(attributes, key)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Values of %s:\n", key)
+	for _, value := range values {
+		fmt.Printf("  %s\n", value)
+	}
+
+	if labelsDryRun {
+		return nil
+	}
+
+	updates := []config.Update{{Path: "labels.allowed_labels", Value: values}}
+	if cfg.Tempo.LabelAttribute == "" {
+		updates = append(updates, config.Update{Path: "tempo.label_attribute", Value: key})
+	}
+
+	configPath, err := config.GetConfigPath()
+	if err != nil {
+		return fmt.Errorf("failed to get config path: %w", err)
+	}
+	if err := config.UpdateFile(configPath, updates...); err != nil {
+		return err
+	}
+
+	// Keep the live list in step with the config
+	saveLabelCache(&labelCache{Attribute: key, Values: values, FetchedAt: time.Now()})
+
+	fmt.Printf("\n✓ Updated %d allowed labels in %s\n", len(values), configPath)
+	return nil
+}
+
+// selectLabelAttribute picks the static list attribute to sync labels from
+func selectLabelAttribute(attributes []tempo.WorkAttribute) (string, error) {
+	var options []string
+	keys := map[string]string{}
+	for _, attribute := range attributes {
+		if attribute.Type != tempo.StaticListType {
+			continue
+		}
+		option := fmt.Sprintf("%s (%s)", attribute.Name, attribute.Key)
+		options = append(options, option)
+		keys[option] = attribute.Key
+	}
+
+	switch len(options) {
+	case 0:
+		return "", fmt.Errorf("no static list work attributes found in Tempo")
+	case 1:
+		return keys[options[0]], nil
+	}
+
+	selected, err := ui.SelectOption("Select the work attribute to use as labels:", options)
+	if err != nil {
+		return "", err
+	}
+	return keys[selected], nil
+}
+
+// staticListValues returns the values of the static list attribute with the given key
+func staticListValues(attributes []tempo.WorkAttribute, key string) ([]string, error) {
+	for _, attribute := range attributes {
+		if attribute.Key != key {
+			continue
+		}
+		if attribute.Type != tempo.StaticListType {
+			return nil, fmt.Errorf("work attribute %s is a %s, not a static list", key, attribute.Type)
+		}
+		if len(attribute.Values) == 0 {
+			return nil, fmt.Errorf("work attribute %s has no values", key)
+		}
+		return attribute.Values, nil
+	}
+	return nil, fmt.Errorf("work attribute %s not found in Tempo", key)
+}
+
+// labelCache holds the last fetched values of the Tempo label attribute
+type labelCache struct {
+	Attribute string    `json:"attribute"`
+	Values    []string  `json:"values"`
+	FetchedAt time.Time `json:"fetched_at"`
+}
+
+// applyTempoLabels replaces the allowed labels with the live values of tempo.label_attribute
+// when labels.from_tempo is set. Values are cached for labels.cache_ttl; if fetching fails,
+// stale cached values or the configured list are used instead.
+// Call it where labels are chosen or checked, not when loading the config, so commands
+// that do not use labels never reach Tempo.
+func applyTempoLabels(cfg *config.Config) {
+	if !cfg.Labels.FromTempo {
+		return
+	}
+	if cfg.Tempo.APIToken == "" || cfg.Tempo.LabelAttribute == "" {
+		log.Warn().Msg("labels.from_tempo requires tempo.api_token and tempo.label_attribute, using labels.allowed_labels")
+		return
+	}
+
+	ttl, err := str2duration.ParseDuration(cfg.Labels.CacheTTL)
+	if err != nil {
+		log.Warn().Err(err).Str("cache_ttl", cfg.Labels.CacheTTL).Msg("Invalid labels.cache_ttl, using 24h")
+		ttl = defaultLabelCacheTTL
+	}
+
+	cache := loadLabelCache()
+	if values, fresh := cachedLabels(cache, cfg.Tempo.LabelAttribute, ttl, time.Now()); fresh {
+		cfg.Labels.AllowedLabels = values
+		return
+	}
+
+	attributes, err := tempo.NewClient(cfg.Tempo.APIToken).GetWorkAttributes()
+	if err == nil {
+		var values []string
+		if values, err = staticListValues(attributes, cfg.Tempo.LabelAttribute); err == nil {
+			saveLabelCache(&labelCache{Attribute: cfg.Tempo.LabelAttribute, Values: values, FetchedAt: time.Now()})
+			cfg.Labels.AllowedLabels = values
+			return
+		}
+	}
+
+	log.Warn().Err(err).Msg("Failed to fetch labels from Tempo")
+	if cache != nil && cache.Attribute == cfg.Tempo.LabelAttribute && len(cache.Values) > 0 {
+		cfg.Labels.AllowedLabels = cache.Values
+	}
+}
+
+// cachedLabels returns the cached values and whether they are for attribute and younger than ttl
+func cachedLabels(cache *labelCache, attribute string, ttl time.Duration, now time.Time) ([]string, bool) {
+	if cache == nil || cache.Attribute != attribute || len(cache.Values) == 0 {
+		return nil, false
+	}
+	if now.Sub(cache.FetchedAt) >= ttl {
+		return nil, false
+	}
+	return cache.Values, true
+}
+
+// labelCachePath returns the path of the label cache file
+func labelCachePath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "labels_cache.json"), nil
+}
+
+// loadLabelCache reads the label cache, returning nil if there is none
+func loadLabelCache() *labelCache {
+	path, err := labelCachePath()
+	if err != nil {
+		return nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+
+	var cache labelCache
+	if err := json.Unmarshal(data, &cache); err != nil {
+		log.Debug().Err(err).Msg("Failed to unmarshal label cache")
+		return nil
+	}
+	return &cache
+}
+
+// saveLabelCache writes the label cache, logging failures
+func saveLabelCache(cache *labelCache) {
+	path, err := labelCachePath()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to get label cache path")
+		return
+	}
+
+	data, err := json.Marshal(cache)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to marshal label cache")
+		return
+	}
+
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		log.Debug().Err(err).Msg("Failed to write label cache")
+	}
+}
+
+// jiraWorklogComment returns the Jira worklog comment of an entry
+// The label is prefixed as "[label]" when labels.propagate is comment
+func jiraWorklogComment(cfg *config.Config, entry *storage.TimeEntry) string {
+	if cfg.Labels.Propagate != "comment" || entry.Label == "" {
+		return entry.Comment
+	}
+	if entry.Comment == "" {
+		return fmt.Sprintf("[%s]", entry.Label)
+	}
+	return fmt.Sprintf("[%s] %s", entry.Label, entry.Comment)
+}
+
+// errNotInTempo is returned when Tempo has not imported a Jira worklog yet, so its label cannot be sent
+var errNotInTempo = errors.New("not in Tempo yet")
+
+// addJiraWorklog logs an entry to Jira and sends its label as set by labels.propagate
+// A label that cannot be sent is reported but not returned; 'tasklog sync' retries it
+// Tempo usually imports a new Jira worklog after a while, so that label is left to 'tasklog sync' quietly
+func addJiraWorklog(cfg *config.Config, jiraClient *jira.Client, entry *storage.TimeEntry, opts jira.WorklogOptions) error {
+	worklog, err := jiraClient.AddWorklog(entry.IssueKey, entry.TimeSpentSeconds, entry.Started, jiraWorklogComment(cfg, entry), opts)
+	if err != nil {
+		return err
+	}
+	entry.SyncedToJira = true
+	entry.JiraWorklogID = &worklog.ID
+
+	switch cfg.Labels.Propagate {
+	case "comment":
+		entry.LabelSynced = true
+	case "property", "tempo":
+		err := propagateLabel(cfg, jiraClient, entry)
+		if errors.Is(err, errNotInTempo) {
+			log.Debug().Err(err).Int64("id", entry.ID).Msg("Label left to sync until Tempo imports the worklog")
+		} else if err != nil {
+			log.Warn().Err(err).Int64("id", entry.ID).Msg("Failed to send label")
+			fmt.Printf("⚠ Failed to send label: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// propagateLabel sends the label of an entry already logged to Jira, as set by labels.propagate
+func propagateLabel(cfg *config.Config, jiraClient *jira.Client, entry *storage.TimeEntry) error {
+	if entry.Label == "" || entry.JiraWorklogID == nil {
+		return nil
+	}
+
+	switch cfg.Labels.Propagate {
+	case "property":
+		if err := jiraClient.SetWorklogProperty(entry.IssueKey, *entry.JiraWorklogID, labelPropertyKey, map[string]string{"label": entry.Label}); err != nil {
+			return err
+		}
+	case "comment":
+		if _, err := jiraClient.UpdateWorklog(entry.IssueKey, *entry.JiraWorklogID, entry.TimeSpentSeconds, entry.Started, jiraWorklogComment(cfg, entry)); err != nil {
+			return err
+		}
+	case "tempo":
+		jiraID, err := strconv.ParseInt(*entry.JiraWorklogID, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid Jira worklog ID %q", *entry.JiraWorklogID)
+		}
+		tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+		tempoID, err := tempoClient.GetTempoWorklogID(jiraID)
+		if err != nil {
+			return err
+		}
+		if tempoID == 0 {
+			return fmt.Errorf("jira worklog %d is %w", jiraID, errNotInTempo)
+		}
+		attributes := []tempo.WorklogAttribute{{Key: cfg.Tempo.LabelAttribute, Value: cfg.TempoLabelValue(entry.Label)}}
+		if err := tempoClient.SetWorklogAttributes(tempoID, attributes); err != nil {
+			return err
+		}
+		tempoWorklogID := strconv.Itoa(tempoID)
+		entry.TempoWorklogID = &tempoWorklogID
+	default:
+		return nil
+	}
+
+	entry.LabelSynced = true
+	return nil
+}
+
+// backfillLabels sends the labels of entries logged to Jira before labels.propagate was set, or whose label failed to send
+func backfillLabels(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, out io.Writer) error {
+	if cfg.Labels.Propagate == "none" {
+		return nil
+	}
+
+	entries, err := store.GetLabelUnsyncedEntries()
+	if err != nil {
+		return fmt.Errorf("failed to fetch entries with unsynced labels: %w", err)
+	}
+	if len(entries) == 0 {
+		return nil
+	}
+
+	fmt.Fprintf(out, "\nSending labels of %d entries (%s)\n", len(entries), cfg.Labels.Propagate)
+
+	successCount, waitingCount := 0, 0
+	for _, entry := range entries {
+		err := propagateLabel(cfg, jiraClient, &entry)
+		if errors.Is(err, errNotInTempo) {
+			log.Debug().Err(err).Int64("id", entry.ID).Msg("Label waiting for Tempo")
+			waitingCount++
+			continue
+		}
+		if err != nil {
+			log.Error().Err(err).Int64("id", entry.ID).Msg("Failed to send label")
+			fmt.Fprintf(out, "  ✗ %s [%s]: %v\n", entry.IssueKey, entry.Label, err)
+			continue
+		}
+		if err := store.UpdateTimeEntry(&entry); err != nil {
+			log.Error().Err(err).Int64("id", entry.ID).Msg("Failed to update entry")
+			continue
+		}
+		successCount++
+	}
+
+	if waitingCount > 0 {
+		fmt.Fprintf(out, "Labels sent: %d successful, %d failed, %d waiting for Tempo to import the worklog\n",
+			successCount, len(entries)-successCount-waitingCount, waitingCount)
+	} else {
+		fmt.Fprintf(out, "Labels sent: %d successful, %d failed\n", successCount, len(entries)-successCount)
+	}
+	return nil
+}
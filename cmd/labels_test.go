@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+)
+
+func TestStaticListValues(t *testing.T) {
+	attributes := []tempo.WorkAttribute{
+		{Key: "_WorkType_", Type: tempo.StaticListType, Values: []string{"Development", "Meeting"}},
+		{Key: "_Ticket_", Type: "INPUT_FIELD"},
+		{Key: "_Empty_", Type: tempo.StaticListType},
+	}
+
+	values, err := staticListValues(attributes, "_WorkType_")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(values) != 2 || values[0] != "Development" {
+		t.Errorf("unexpected values: %v", values)
+	}
+
+	for _, key := range []string{"_Ticket_", "_Empty_", "_Missing_"} {
+		if _, err := staticListValues(attributes, key); err == nil {
+			t.Errorf("expected an error for %s", key)
+		}
+	}
+}
+
+func TestCachedLabels(t *testing.T) {
+	now := time.Now()
+	cache := &labelCache{Attribute: "_WorkType_", Values: []string{"Development"}, FetchedAt: now.Add(-time.Hour)}
+
+	if values, fresh := cachedLabels(cache, "_WorkType_", 24*time.Hour, now); !fresh || len(values) != 1 {
+		t.Errorf("expected fresh cached values, got %v %v", values, fresh)
+	}
+	if _, fresh := cachedLabels(cache, "_WorkType_", 30*time.Minute, now); fresh {
+		t.Error("expected values older than the TTL to be stale")
+	}
+	if _, fresh := cachedLabels(cache, "_Other_", 24*time.Hour, now); fresh {
+		t.Error("expected values of another attribute to be ignored")
+	}
+	if _, fresh := cachedLabels(nil, "_WorkType_", 24*time.Hour, now); fresh {
+		t.Error("expected no values without a cache")
+	}
+}
+
+func TestJiraWorklogComment(t *testing.T) {
+	comment := &config.Config{Labels: config.LabelsConfig{Propagate: "comment"}}
+
+	tests := []struct {
+		name  string
+		cfg   *config.Config
+		entry storage.TimeEntry
+		want  string
+	}{
+		{"label and comment", comment, storage.TimeEntry{Label: "meeting", Comment: "Standup"}, "[meeting] Standup"},
+		{"label only", comment, storage.TimeEntry{Label: "meeting"}, "[meeting]"},
+		{"no label", comment, storage.TimeEntry{Comment: "Standup"}, "Standup"},
+		{"other propagation", &config.Config{Labels: config.LabelsConfig{Propagate: "property"}}, storage.TimeEntry{Label: "meeting", Comment: "Standup"}, "Standup"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jiraWorklogComment(tt.cfg, &tt.entry); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestPropagateLabel_Property(t *testing.T) {
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{Labels: config.LabelsConfig{Propagate: "property"}}
+	jiraClient := jira.NewClient(server.URL, "user@example.com", "token", "PROJ")
+	worklogID := "100"
+	entry := &storage.TimeEntry{IssueKey: "PROJ-1", Label: "development", SyncedToJira: true, JiraWorklogID: &worklogID}
+
+	if err := propagateLabel(cfg, jiraClient, entry); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/rest/api/3/issue/PROJ-1/worklog/100/properties/tasklog" {
+		t.Errorf("unexpected request path: %s", path)
+	}
+	if !entry.LabelSynced {
+		t.Error("expected the label to be marked as synced")
+	}
+}
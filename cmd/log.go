@@ -1,13 +1,19 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
 	"tasklog/internal/config"
+	"tasklog/internal/gitinfo"
 	"tasklog/internal/jira"
 	"tasklog/internal/storage"
 	"tasklog/internal/tempo"
@@ -16,13 +22,21 @@ import (
 )
 
 var (
-	shortcutName string
-	taskKey      string
-	timeSpent    string
-	label        string
-	startedAt    string
+	shortcutName   string
+	taskKey        string
+	timeSpent      string
+	label          string
+	startedAt      string
+	fromGit        bool
+	accountKey     string
+	adjustEstimate string
+	newEstimate    string
+	transitionTo   string
 )
 
+// maxGitSuggestions limits how many git-detected issues are fetched for the task picker
+const maxGitSuggestions = 5
+
 var logCmd = &cobra.Command{
 	Use:   "log [shortcut-name]",
 	Short: "Log time to a task",
@@ -33,7 +47,13 @@ Examples:
   tasklog log              # Interactive mode
   tasklog log daily        # Use 'daily' shortcut
   tasklog log standup      # Use 'standup' shortcut
-  tasklog log -t PROJ-123  # Log to specific task` + configHelp,
+  tasklog log -t PROJ-123  # Log to specific task
+  tasklog log --from-git   # Log to the issue in the current git branch
+  tasklog log -t PROJ-123 --transition "In Progress" --adjust-estimate new --estimate 1d
+
+When run inside a git repository, issue keys found in the current branch name
+and recent commit messages are suggested at the top of the task list, after any
+issues Tempo Planner allocated you to today.` + configHelp,
 	Args: cobra.MaximumNArgs(1),
 	RunE: runLog,
 }
@@ -45,6 +65,11 @@ func init() {
 	logCmd.Flags().StringVarP(&timeSpent, "time", "d", "", "Time spent (e.g., 2h 30m, 2.5h, 150m)")
 	logCmd.Flags().StringVarP(&label, "label", "l", "", "Work log label")
 	logCmd.Flags().StringVarP(&startedAt, "at", "a", "", "When work was performed (e.g., 2pm, yesterday, 2h ago)")
+	logCmd.Flags().BoolVar(&fromGit, "from-git", false, "Use the issue key from the current git branch")
+	logCmd.Flags().StringVar(&accountKey, "account", "", "Tempo account key to bill the work to (direct mode)")
+	logCmd.Flags().StringVar(&adjustEstimate, "adjust-estimate", "", "Remaining estimate adjustment: auto, new, leave or manual")
+	logCmd.Flags().StringVar(&newEstimate, "estimate", "", "New remaining estimate (new) or amount to reduce it by (manual), e.g. 2h")
+	logCmd.Flags().StringVar(&transitionTo, "transition", "", "Move the issue to this status after logging (e.g., \"In Progress\")")
 
 	// Set custom usage template to show available shortcuts
 	logCmd.SetUsageFunc(logUsageFunc)
@@ -87,7 +112,10 @@ func runLog(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize clients
-	jiraClient := jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
 	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
 
 	// Initialize storage
@@ -120,49 +148,75 @@ func runLog(cmd *cobra.Command, args []string) error {
 		if label == "" {
 			label = shortcut.Label
 		}
+		if accountKey == "" {
+			accountKey = shortcut.Account
+		}
+		if adjustEstimate == "" {
+			adjustEstimate = shortcut.AdjustEstimate
+		}
+		if newEstimate == "" {
+			newEstimate = shortcut.Estimate
+		}
+		if transitionTo == "" {
+			transitionTo = shortcut.Transition
+		}
+	}
+
+	worklogOptions := jira.WorklogOptions{AdjustEstimate: adjustEstimate, Estimate: newEstimate}
+	if err := worklogOptions.Validate(); err != nil {
+		return err
+	}
+
+	// Resolve task from the current git branch
+	if fromGit && taskKey == "" {
+		keys, err := detectGitIssueKeys(cfg)
+		if err != nil {
+			return fmt.Errorf("failed to detect task from git: %w", err)
+		}
+		if len(keys.FromRef) == 0 {
+			return fmt.Errorf("no %s issue key found in branch '%s'", cfg.Jira.ProjectKey, keys.Branch)
+		}
+		taskKey = keys.FromRef[0]
 	}
 
 	// Get task
+	var pendingValidation bool
 	if taskKey != "" {
 		log.Debug().Str("task", taskKey).Msg("Fetching specified task")
 		issue, err := jiraClient.GetIssue(taskKey)
-		if err != nil {
+		switch {
+		case err == nil:
+			selectedIssue = issue
+			if err := store.SaveIssue(toCachedIssue(*issue)); err != nil {
+				log.Debug().Err(err).Str("key", issue.Key).Msg("Failed to cache issue")
+			}
+		case jira.IsUnreachable(err):
+			// Save offline and let 'tasklog sync' check the key once Jira is back
+			log.Debug().Err(err).Str("task", taskKey).Msg("Jira is unreachable, deferring validation")
+			fmt.Println("⚠ Jira is unreachable, the entry will be saved locally and validated by 'tasklog sync'")
+			selectedIssue = offlineIssue(store, taskKey)
+			pendingValidation = true
+		default:
 			return fmt.Errorf("failed to fetch task %s: %w", taskKey, err)
 		}
-		selectedIssue = issue
-		fmt.Printf("Task: %s - %s\n", selectedIssue.Key, selectedIssue.Fields.Summary)
+		fmt.Printf("Task: %s - %s\n", selectedIssue.Key, issueTitle(selectedIssue))
 	} else {
 		// Interactive task selection
-		log.Debug().Msg("Fetching in-progress tasks")
-		inProgressIssues, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+		selectedIssue, err = selectTask(cfg, store, jiraClient)
 		if err != nil {
-			return fmt.Errorf("failed to fetch in-progress tasks: %w", err)
+			return err
 		}
+	}
 
-		selectedIssue, err = ui.SelectTask(inProgressIssues)
+	// Get Tempo account, which can only be set when logging to Tempo directly
+	var selectedAccount string
+	if cfg.Tempo.Mode == "direct" {
+		selectedAccount, err = selectAccount(cfg, tempoClient, selectedIssue, accountKey)
 		if err != nil {
-			return fmt.Errorf("failed to select task: %w", err)
-		}
-
-		// If user chose to search, perform the search
-		if selectedIssue.Fields.Summary == "" {
-			searchResults, err := jiraClient.SearchIssues(selectedIssue.Key)
-			if err != nil {
-				return fmt.Errorf("failed to search tasks: %w", err)
-			}
-
-			selectedIssue, err = ui.SelectFromSearchResults(searchResults)
-			if err != nil {
-				return fmt.Errorf("failed to select from search results: %w", err)
-			}
-
-			// Fetch full issue details
-			issue, err := jiraClient.GetIssue(selectedIssue.Key)
-			if err != nil {
-				return fmt.Errorf("failed to fetch task details: %w", err)
-			}
-			selectedIssue = issue
+			return fmt.Errorf("failed to select account: %w", err)
 		}
+	} else if accountKey != "" {
+		return fmt.Errorf("accounts can only be set when tempo.mode is direct")
 	}
 
 	// Get time spent
@@ -184,6 +238,7 @@ func runLog(cmd *cobra.Command, args []string) error {
 	}
 
 	// Get label
+	applyTempoLabels(cfg)
 	if label != "" {
 		if !cfg.IsLabelAllowed(label) {
 			return fmt.Errorf("label '%s' is not in the allowed labels list", label)
@@ -229,10 +284,22 @@ func runLog(cmd *cobra.Command, args []string) error {
 
 	// Confirm before logging
 	fmt.Printf("\n")
-	fmt.Printf("Task:    %s - %s\n", selectedIssue.Key, selectedIssue.Fields.Summary)
+	fmt.Printf("Task:    %s - %s\n", selectedIssue.Key, issueTitle(selectedIssue))
 	fmt.Printf("Time:    %s\n", timeparse.Format(timeSeconds))
 	fmt.Printf("Started: %s\n", started.Format("Mon Jan 2 15:04"))
 	fmt.Printf("Label:   %s\n", selectedLabel)
+	if estimates := formatEstimates(selectedIssue.Fields.TimeTracking); estimates != "" {
+		fmt.Printf("Tracked: %s\n", estimates)
+	}
+	if worklogOptions.AdjustEstimate != "" {
+		fmt.Printf("Adjust:  %s\n", describeAdjustEstimate(worklogOptions))
+	}
+	if transitionTo != "" && !strings.EqualFold(selectedIssue.Fields.Status.Name, transitionTo) {
+		fmt.Printf("Status:  %s → %s\n", selectedIssue.Fields.Status.Name, transitionTo)
+	}
+	if selectedAccount != "" {
+		fmt.Printf("Account: %s\n", selectedAccount)
+	}
 	if comment != "" {
 		fmt.Printf("Comment: %s\n", comment)
 	}
@@ -250,16 +317,358 @@ func runLog(cmd *cobra.Command, args []string) error {
 
 	// Create time entry
 	entry := &storage.TimeEntry{
-		IssueKey:         selectedIssue.Key,
-		IssueSummary:     selectedIssue.Fields.Summary,
-		TimeSpentSeconds: timeSeconds,
-		TimeSpent:        timeparse.Format(timeSeconds),
-		Label:            selectedLabel,
-		Comment:          comment,
-		Started:          started,
-		SyncedToJira:     false,
-		SyncedToTempo:    false,
+		IssueKey:          selectedIssue.Key,
+		IssueSummary:      selectedIssue.Fields.Summary,
+		TimeSpentSeconds:  timeSeconds,
+		TimeSpent:         timeparse.Format(timeSeconds),
+		Label:             selectedLabel,
+		Comment:           comment,
+		Started:           started,
+		SyncedToJira:      false,
+		SyncedToTempo:     false,
+		Shortcut:          shortcutName,
+		Account:           selectedAccount,
+		PendingValidation: pendingValidation,
+	}
+
+	if err := submitEntry(cfg, store, jiraClient, entry, worklogOptions); err != nil {
+		return err
+	}
+
+	// Offline, nothing else can be done until 'tasklog sync'
+	if pendingValidation {
+		if transitionTo != "" {
+			fmt.Printf("⚠ Jira is unreachable, %s was not moved to %s\n", selectedIssue.Key, transitionTo)
+		}
+		return nil
+	}
+
+	if transitionTo != "" {
+		if err := transitionIssue(jiraClient, selectedIssue, transitionTo); err != nil {
+			log.Error().Err(err).Msg("Failed to transition issue")
+			fmt.Printf("⚠ Failed to move %s to %s: %v\n", selectedIssue.Key, transitionTo, err)
+		}
+	}
+
+	// Show today's summary
+	fmt.Println()
+	if err := showTodaySummary(store, jiraClient, tempoClient, cfg); err != nil {
+		log.Error().Err(err).Msg("Failed to show summary")
+	}
+
+	return nil
+}
+
+// offlineIssue returns the issue to log to while Jira is unreachable, with the summary from the issue cache if known
+func offlineIssue(store *storage.Storage, key string) *jira.Issue {
+	key = strings.ToUpper(strings.TrimSpace(key))
+	cached, err := store.GetCachedIssue(key)
+	if err != nil {
+		log.Debug().Err(err).Str("key", key).Msg("Failed to read issue cache")
+	}
+	if cached == nil {
+		return &jira.Issue{Key: key}
+	}
+	issue := fromCachedIssue(*cached)
+	return &issue
+}
+
+// issueTitle returns the summary of an issue, or a placeholder when it is not known yet
+func issueTitle(issue *jira.Issue) string {
+	if issue.Fields.Summary == "" {
+		return "(summary unknown, pending validation)"
+	}
+	return issue.Fields.Summary
+}
+
+// selectTask lets the user pick a task from planned and git suggestions and in-progress issues, or search for one
+// In-progress and recently used issues come from the local issue cache, which is refreshed in the background
+// The picked issue is fetched from Jira for its current status and time tracking
+func selectTask(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client) (*jira.Issue, error) {
+	// The refresh is not waited for once the picker is shown; it is written in one transaction,
+	// so a command exiting before it ends leaves the previous cache in place
+	refreshed := make(chan error, 1)
+	go func() {
+		_, err := refreshIssueCache(cfg, store, jiraClient)
+		if err != nil {
+			log.Debug().Err(err).Msg("Background issue cache refresh failed")
+		}
+		refreshed <- err
+	}()
+
+	// Suggestions may need Jira and Tempo, so they are fetched while the cache is read
+	plannedSuggestions := make(chan []jira.Issue, 1)
+	go func() { plannedSuggestions <- plannedIssues(cfg, jiraClient) }()
+	gitSuggestions := make(chan []jira.Issue, 1)
+	go func() { gitSuggestions <- gitSuggestedIssues(cfg, store, jiraClient) }()
+
+	inProgressIssues, err := store.GetInProgressCachedIssues()
+	if err != nil {
+		return nil, err
+	}
+	recentIssues, err := store.GetRecentIssues(maxRecentSuggestions)
+	if err != nil {
+		return nil, err
+	}
+
+	// Nothing cached yet, so wait for the first refresh
+	if len(inProgressIssues) == 0 && len(recentIssues) == 0 {
+		log.Debug().Msg("Issue cache is empty, waiting for refresh")
+		if err := <-refreshed; err != nil {
+			fmt.Printf("⚠ %v\n", err)
+		}
+		if inProgressIssues, err = store.GetInProgressCachedIssues(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Issues planned for today come first, then the ones from git, then the recently used ones
+	// Suggestions that are not ready in time are left out rather than holding up the picker
+	deadline := time.After(suggestionWait)
+	suggestedIssues := receiveSuggestions(plannedSuggestions, deadline, "planned")
+	suggestedIssues = append(suggestedIssues, receiveSuggestions(gitSuggestions, deadline, "git")...)
+	suggestedIssues = append(suggestedIssues, fromCachedIssues(recentIssues)...)
+
+	selectedIssue, err := ui.SelectTask(suggestedIssues, fromCachedIssues(inProgressIssues))
+	if err != nil {
+		return nil, fmt.Errorf("failed to select task: %w", err)
+	}
+
+	// If user chose to search, perform the search
+	if selectedIssue.Fields.Summary == "" {
+		searchResults, err := jiraClient.SearchIssues(selectedIssue.Key)
+		if err != nil {
+			log.Debug().Err(err).Msg("Searching the issue cache instead")
+			cached, cacheErr := store.SearchCachedIssues(selectedIssue.Key, maxSearchResults)
+			if cacheErr != nil || len(cached) == 0 {
+				return nil, fmt.Errorf("failed to search tasks: %w", err)
+			}
+			fmt.Printf("⚠ Jira search failed, showing cached issues: %v\n", err)
+			searchResults = fromCachedIssues(cached)
+		}
+
+		selectedIssue, err = ui.SelectFromSearchResults(searchResults)
+		if err != nil {
+			return nil, fmt.Errorf("failed to select from search results: %w", err)
+		}
+	}
+
+	return fetchSelectedIssue(store, jiraClient, selectedIssue)
+}
+
+// suggestionWait is how long the task picker waits for planned and git suggestions
+const suggestionWait = 2 * time.Second
+
+// receiveSuggestions returns the issues suggested on ch, or nil when they are not ready by deadline
+func receiveSuggestions(ch <-chan []jira.Issue, deadline <-chan time.Time, source string) []jira.Issue {
+	select {
+	case issues := <-ch:
+		return issues
+	case <-deadline:
+		log.Debug().Str("source", source).Msg("Suggestions not ready in time, leaving them out")
+		return nil
+	}
+}
+
+// fetchSelectedIssue fetches the full details of a picked issue, which may come from the issue cache
+// The picked copy is used only when Jira is unreachable
+func fetchSelectedIssue(store *storage.Storage, jiraClient *jira.Client, picked *jira.Issue) (*jira.Issue, error) {
+	issue, err := jiraClient.GetIssue(picked.Key)
+	if err != nil {
+		if !jira.IsUnreachable(err) {
+			return nil, fmt.Errorf("failed to fetch task details: %w", err)
+		}
+		log.Debug().Err(err).Str("key", picked.Key).Msg("Using cached issue details")
+		return picked, nil
+	}
+
+	if err := store.SaveIssue(toCachedIssue(*issue)); err != nil {
+		log.Debug().Err(err).Str("key", issue.Key).Msg("Failed to cache issue")
+	}
+	return issue, nil
+}
+
+// selectAccount returns the Tempo account to bill an issue's work to
+// The preset (flag or shortcut) wins, then tempo.project_accounts; otherwise the accounts linked
+// to the issue's project are used, asking when more than one applies
+func selectAccount(cfg *config.Config, tempoClient *tempo.Client, issue *jira.Issue, preset string) (string, error) {
+	if preset != "" {
+		return preset, nil
+	}
+	if account := cfg.ProjectAccount(issue.Key); account != "" {
+		return account, nil
+	}
+	if issue.Fields.Project == nil {
+		return "", nil
+	}
+
+	links, err := tempoClient.GetProjectAccounts(issue.Fields.Project.ID)
+	if err != nil {
+		log.Debug().Err(err).Str("project", issue.Fields.Project.Key).Msg("Failed to fetch Tempo accounts")
+		fmt.Printf("⚠ Failed to fetch the Tempo accounts of %s, logging without an account (use --account to set one): %v\n",
+			issue.Fields.Project.Key, err)
+		return "", nil
+	}
+
+	switch len(links) {
+	case 0:
+		return "", nil
+	case 1:
+		return links[0].Account.Key, nil
+	}
+
+	options, keys := accountOptions(links)
+	selected, err := ui.SelectOption("Select the Tempo account:", options)
+	if err != nil {
+		return "", err
+	}
+	return keys[selected], nil
+}
+
+// accountOptions returns the prompt options for linked accounts, default account first, and the key of each option
+func accountOptions(links []tempo.AccountLink) ([]string, map[string]string) {
+	sorted := make([]tempo.AccountLink, len(links))
+	copy(sorted, links)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Default && !sorted[j].Default })
+
+	options := make([]string, len(sorted))
+	keys := make(map[string]string, len(sorted))
+	for i, link := range sorted {
+		option := fmt.Sprintf("%s (%s)", link.Account.Name, link.Account.Key)
+		if link.Default {
+			option += " - default"
+		}
+		options[i] = option
+		keys[option] = link.Account.Key
+	}
+	return options, keys
+}
+
+// formatEstimates describes the original estimate, remaining estimate and time spent of an issue
+// Returns an empty string when the issue has no time tracking
+func formatEstimates(tt *jira.IssueTimeTracking) string {
+	if tt == nil || (tt.OriginalEstimate == "" && tt.RemainingEstimate == "" && tt.TimeSpent == "") {
+		return ""
 	}
+	value := func(s string) string {
+		if s == "" {
+			return "-"
+		}
+		return s
+	}
+	return fmt.Sprintf("%s original, %s remaining, %s spent", value(tt.OriginalEstimate), value(tt.RemainingEstimate), value(tt.TimeSpent))
+}
+
+// describeAdjustEstimate describes how logging changes the remaining estimate
+func describeAdjustEstimate(opts jira.WorklogOptions) string {
+	switch opts.AdjustEstimate {
+	case "new":
+		return fmt.Sprintf("set remaining estimate to %s", opts.Estimate)
+	case "manual":
+		return fmt.Sprintf("reduce remaining estimate by %s", opts.Estimate)
+	case "leave":
+		return "leave remaining estimate unchanged"
+	default:
+		return "reduce remaining estimate by the time spent"
+	}
+}
+
+// transitionIssue moves an issue to the target status, matched by status or transition name
+// Does nothing if the issue already has that status
+func transitionIssue(jiraClient *jira.Client, issue *jira.Issue, target string) error {
+	if strings.EqualFold(issue.Fields.Status.Name, target) {
+		return nil
+	}
+
+	transitions, err := jiraClient.GetTransitions(issue.Key)
+	if err != nil {
+		return err
+	}
+	transition, err := findTransition(transitions, target)
+	if err != nil {
+		return err
+	}
+	if err := jiraClient.TransitionIssue(issue.Key, transition.ID); err != nil {
+		return err
+	}
+
+	fmt.Printf("✓ Moved %s to %s\n", issue.Key, transition.To.Name)
+	return nil
+}
+
+// findTransition returns the transition leading to the target status, or named like the target
+func findTransition(transitions []jira.Transition, target string) (*jira.Transition, error) {
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].To.Name, target) {
+			return &transitions[i], nil
+		}
+	}
+	for i := range transitions {
+		if strings.EqualFold(transitions[i].Name, target) {
+			return &transitions[i], nil
+		}
+	}
+
+	available := make([]string, len(transitions))
+	for i, t := range transitions {
+		available[i] = t.To.Name
+	}
+	return nil, fmt.Errorf("no transition to %q, available: %s", target, strings.Join(available, ", "))
+}
+
+// detectGitIssueKeys detects issue keys for the configured project in the current directory's git repository
+func detectGitIssueKeys(cfg *config.Config) (*gitinfo.IssueKeys, error) {
+	dir, err := os.Getwd()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get working directory: %w", err)
+	}
+	return gitinfo.Detect(dir, cfg.Jira.ProjectKey)
+}
+
+// gitSuggestedIssues returns the issues referenced by the current git branch and recent commits
+// Issues are read from the issue cache when present and fetched from Jira otherwise
+// Returns nil when not inside a git repository or when no keys are found
+func gitSuggestedIssues(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client) []jira.Issue {
+	keys, err := detectGitIssueKeys(cfg)
+	if err != nil {
+		log.Debug().Err(err).Msg("No git issue keys detected")
+		return nil
+	}
+
+	var issues []jira.Issue
+	for _, key := range keys.All() {
+		if len(issues) >= maxGitSuggestions {
+			break
+		}
+		if cached, err := store.GetCachedIssue(key); err == nil && cached != nil {
+			issues = append(issues, fromCachedIssue(*cached))
+			continue
+		}
+		issue, err := jiraClient.GetIssue(key)
+		if err != nil {
+			log.Debug().Err(err).Str("key", key).Msg("Skipping git issue suggestion")
+			continue
+		}
+		if err := store.SaveIssue(toCachedIssue(*issue)); err != nil {
+			log.Debug().Err(err).Str("key", key).Msg("Failed to cache issue")
+		}
+		issues = append(issues, *issue)
+	}
+	return issues
+}
+
+// submitEntry saves a time entry to the local cache, logs it to Jira (or Tempo in direct mode) and records the sync status
+// Logging failures are reported but not returned, so the entry can be retried with 'tasklog sync'
+// The remaining estimate is adjusted as set by opts when logging to Jira; retries use Jira's default
+// Entries pending validation are only saved, 'tasklog sync' validates and logs them
+// When another process keeps sending entries, the entry is only saved and left to that sync
+func submitEntry(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, entry *storage.TimeEntry, opts jira.WorklogOptions) error {
+	// Hold the sync lock so 'tasklog daemon' does not send the new entry at the same time
+	syncLock, lockErr := acquireSyncLock(cfg, syncLockWait)
+	if lockErr != nil && !errors.Is(lockErr, errSyncRunning) {
+		log.Warn().Err(lockErr).Msg("Failed to take the sync lock, sending without it")
+	}
+	defer syncLock.Release()
 
 	// Save to local storage first
 	if err := store.AddTimeEntry(entry); err != nil {
@@ -268,22 +677,50 @@ func runLog(cmd *cobra.Command, args []string) error {
 
 	fmt.Println("✓ Saved to local cache")
 
-	// Log to Jira
-	log.Debug().Msg("Logging to Jira")
-	worklog, err := jiraClient.AddWorklog(selectedIssue.Key, timeSeconds, started, comment)
-	if err != nil {
-		log.Error().Err(err).Msg("Failed to log to Jira")
-		fmt.Printf("⚠ Failed to log to Jira: %v\n", err)
+	// Show work starting now as the Slack status
+	setEntryWorkStatus(cfg, entry, time.Now())
+
+	if errors.Is(lockErr, errSyncRunning) {
+		fmt.Println("⚠ Another tasklog process is sending entries, this one will be sent by the next sync")
+		return nil
+	}
+
+	if entry.PendingValidation {
+		if opts.AdjustEstimate != "" {
+			fmt.Println("⚠ The remaining estimate is only adjusted when logging online, ignoring it")
+		}
+		fmt.Printf("⚠ Run 'tasklog sync' once Jira is reachable to validate %s and log the time\n", entry.IssueKey)
+		return nil
+	}
+
+	if cfg.Tempo.Mode == "direct" {
+		if opts.AdjustEstimate != "" {
+			fmt.Println("⚠ The remaining estimate can only be adjusted when logging to Jira, ignoring it")
+		}
+
+		// Log to Tempo, which creates the Jira worklog
+		log.Debug().Msg("Logging to Tempo")
+		if err := logToTempo(cfg, jiraClient, entry); err != nil {
+			log.Error().Err(err).Msg("Failed to log to Tempo")
+			fmt.Printf("⚠ Failed to log to Tempo: %v\n", err)
+		} else {
+			fmt.Println("✓ Logged to Tempo (Jira worklog created by Tempo)")
+		}
 	} else {
-		entry.SyncedToJira = true
-		entry.JiraWorklogID = &worklog.ID
-		fmt.Println("✓ Logged to Jira")
+		// Log to Jira
+		log.Debug().Msg("Logging to Jira")
+		if err := addJiraWorklog(cfg, jiraClient, entry, opts); err != nil {
+			log.Error().Err(err).Msg("Failed to log to Jira")
+			fmt.Printf("⚠ Failed to log to Jira: %v\n", err)
+		} else {
+			fmt.Println("✓ Logged to Jira")
 
-		// If Tempo is enabled, Jira automatically creates a Tempo worklog
-		// Mark as synced to Tempo since it's handled by Jira
-		if cfg.Tempo.Enabled {
-			entry.SyncedToTempo = true
-			fmt.Println("✓ Tempo worklog created automatically by Jira")
+			// If Tempo is enabled, Jira automatically creates a Tempo worklog
+			// Mark as synced to Tempo since it's handled by Jira
+			if cfg.Tempo.Enabled {
+				entry.SyncedToTempo = true
+				fmt.Println("✓ Tempo worklog created automatically by Jira")
+			}
 		}
 	}
 
@@ -297,24 +734,136 @@ func runLog(cmd *cobra.Command, args []string) error {
 		log.Error().Err(err).Msg("Failed to update time entry sync status")
 	}
 
-	// Show today's summary
-	fmt.Println()
+	// Offer the issue as a recent one in the task picker
+	project, _, _ := strings.Cut(entry.IssueKey, "-")
+	if err := store.MarkIssueUsed(entry.IssueKey, entry.IssueSummary, project, time.Now()); err != nil {
+		log.Debug().Err(err).Msg("Failed to update issue cache")
+	}
+
+	return nil
+}
+
+// logToTempo logs an entry straight to Tempo and records the Tempo and Jira worklog IDs
+// Tempo creates the Jira worklog, so the entry is synced to both on success
+func logToTempo(cfg *config.Config, jiraClient *jira.Client, entry *storage.TimeEntry) error {
+	// Tempo needs the numeric issue ID and the author's account ID
+	issue, err := jiraClient.GetIssue(entry.IssueKey)
+	if err != nil {
+		return err
+	}
+	issueID, err := strconv.ParseInt(issue.ID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid issue ID %q for %s", issue.ID, entry.IssueKey)
+	}
+	user, err := jiraClient.GetCurrentUser()
+	if err != nil {
+		return err
+	}
+
+	if entry.Account == "" {
+		entry.Account = cfg.ProjectAccount(entry.IssueKey)
+	}
+	description, attributes := tempoWorklogContent(cfg, entry)
+	worklog, err := tempo.NewClient(cfg.Tempo.APIToken).AddWorklog(issueID, user.AccountID, entry.TimeSpentSeconds, entry.Started, description, attributes)
+	if err != nil {
+		return err
+	}
+
+	tempoID := strconv.Itoa(worklog.TempoWorklogID)
+	entry.TempoWorklogID = &tempoID
+	entry.SyncedToTempo = true
+	if worklog.JiraWorklogID != 0 {
+		jiraID := strconv.Itoa(worklog.JiraWorklogID)
+		entry.JiraWorklogID = &jiraID
+	}
+	entry.SyncedToJira = true
+	// The label went along as a work attribute or in the description
+	entry.LabelSynced = true
+	return nil
+}
+
+// tempoWorklogContent returns the Tempo description and work attributes of an entry
+// The label goes into the configured work attribute; without one it is kept in the description
+// The account, if any, goes into the account attribute
+func tempoWorklogContent(cfg *config.Config, entry *storage.TimeEntry) (string, []tempo.WorklogAttribute) {
+	description := entry.Comment
+	var attributes []tempo.WorklogAttribute
+
+	switch {
+	case entry.Label == "":
+	case cfg.Tempo.LabelAttribute != "":
+		attributes = append(attributes, tempo.WorklogAttribute{Key: cfg.Tempo.LabelAttribute, Value: cfg.TempoLabelValue(entry.Label)})
+	case entry.Comment == "":
+		description = fmt.Sprintf("[%s]", entry.Label)
+	default:
+		description = fmt.Sprintf("[%s] %s", entry.Label, entry.Comment)
+	}
+
+	if entry.Account != "" {
+		attributes = append(attributes, tempo.WorklogAttribute{Key: cfg.Tempo.AccountAttribute, Value: entry.Account})
+	}
+	return description, attributes
+}
+
+// remoteWorklog is a worklog from the source of truth shown in the summary
+type remoteWorklog struct {
+	Started  string // Start time as HH:MM
+	Seconds  int
+	Detail   string // Description or comment
+	IssueKey string
+}
+
+// fetchTodayWorklogs returns today's worklogs of the current user and the name of their source
+// Tempo is the source of truth when enabled; otherwise worklogs are read from Jira
+func fetchTodayWorklogs(cfg *config.Config, jiraClient *jira.Client, tempoClient *tempo.Client) (string, []remoteWorklog, error) {
+	// Get current user for filtering
+	currentUser, err := jiraClient.GetCurrentUser()
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	var worklogs []remoteWorklog
+
 	if cfg.Tempo.Enabled && cfg.Tempo.APIToken != "" {
-		if err := showTodaySummary(store, jiraClient, tempoClient, cfg); err != nil {
-			log.Error().Err(err).Msg("Failed to show summary")
+		log.Debug().Msg("Fetching today's worklogs from Tempo")
+		tempoWorklogs, err := tempoClient.GetTodayWorklogs(currentUser.AccountID)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to fetch Tempo worklogs: %w", err)
 		}
-	} else {
-		fmt.Println("═══════════════════════════════════════════")
-		fmt.Println("📊 Summary is disabled")
-		fmt.Println("═══════════════════════════════════════════")
-		fmt.Println("To enable time tracking summary, configure Tempo API in your config:")
-		fmt.Println("  tempo:")
-		fmt.Println("    enabled: true")
-		fmt.Println("    api_token: \"your-tempo-api-token\"")
-		fmt.Println("═══════════════════════════════════════════")
+		for _, wl := range tempoWorklogs {
+			started := wl.StartTime
+			if len(started) == len("15:04:05") {
+				started = started[:len("15:04")]
+			}
+			worklogs = append(worklogs, remoteWorklog{
+				Started:  started,
+				Seconds:  wl.TimeSpentSeconds,
+				Detail:   wl.Description,
+				IssueKey: wl.IssueKey,
+			})
+		}
+		return "Tempo", worklogs, nil
 	}
 
-	return nil
+	log.Debug().Msg("Fetching today's worklogs from Jira")
+	now := time.Now()
+	jiraWorklogs, err := jiraClient.GetWorklogs(startOfDay(now), startOfDay(now).AddDate(0, 0, 1), currentUser.ID())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to fetch Jira worklogs: %w", err)
+	}
+	for _, wl := range jiraWorklogs {
+		started := wl.Started
+		if t, err := wl.StartedTime(); err == nil {
+			started = t.In(now.Location()).Format("15:04")
+		}
+		worklogs = append(worklogs, remoteWorklog{
+			Started:  started,
+			Seconds:  wl.TimeSpentSeconds,
+			Detail:   wl.CommentText(),
+			IssueKey: wl.IssueKey,
+		})
+	}
+	return "Jira", worklogs, nil
 }
 
 func showTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClient *tempo.Client, cfg *config.Config) error {
@@ -322,17 +871,9 @@ func showTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClie
 	fmt.Println("📊 Today's Time Tracking Summary")
 	fmt.Println("═══════════════════════════════════════════")
 
-	// Get current user for filtering
-	currentUser, err := jiraClient.GetCurrentUser()
+	source, remoteWorklogs, err := fetchTodayWorklogs(cfg, jiraClient, tempoClient)
 	if err != nil {
-		return fmt.Errorf("failed to get current user: %w", err)
-	}
-
-	// Fetch from Tempo as source of truth
-	log.Debug().Msg("Fetching today's worklogs from Tempo")
-	tempoWorklogs, tempoErr := tempoClient.GetTodayWorklogs(currentUser.AccountID)
-	if tempoErr != nil {
-		return fmt.Errorf("failed to fetch Tempo worklogs: %w", tempoErr)
+		return err
 	}
 
 	// Get local entries
@@ -342,24 +883,24 @@ func showTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClie
 	}
 
 	// Calculate totals
-	var tempoTotal, localTotal int
+	var remoteTotal, localTotal int
 
-	for _, wl := range tempoWorklogs {
-		tempoTotal += wl.TimeSpentSeconds
+	for _, wl := range remoteWorklogs {
+		remoteTotal += wl.Seconds
 	}
 
 	for _, entry := range localEntries {
 		localTotal += entry.TimeSpentSeconds
 	}
 
-	// Display Tempo worklogs (source of truth)
-	fmt.Printf("\n✓ Tempo Worklogs (%d entries): %s\n", len(tempoWorklogs), timeparse.Format(tempoTotal))
-	if len(tempoWorklogs) > 0 {
-		for _, wl := range tempoWorklogs {
+	// Display remote worklogs (source of truth)
+	fmt.Printf("\n✓ %s Worklogs (%d entries): %s\n", source, len(remoteWorklogs), timeparse.Format(remoteTotal))
+	if len(remoteWorklogs) > 0 {
+		for _, wl := range remoteWorklogs {
 			fmt.Printf("  %s - %-10s [%-12s] %s\n",
-				wl.StartTime,
-				timeparse.Format(wl.TimeSpentSeconds),
-				wl.Description,
+				wl.Started,
+				timeparse.Format(wl.Seconds),
+				wl.Detail,
 				wl.IssueKey,
 			)
 		}
@@ -399,15 +940,15 @@ func showTodaySummary(store *storage.Storage, jiraClient *jira.Client, tempoClie
 
 	fmt.Println("\n═══════════════════════════════════════════")
 
-	// Show comparison between Tempo and local data
+	// Show comparison between remote and local data
 	if len(localEntries) > 0 {
-		diff := tempoTotal - localTotal
+		diff := remoteTotal - localTotal
 		if diff == 0 {
-			fmt.Println("✓ Local cache matches Tempo")
+			fmt.Printf("✓ Local cache matches %s\n", source)
 		} else if diff > 0 {
-			fmt.Printf("⚠️  Tempo has %s more than local cache\n", timeparse.Format(diff))
+			fmt.Printf("⚠️  %s has %s more than local cache\n", source, timeparse.Format(diff))
 		} else {
-			fmt.Printf("⚠️  Local cache has %s not synced to Tempo\n", timeparse.Format(-diff))
+			fmt.Printf("⚠️  Local cache has %s not synced to %s\n", timeparse.Format(-diff), source)
 		}
 	}
 
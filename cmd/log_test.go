@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+)
+
+func TestTempoWorklogContent(t *testing.T) {
+	mapped := &config.Config{Tempo: config.TempoConfig{
+		LabelAttribute: "_WorkType_",
+		LabelValues:    map[string]string{"development": "Development"},
+	}}
+
+	description, attributes := tempoWorklogContent(mapped, &storage.TimeEntry{Label: "development", Comment: "Fixed login"})
+	if description != "Fixed login" {
+		t.Errorf("expected comment as description, got %q", description)
+	}
+	if len(attributes) != 1 || attributes[0].Key != "_WorkType_" || attributes[0].Value != "Development" {
+		t.Errorf("expected mapped work attribute, got %+v", attributes)
+	}
+
+	_, attributes = tempoWorklogContent(mapped, &storage.TimeEntry{Label: "meeting"})
+	if len(attributes) != 1 || attributes[0].Value != "meeting" {
+		t.Errorf("expected unmapped label as attribute value, got %+v", attributes)
+	}
+
+	// Without a label attribute the label stays in the description
+	description, attributes = tempoWorklogContent(&config.Config{}, &storage.TimeEntry{Label: "meeting", Comment: "Standup"})
+	if description != "[meeting] Standup" || attributes != nil {
+		t.Errorf("expected label in description, got %q %+v", description, attributes)
+	}
+}
+
+func TestTempoWorklogContent_Account(t *testing.T) {
+	cfg := &config.Config{Tempo: config.TempoConfig{AccountAttribute: "_Account_"}}
+
+	_, attributes := tempoWorklogContent(cfg, &storage.TimeEntry{Label: "development", Account: "ACME"})
+	if len(attributes) != 1 || attributes[0].Key != "_Account_" || attributes[0].Value != "ACME" {
+		t.Errorf("expected account attribute, got %+v", attributes)
+	}
+}
+
+func TestAccountOptions(t *testing.T) {
+	links := []tempo.AccountLink{
+		{Account: tempo.Account{Key: "INTERNAL", Name: "Internal"}},
+		{Account: tempo.Account{Key: "ACME", Name: "Acme Corp"}, Default: true},
+	}
+
+	options, keys := accountOptions(links)
+	if len(options) != 2 || options[0] != "Acme Corp (ACME) - default" || options[1] != "Internal (INTERNAL)" {
+		t.Errorf("expected the default account first, got %v", options)
+	}
+	if keys[options[1]] != "INTERNAL" {
+		t.Errorf("expected option to map to its key, got %q", keys[options[1]])
+	}
+}
+
+func TestFormatEstimates(t *testing.T) {
+	if got := formatEstimates(nil); got != "" {
+		t.Errorf("expected nothing without time tracking, got %q", got)
+	}
+	if got := formatEstimates(&jira.IssueTimeTracking{}); got != "" {
+		t.Errorf("expected nothing for empty time tracking, got %q", got)
+	}
+
+	tt := &jira.IssueTimeTracking{OriginalEstimate: "2d", RemainingEstimate: "1d 4h"}
+	if got := formatEstimates(tt); got != "2d original, 1d 4h remaining, - spent" {
+		t.Errorf("unexpected estimates: %q", got)
+	}
+}
+
+func TestFindTransition(t *testing.T) {
+	transitions := []jira.Transition{
+		{ID: "11", Name: "Start Progress", To: jira.IssueStatus{Name: "In Progress"}},
+		{ID: "21", Name: "Done", To: jira.IssueStatus{Name: "Closed"}},
+	}
+
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{"in progress", "11"},    // By status, case-insensitive
+		{"Start Progress", "11"}, // By transition name
+		{"Done", "21"},
+	}
+	for _, tt := range tests {
+		transition, err := findTransition(transitions, tt.target)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.target, err)
+		}
+		if transition.ID != tt.want {
+			t.Errorf("%s: expected transition %s, got %s", tt.target, tt.want, transition.ID)
+		}
+	}
+
+	if _, err := findTransition(transitions, "Review"); err == nil {
+		t.Error("expected an error for an unavailable status")
+	}
+}
+
+func TestOfflineIssue(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	if err := store.SaveIssue(storage.CachedIssue{Key: "PROJ-1", Summary: "Fix login", Project: "PROJ"}); err != nil {
+		t.Fatalf("failed to cache issue: %v", err)
+	}
+
+	issue := offlineIssue(store, " proj-1 ")
+	if issue.Key != "PROJ-1" || issue.Fields.Summary != "Fix login" {
+		t.Errorf("expected cached PROJ-1, got %+v", issue)
+	}
+
+	issue = offlineIssue(store, "PROJ-2")
+	if issue.Key != "PROJ-2" || issue.Fields.Summary != "" {
+		t.Errorf("expected PROJ-2 without summary, got %+v", issue)
+	}
+	if got := issueTitle(issue); got == "" {
+		t.Error("expected a placeholder title for an unknown summary")
+	}
+}
+
+func TestFetchSelectedIssue(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	picked := &jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{Summary: "Login fix", Status: jira.IssueStatus{Name: "To Do"}}}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/3/issue/PROJ-1":
+			json.NewEncoder(w).Encode(jira.Issue{Key: "PROJ-1", Fields: jira.IssueFields{
+				Summary:      "Login fix",
+				Status:       jira.IssueStatus{Name: "In Progress"},
+				TimeTracking: &jira.IssueTimeTracking{TimeSpent: "2h"},
+			}})
+		case "/rest/api/3/issue/PROJ-2":
+			http.Error(w, "not found", http.StatusNotFound)
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	jiraClient := jira.NewClient(server.URL, "me@example.com", "token", "PROJ")
+
+	issue, err := fetchSelectedIssue(store, jiraClient, picked)
+	if err != nil {
+		t.Fatalf("fetchSelectedIssue failed: %v", err)
+	}
+	if issue.Fields.Status.Name != "In Progress" || issue.Fields.TimeTracking == nil {
+		t.Errorf("expected the current status and time tracking, got %+v", issue.Fields)
+	}
+
+	if _, err := fetchSelectedIssue(store, jiraClient, &jira.Issue{Key: "PROJ-2"}); err == nil {
+		t.Error("expected an error for an issue Jira does not find")
+	}
+
+	issue, err = fetchSelectedIssue(store, jiraClient, &jira.Issue{Key: "PROJ-3", Fields: jira.IssueFields{Summary: "Cached"}})
+	if err != nil {
+		t.Fatalf("fetchSelectedIssue failed: %v", err)
+	}
+	if issue.Fields.Summary != "Cached" {
+		t.Errorf("expected the picked issue while Jira is unreachable, got %+v", issue)
+	}
+}
@@ -0,0 +1,163 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/oauth"
+	"tasklog/internal/ui"
+)
+
+// jiraSessionKey is the secret store key of the Jira OAuth session
+const jiraSessionKey = "jira-oauth"
+
+// loginTimeout is how long to wait for the user to approve access in the browser
+const loginTimeout = 5 * time.Minute
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Sign in to an external service",
+}
+
+var loginJiraCmd = &cobra.Command{
+	Use:   "jira",
+	Short: "Sign in to Jira Cloud with OAuth",
+	Long: `Signs in to Jira Cloud with OAuth 2.0 instead of an API token.
+
+Your browser opens Atlassian's consent page. After you approve access, Atlassian
+redirects back to a temporary server on localhost, and tasklog keeps the refresh
+token in ~/.tasklog/secrets.json (readable only by you). Access tokens are refreshed
+automatically, so you only need to log in again if you revoke access.
+
+Setup:
+  1. Create an OAuth 2.0 (3LO) app at https://developer.atlassian.com/console/myapp/
+  2. Add the Jira API scopes read:jira-work, write:jira-work and read:jira-user
+  3. Set the callback URL to http://localhost:8765/callback
+  4. Configure tasklog:
+
+     jira:
+       auth: oauth
+       oauth:
+         client_id: "your-client-id"
+         client_secret: "your-client-secret"` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runLoginJira,
+}
+
+func init() {
+	rootCmd.AddCommand(loginCmd)
+	loginCmd.AddCommand(loginJiraCmd)
+}
+
+func runLoginJira(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Jira.Auth != "oauth" {
+		return fmt.Errorf("set jira.auth to oauth and configure jira.oauth.client_id in your config.yaml first")
+	}
+
+	store, err := newSecretStore()
+	if err != nil {
+		return err
+	}
+
+	oauthConfig := jiraOAuthConfig(cfg)
+
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+
+	token, err := oauth.Login(ctx, oauthConfig, func(authURL string) error {
+		fmt.Println("Opening your browser to sign in to Jira...")
+		fmt.Printf("If it does not open, visit:\n\n  %s\n\n", authURL)
+		if err := openBrowser(authURL); err != nil {
+			log.Debug().Err(err).Msg("Failed to open browser")
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to log in: %w", err)
+	}
+
+	resources, err := oauth.AccessibleResources(oauthConfig, token.AccessToken)
+	if err != nil {
+		return err
+	}
+	site, err := selectJiraSite(cfg, resources)
+	if err != nil {
+		return err
+	}
+
+	if err := oauth.SaveSession(store, jiraSessionKey, &oauth.Session{
+		RefreshToken: token.RefreshToken,
+		CloudID:      site.ID,
+		SiteURL:      site.URL,
+	}); err != nil {
+		return fmt.Errorf("failed to save login: %w", err)
+	}
+
+	fmt.Printf("✓ Logged in to %s\n", site.URL)
+	return nil
+}
+
+// jiraOAuthConfig returns the Atlassian OAuth configuration for the Jira OAuth app
+func jiraOAuthConfig(cfg *config.Config) oauth.Config {
+	return oauth.AtlassianConfig(cfg.Jira.OAuth.ClientID, cfg.Jira.OAuth.ClientSecret, cfg.Jira.OAuth.CallbackPort)
+}
+
+// selectJiraSite picks the site matching jira.url, or asks when access was granted to several sites
+func selectJiraSite(cfg *config.Config, resources []oauth.Resource) (*oauth.Resource, error) {
+	if len(resources) == 0 {
+		return nil, fmt.Errorf("no Jira sites were authorized")
+	}
+
+	configured := strings.TrimSuffix(cfg.Jira.URL, "/")
+	for i := range resources {
+		if strings.TrimSuffix(resources[i].URL, "/") == configured {
+			return &resources[i], nil
+		}
+	}
+	if len(resources) == 1 {
+		return &resources[0], nil
+	}
+
+	urls := make([]string, len(resources))
+	for i, r := range resources {
+		urls[i] = r.URL
+	}
+	selected, err := ui.SelectOption("Select a Jira site:", urls)
+	if err != nil {
+		return nil, err
+	}
+	for i := range resources {
+		if resources[i].URL == selected {
+			return &resources[i], nil
+		}
+	}
+	return nil, fmt.Errorf("unknown site: %s", selected)
+}
+
+// openBrowser opens a URL in the default browser
+func openBrowser(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	return cmd.Start()
+}
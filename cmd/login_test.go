@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"testing"
+
+	"tasklog/internal/config"
+	"tasklog/internal/oauth"
+)
+
+func TestSelectJiraSite(t *testing.T) {
+	cfg := &config.Config{Jira: config.JiraConfig{URL: "https://second.atlassian.net/"}}
+	resources := []oauth.Resource{
+		{ID: "cloud-1", URL: "https://first.atlassian.net"},
+		{ID: "cloud-2", URL: "https://second.atlassian.net"},
+	}
+
+	site, err := selectJiraSite(cfg, resources)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if site.ID != "cloud-2" {
+		t.Errorf("expected the site matching jira.url, got %s", site.ID)
+	}
+
+	// A single authorized site is used even if jira.url differs
+	site, err = selectJiraSite(cfg, resources[:1])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if site.ID != "cloud-1" {
+		t.Errorf("expected the only site, got %s", site.ID)
+	}
+
+	if _, err := selectJiraSite(cfg, nil); err == nil {
+		t.Error("expected an error without authorized sites")
+	}
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/rs/zerolog/log"
+
+	"tasklog/internal/config"
+	"tasklog/internal/notify"
+)
+
+// namedNotifier is a configured notifier with the name it is referenced by
+type namedNotifier struct {
+	name string
+	notify.Notifier
+}
+
+// newNotifiers returns the notifiers notified of event
+// With names, only the named notifiers are returned regardless of their events
+// Slack is notified of every event unless a slack notifier entry is configured
+func newNotifiers(cfg *config.Config, event string, names []string) ([]namedNotifier, error) {
+	selected := func(name string, events []string) bool {
+		if len(names) > 0 {
+			return slices.Contains(names, name)
+		}
+		return len(events) == 0 || slices.Contains(events, event)
+	}
+
+	var notifiers []namedNotifier
+	if !cfg.HasNotifierEntry("slack") && cfg.Slack.UserToken != "" && cfg.Slack.ChannelID != "" && selected("slack", nil) {
+		client := newSlackClient(cfg, cfg.Slack.ChannelID)
+		notifiers = append(notifiers, namedNotifier{name: "slack", Notifier: notify.NewSlack(client)})
+	}
+
+	for _, entry := range cfg.Notifiers {
+		if !selected(entry.Name, entry.Events) {
+			continue
+		}
+		n, err := newNotifier(cfg, entry, event)
+		if err != nil {
+			return nil, fmt.Errorf("notifier '%s': %w", entry.Name, err)
+		}
+		notifiers = append(notifiers, namedNotifier{name: entry.Name, Notifier: n})
+	}
+	return notifiers, nil
+}
+
+// newNotifier creates the notifier of a notifier entry
+func newNotifier(cfg *config.Config, entry config.NotifierEntry, event string) (notify.Notifier, error) {
+	switch entry.Type {
+	case "slack":
+		channel := entry.Channel
+		if channel == "" {
+			channel = cfg.Slack.ChannelID
+		}
+		return notify.NewSlack(newSlackClient(cfg, channel)), nil
+	case "teams":
+		return notify.NewTeams(entry.URL), nil
+	case "mattermost":
+		return notify.NewMattermost(entry.URL), nil
+	case "discord":
+		return notify.NewDiscord(entry.URL), nil
+	case "webhook":
+		body, err := notify.ParseBodyTemplate(entry.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid body template: %w", err)
+		}
+		return notify.NewGenericWebhook(entry.URL, event, body, entry.Headers), nil
+	default:
+		return nil, fmt.Errorf("unknown notifier type %q", entry.Type)
+	}
+}
+
+// postToNotifiers posts a message to every notifier, reporting each result
+// Returns the number of notifiers the message was posted to
+func postToNotifiers(notifiers []namedNotifier, msg notify.Message) int {
+	posted := 0
+	for _, n := range notifiers {
+		if err := n.PostMessage(msg); err != nil {
+			log.Error().Err(err).Str("notifier", n.name).Msg("Failed to post message")
+			fmt.Printf("⚠️  Failed to post to %s: %v\n", n.name, err)
+			continue
+		}
+		fmt.Printf("✓ Posted to %s\n", n.name)
+		posted++
+	}
+	return posted
+}
+
+// isUnsupported reports whether a notifier cannot do an operation, like webhooks setting a status
+func isUnsupported(err error) bool {
+	return errors.Is(err, notify.ErrUnsupported)
+}
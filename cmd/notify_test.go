@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+
+	"tasklog/internal/config"
+	"tasklog/internal/notify"
+	"tasklog/internal/storage"
+)
+
+func notifierNames(notifiers []namedNotifier) []string {
+	names := make([]string, len(notifiers))
+	for i, n := range notifiers {
+		names[i] = n.name
+	}
+	return names
+}
+
+func TestNewNotifiers(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Slack.UserToken = "xoxp-token"
+	cfg.Slack.ChannelID = "C123"
+	cfg.Notifiers = []config.NotifierEntry{
+		{Name: "teams", Type: "teams", URL: "https://example.com/teams", Events: []string{"break", "standup"}},
+		{Name: "hook", Type: "webhook", URL: "https://example.com/hook", Body: `{"text": {{json .Text}}}`, Events: []string{"summary"}},
+	}
+
+	tests := []struct {
+		name  string
+		event string
+		names []string
+		want  []string
+	}{
+		{"break", notify.EventBreak, nil, []string{"slack", "teams"}},
+		{"summary", notify.EventSummary, nil, []string{"slack", "hook"}},
+		{"named for a break", notify.EventBreak, []string{"hook"}, []string{"hook"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifiers, err := newNotifiers(cfg, tt.event, tt.names)
+			if err != nil {
+				t.Fatalf("newNotifiers failed: %v", err)
+			}
+			got := notifierNames(notifiers)
+			if len(got) != len(tt.want) {
+				t.Fatalf("notifiers = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if got[i] != tt.want[i] {
+					t.Errorf("notifiers = %v, want %v", got, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewNotifiers_SlackEntry(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Slack.UserToken = "xoxp-token"
+	cfg.Slack.ChannelID = "C123"
+	cfg.Notifiers = []config.NotifierEntry{{Name: "standups", Type: "slack", Channel: "C456", Events: []string{"standup"}}}
+
+	notifiers, err := newNotifiers(cfg, notify.EventBreak, nil)
+	if err != nil {
+		t.Fatalf("newNotifiers failed: %v", err)
+	}
+	if len(notifiers) != 0 {
+		t.Errorf("expected the slack entry to replace the built-in slack notifier, got %v", notifierNames(notifiers))
+	}
+}
+
+func TestNewNotifiers_InvalidBody(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Notifiers = []config.NotifierEntry{{Name: "hook", Type: "webhook", URL: "https://example.com", Body: "{{"}}
+
+	if _, err := newNotifiers(cfg, notify.EventBreak, nil); err == nil {
+		t.Error("expected an error for an invalid body template")
+	}
+}
+
+func TestBuildDailySummary(t *testing.T) {
+	if got := buildDailySummary(nil); got != "Nothing logged today" {
+		t.Errorf("buildDailySummary(nil) = %q", got)
+	}
+
+	got := buildDailySummary([]storage.TimeEntry{
+		{IssueKey: "PROJ-1", IssueSummary: "Login fix", TimeSpentSeconds: 1800},
+		{IssueKey: "PROJ-2", IssueSummary: "Payments", TimeSpentSeconds: 3600},
+	})
+	want := "*Total* — 1h 30m\n• PROJ-2 Payments — 1h\n• PROJ-1 Login fix — 30m"
+	if got != want {
+		t.Errorf("buildDailySummary() =\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,243 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/tempo"
+	"tasklog/internal/timeparse"
+)
+
+// maxPlannedSuggestions limits how many planned issues are fetched for the task picker
+const maxPlannedSuggestions = 5
+
+var planWeek bool
+
+var planCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "Compare your Tempo plans with the time you logged",
+	Long: `Shows the issues Tempo Planner allocated you to, next to the time you logged on
+them in Tempo, with what is still left, what is overspent, and what you logged
+without a plan.
+
+Planned issues for today are also offered at the top of the 'tasklog log' picker.
+
+Examples:
+  tasklog plan          # Today
+  tasklog plan --week   # This week (Monday to Sunday)` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runPlan,
+}
+
+func init() {
+	rootCmd.AddCommand(planCmd)
+
+	planCmd.Flags().BoolVar(&planWeek, "week", false, "Show the whole week instead of today")
+}
+
+// planRow is the planned and logged time of one issue
+type planRow struct {
+	IssueID int64
+	Planned int
+	Logged  int
+}
+
+// Status describes how the logged time compares with the plan
+func (r planRow) Status() string {
+	switch {
+	case r.Planned == 0:
+		return "unplanned"
+	case r.Logged > r.Planned:
+		return fmt.Sprintf("overspent by %s", timeparse.Format(r.Logged-r.Planned))
+	case r.Logged == r.Planned:
+		return "done"
+	default:
+		return fmt.Sprintf("%s left", timeparse.Format(r.Planned-r.Logged))
+	}
+}
+
+func runPlan(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	if !cfg.Tempo.Enabled || cfg.Tempo.APIToken == "" {
+		return fmt.Errorf("plans require tempo.enabled and tempo.api_token")
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+
+	currentUser, err := jiraClient.GetCurrentUser()
+	if err != nil {
+		return fmt.Errorf("failed to get current user: %w", err)
+	}
+
+	from, to := planRange(time.Now(), planWeek)
+
+	plans, err := tempoClient.GetPlans(currentUser.AccountID, from, to)
+	if err != nil {
+		return err
+	}
+	worklogs, err := tempoClient.GetWorklogs(from, to, currentUser.AccountID)
+	if err != nil {
+		return err
+	}
+
+	rows := comparePlan(plans, worklogs)
+	if from.Equal(to) {
+		fmt.Printf("📅 Plan for %s\n\n", from.Format("Mon Jan 2"))
+	} else {
+		fmt.Printf("📅 Plan for %s - %s\n\n", from.Format("Mon Jan 2"), to.Format("Mon Jan 2"))
+	}
+	if len(rows) == 0 {
+		fmt.Println("Nothing planned or logged.")
+		return nil
+	}
+
+	issues := planIssues(jiraClient, rows)
+
+	var plannedTotal, loggedTotal int
+	for _, row := range rows {
+		plannedTotal += row.Planned
+		loggedTotal += row.Logged
+
+		title := strconv.FormatInt(row.IssueID, 10)
+		if issue, ok := issues[row.IssueID]; ok {
+			title = fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary)
+		}
+
+		fmt.Printf("  %-50.50s  planned %-8s logged %-8s %s\n",
+			title,
+			timeparse.Format(row.Planned),
+			timeparse.Format(row.Logged),
+			row.Status(),
+		)
+	}
+
+	fmt.Printf("\nPlanned %s, logged %s\n", timeparse.Format(plannedTotal), timeparse.Format(loggedTotal))
+	return nil
+}
+
+// planRange returns the first and last day to compare: today, or the current week from Monday
+func planRange(now time.Time, week bool) (time.Time, time.Time) {
+	today := startOfDay(now)
+	if !week {
+		return today, today
+	}
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	return monday, monday.AddDate(0, 0, 6)
+}
+
+// comparePlan sums planned and logged time per issue
+// Planned issues come first, most planned first, followed by unplanned issues, most logged first
+func comparePlan(plans []tempo.Plan, worklogs []tempo.WorklogResponse) []planRow {
+	byIssue := map[int64]*planRow{}
+	row := func(issueID int64) *planRow {
+		if byIssue[issueID] == nil {
+			byIssue[issueID] = &planRow{IssueID: issueID}
+		}
+		return byIssue[issueID]
+	}
+
+	for _, plan := range plans {
+		if plan.PlanItem.Type != tempo.IssuePlanType {
+			log.Debug().Str("type", plan.PlanItem.Type).Int64("plan", plan.ID).Msg("Skipping plan that is not for an issue")
+			continue
+		}
+		row(plan.PlanItem.ID).Planned += plan.TotalPlannedSecondsInScope
+	}
+	for _, wl := range worklogs {
+		row(wl.Issue.ID).Logged += wl.TimeSpentSeconds
+	}
+
+	rows := make([]planRow, 0, len(byIssue))
+	for _, r := range byIssue {
+		rows = append(rows, *r)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if (rows[i].Planned > 0) != (rows[j].Planned > 0) {
+			return rows[i].Planned > 0
+		}
+		if rows[i].Planned != rows[j].Planned {
+			return rows[i].Planned > rows[j].Planned
+		}
+		if rows[i].Logged != rows[j].Logged {
+			return rows[i].Logged > rows[j].Logged
+		}
+		return rows[i].IssueID < rows[j].IssueID
+	})
+	return rows
+}
+
+// plannedIssues fetches the issues planned for today, to suggest them in the task picker
+// Returns nil when Tempo is not configured or the plans cannot be fetched
+func plannedIssues(cfg *config.Config, jiraClient *jira.Client) []jira.Issue {
+	if !cfg.Tempo.Enabled || cfg.Tempo.APIToken == "" {
+		return nil
+	}
+
+	currentUser, err := jiraClient.GetCurrentUser()
+	if err != nil {
+		log.Debug().Err(err).Msg("Skipping planned issue suggestions")
+		return nil
+	}
+
+	today := startOfDay(time.Now())
+	plans, err := tempo.NewClient(cfg.Tempo.APIToken).GetPlans(currentUser.AccountID, today, today)
+	if err != nil {
+		log.Debug().Err(err).Msg("Skipping planned issue suggestions")
+		return nil
+	}
+
+	rows := comparePlan(plans, nil)
+	if len(rows) > maxPlannedSuggestions {
+		rows = rows[:maxPlannedSuggestions]
+	}
+	byID := planIssues(jiraClient, rows)
+
+	var issues []jira.Issue
+	for _, row := range rows {
+		if issue, ok := byID[row.IssueID]; ok {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
+
+// planIssues resolves the issues of plan rows, keyed by issue ID, with a single search
+// Returns an empty map when the issues cannot be fetched, leaving rows to show their IDs
+func planIssues(jiraClient *jira.Client, rows []planRow) map[int64]jira.Issue {
+	ids := make([]int64, len(rows))
+	for i, row := range rows {
+		ids[i] = row.IssueID
+	}
+
+	byID := map[int64]jira.Issue{}
+	issues, err := jiraClient.GetIssuesByID(ids)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to fetch planned issues")
+		return byID
+	}
+	for _, issue := range issues {
+		id, err := strconv.ParseInt(issue.ID, 10, 64)
+		if err != nil {
+			continue
+		}
+		byID[id] = issue
+	}
+	return byID
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/tempo"
+)
+
+func issuePlan(issueID int64, seconds int) tempo.Plan {
+	plan := tempo.Plan{TotalPlannedSecondsInScope: seconds}
+	plan.PlanItem.ID = issueID
+	plan.PlanItem.Type = tempo.IssuePlanType
+	return plan
+}
+
+func issueWorklog(issueID int64, seconds int) tempo.WorklogResponse {
+	wl := tempo.WorklogResponse{TimeSpentSeconds: seconds}
+	wl.Issue.ID = issueID
+	return wl
+}
+
+func TestComparePlan(t *testing.T) {
+	projectPlan := tempo.Plan{TotalPlannedSecondsInScope: 36000}
+	projectPlan.PlanItem.ID = 99
+	projectPlan.PlanItem.Type = "PROJECT"
+
+	plans := []tempo.Plan{issuePlan(1, 7200), issuePlan(2, 14400), issuePlan(1, 3600), projectPlan}
+	worklogs := []tempo.WorklogResponse{issueWorklog(1, 14400), issueWorklog(2, 3600), issueWorklog(3, 1800)}
+
+	rows := comparePlan(plans, worklogs)
+	want := []planRow{
+		{IssueID: 2, Planned: 14400, Logged: 3600},
+		{IssueID: 1, Planned: 10800, Logged: 14400},
+		{IssueID: 3, Planned: 0, Logged: 1800},
+	}
+	if len(rows) != len(want) {
+		t.Fatalf("expected %d rows, got %+v", len(want), rows)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d: expected %+v, got %+v", i, want[i], rows[i])
+		}
+	}
+
+	statuses := []string{"3h left", "overspent by 1h", "unplanned"}
+	for i, status := range statuses {
+		if got := rows[i].Status(); got != status {
+			t.Errorf("row %d: expected status %q, got %q", i, status, got)
+		}
+	}
+}
+
+func TestPlanRange(t *testing.T) {
+	now := time.Date(2025, 1, 15, 14, 30, 0, 0, time.Local) // Wednesday
+
+	from, to := planRange(now, false)
+	if !from.Equal(to) || from.Day() != 15 || from.Hour() != 0 {
+		t.Errorf("expected today, got %v - %v", from, to)
+	}
+
+	from, to = planRange(now, true)
+	if from.Weekday() != time.Monday || from.Day() != 13 || to.Day() != 19 {
+		t.Errorf("expected Monday to Sunday, got %v - %v", from, to)
+	}
+}
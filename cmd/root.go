@@ -1,12 +1,18 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/oauth"
 	"tasklog/internal/prerelease"
+	"tasklog/internal/secrets"
+	"tasklog/internal/slack"
 	"tasklog/internal/updater"
 
 	"github.com/rs/zerolog/log"
@@ -123,9 +129,51 @@ func checkConfig() (*config.Config, error) {
 		fmt.Fprintf(os.Stderr, "See config.example.yaml for an example configuration.\n")
 		return nil, err
 	}
+
 	return cfg, nil
 }
 
+// newJiraClient creates a Jira client for the configured flavor and authentication
+func newJiraClient(cfg *config.Config) (*jira.Client, error) {
+	if cfg.Jira.Auth == "oauth" {
+		store, err := newSecretStore()
+		if err != nil {
+			return nil, err
+		}
+		session, err := oauth.LoadSession(store, jiraSessionKey)
+		if errors.Is(err, oauth.ErrNotLoggedIn) {
+			return nil, fmt.Errorf("not logged in to Jira, run 'tasklog login jira'")
+		}
+		if err != nil {
+			return nil, err
+		}
+		tokens := oauth.NewTokenSource(jiraOAuthConfig(cfg), store, jiraSessionKey)
+		return jira.NewOAuthClient(session.CloudID, tokens, cfg.Jira.ProjectKey), nil
+	}
+
+	if cfg.Jira.Flavor == string(jira.FlavorDataCenter) {
+		return jira.NewDataCenterClient(cfg.Jira.URL, cfg.Jira.APIToken, cfg.Jira.ProjectKey), nil
+	}
+	return jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey), nil
+}
+
+// newSlackClient creates a Slack client posting to channel, calling slack.base_url when set
+func newSlackClient(cfg *config.Config, channel string) *slack.Client {
+	if cfg.Slack.BaseURL != "" {
+		return slack.NewClientWithBaseURL(cfg.Slack.BaseURL, cfg.Slack.UserToken, channel)
+	}
+	return slack.NewClient(cfg.Slack.UserToken, channel)
+}
+
+// newSecretStore opens the secret store next to the config file
+func newSecretStore() (secrets.Store, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get config directory: %w", err)
+	}
+	return secrets.NewFileStore(filepath.Join(configDir, "secrets.json")), nil
+}
+
 var (
 	version = "dev"
 	commit  = "none"
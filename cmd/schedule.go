@@ -0,0 +1,319 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/schedule"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+var scheduleDryRun bool
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "Run and inspect scheduled shortcuts",
+	Long: `Log shortcuts automatically based on the schedule defined in your config.
+
+Add a schedule to any shortcut:
+  jira:
+    shortcuts:
+      - name: "daily"
+        task: "PROJ-123"
+        time: "30m"
+        label: "meeting"
+        schedule:
+          days: ["mon", "tue", "wed", "thu", "fri"]
+          at: "09:30"
+          # or: cron: "30 9 * * 1-5"
+
+Then call 'tasklog schedule run' from cron, a systemd timer or your shell profile.` + configHelp,
+}
+
+var scheduleRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "Log every scheduled shortcut that is due",
+	Long: `Logs every scheduled shortcut that is due and not logged yet.
+
+A shortcut is skipped on configured holidays and on days that already have an
+entry logged with that shortcut, so it is safe to run this command repeatedly.
+Missed runs from previous days are caught up when scheduler.catch_up_days is set.` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runScheduleRun,
+}
+
+var scheduleListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Show scheduled shortcuts and their next run times",
+	Args:  cobra.NoArgs,
+	RunE:  runScheduleList,
+}
+
+func init() {
+	rootCmd.AddCommand(scheduleCmd)
+	scheduleCmd.AddCommand(scheduleRunCmd)
+	scheduleCmd.AddCommand(scheduleListCmd)
+
+	scheduleRunCmd.Flags().BoolVar(&scheduleDryRun, "dry-run", false, "Show what would be logged without logging anything")
+}
+
+func runScheduleRun(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	windowStart := startOfDay(now).AddDate(0, 0, -cfg.Scheduler.CatchUpDays)
+
+	loggedCount := 0
+	failureCount := 0
+
+	for _, sc := range cfg.Jira.Shortcuts {
+		if sc.Schedule == nil {
+			continue
+		}
+
+		sched, err := shortcutSchedule(sc)
+		if err != nil {
+			fmt.Printf("✗ %s: %v\n", sc.Name, err)
+			failureCount++
+			continue
+		}
+
+		for _, runAt := range dueRuns(sched, windowStart, now) {
+			day := runAt.Format("Mon Jan 2")
+
+			if cfg.IsHoliday(runAt) {
+				log.Debug().Str("shortcut", sc.Name).Str("day", day).Msg("Skipping holiday")
+				continue
+			}
+
+			logged, err := shortcutLogged(store, sc, runAt)
+			if err != nil {
+				return fmt.Errorf("failed to check existing entries: %w", err)
+			}
+			if logged {
+				log.Debug().Str("shortcut", sc.Name).Str("day", day).Msg("Already logged")
+				continue
+			}
+
+			if scheduleDryRun {
+				fmt.Printf("• Would log %s (%s) for %s\n", sc.Name, sc.Task, runAt.Format("Mon Jan 2 15:04"))
+				continue
+			}
+
+			fmt.Printf("⏰ Logging %s (%s) for %s\n", sc.Name, sc.Task, runAt.Format("Mon Jan 2 15:04"))
+			if err := logScheduledShortcut(cfg, store, jiraClient, sc, runAt); err != nil {
+				log.Error().Err(err).Str("shortcut", sc.Name).Msg("Failed to log scheduled shortcut")
+				fmt.Printf("  ✗ %v\n", err)
+				failureCount++
+				continue
+			}
+			loggedCount++
+		}
+	}
+
+	if scheduleDryRun {
+		return nil
+	}
+
+	if loggedCount == 0 && failureCount == 0 {
+		fmt.Println("✓ Nothing due")
+		return nil
+	}
+
+	fmt.Printf("\nSchedule run complete: %d logged, %d failed\n", loggedCount, failureCount)
+	return nil
+}
+
+func runScheduleList(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	found := false
+
+	for _, sc := range cfg.Jira.Shortcuts {
+		if sc.Schedule == nil {
+			continue
+		}
+
+		if !found {
+			fmt.Println("📅 Scheduled shortcuts:")
+			fmt.Println()
+			found = true
+		}
+
+		sched, err := shortcutSchedule(sc)
+		if err != nil {
+			fmt.Printf("  ✗ %-15s %v\n", sc.Name, err)
+			continue
+		}
+
+		next := "never"
+		if runAt := nextRun(cfg, sched, now); !runAt.IsZero() {
+			next = runAt.Format("Mon Jan 2 15:04")
+		}
+
+		todayStatus := ""
+		if logged, err := shortcutLogged(store, sc, now); err == nil && logged {
+			todayStatus = " (logged today)"
+		}
+
+		fmt.Printf("  %-15s %-10s %-28s next: %s%s\n", sc.Name, sc.Task, describeSchedule(sc.Schedule), next, todayStatus)
+	}
+
+	if !found {
+		fmt.Println("No scheduled shortcuts. Add a 'schedule' to a shortcut in your config.yaml")
+	}
+
+	return nil
+}
+
+// shortcutLogged reports whether a day is already covered for a scheduled shortcut,
+// either by the shortcut itself or by time logged on its task another way
+func shortcutLogged(store *storage.Storage, sc config.ShortcutEntry, day time.Time) (bool, error) {
+	return store.HasShortcutEntry(sc.Name, strings.ToUpper(sc.Task), day)
+}
+
+// logScheduledShortcut logs a shortcut non-interactively for the given run time
+func logScheduledShortcut(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, sc config.ShortcutEntry, runAt time.Time) error {
+	if sc.Time == "" {
+		return fmt.Errorf("shortcut '%s' has no time set; scheduled shortcuts require a predefined time", sc.Name)
+	}
+
+	timeSeconds, err := timeparse.Parse(sc.Time)
+	if err != nil {
+		return fmt.Errorf("invalid time format: %w", err)
+	}
+
+	applyTempoLabels(cfg)
+	if !cfg.IsLabelAllowed(sc.Label) {
+		return fmt.Errorf("label '%s' is not in the allowed labels list", sc.Label)
+	}
+
+	issue, err := jiraClient.GetIssue(sc.Task)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task %s: %w", sc.Task, err)
+	}
+
+	entry := &storage.TimeEntry{
+		IssueKey:         issue.Key,
+		IssueSummary:     issue.Fields.Summary,
+		TimeSpentSeconds: timeSeconds,
+		TimeSpent:        timeparse.Format(timeSeconds),
+		Label:            sc.Label,
+		Started:          runAt,
+		Shortcut:         sc.Name,
+		Account:          sc.Account,
+	}
+
+	opts := jira.WorklogOptions{AdjustEstimate: sc.AdjustEstimate, Estimate: sc.Estimate}
+	if err := opts.Validate(); err != nil {
+		return fmt.Errorf("shortcut '%s': %w", sc.Name, err)
+	}
+	if err := submitEntry(cfg, store, jiraClient, entry, opts); err != nil {
+		return err
+	}
+
+	if sc.Transition != "" {
+		if err := transitionIssue(jiraClient, issue, sc.Transition); err != nil {
+			log.Error().Err(err).Str("shortcut", sc.Name).Msg("Failed to transition issue")
+			fmt.Printf("⚠ Failed to move %s to %s: %v\n", issue.Key, sc.Transition, err)
+		}
+	}
+	return nil
+}
+
+// shortcutSchedule parses the schedule configured for a shortcut
+func shortcutSchedule(sc config.ShortcutEntry) (*schedule.Schedule, error) {
+	return parseSchedule(sc.Schedule)
+}
+
+// parseSchedule parses a configured schedule, given either as cron or as days/at
+func parseSchedule(entry *config.ScheduleEntry) (*schedule.Schedule, error) {
+	switch {
+	case entry.Cron != "" && entry.At != "":
+		return nil, fmt.Errorf("schedule must use either cron or days/at, not both")
+	case entry.Cron != "":
+		return schedule.ParseCron(entry.Cron)
+	case entry.At != "":
+		return schedule.FromWeekdays(entry.Days, entry.At)
+	default:
+		return nil, fmt.Errorf("schedule must define either cron or at")
+	}
+}
+
+// dueRuns returns the first scheduled time of each day between windowStart and now
+// A shortcut is logged at most once per day, even if its schedule fires more often
+func dueRuns(sched *schedule.Schedule, windowStart, now time.Time) []time.Time {
+	var runs []time.Time
+	seenDays := map[string]bool{}
+	for _, runAt := range sched.Between(windowStart.Add(-time.Nanosecond), now) {
+		day := runAt.Format("2006-01-02")
+		if seenDays[day] {
+			continue
+		}
+		seenDays[day] = true
+		runs = append(runs, runAt)
+	}
+	return runs
+}
+
+// nextRun returns the next scheduled time after now that does not fall on a holiday
+func nextRun(cfg *config.Config, sched *schedule.Schedule, now time.Time) time.Time {
+	runAt := sched.Next(now)
+	for i := 0; i < 366 && !runAt.IsZero() && cfg.IsHoliday(runAt); i++ {
+		runAt = sched.Next(startOfDay(runAt).AddDate(0, 0, 1).Add(-time.Nanosecond))
+	}
+	return runAt
+}
+
+// describeSchedule returns a short human-readable description of a schedule
+func describeSchedule(s *config.ScheduleEntry) string {
+	if s.Cron != "" {
+		return fmt.Sprintf("cron %q", s.Cron)
+	}
+	days := "every day"
+	if len(s.Days) > 0 {
+		days = strings.Join(s.Days, ",")
+	}
+	return fmt.Sprintf("%s at %s", days, s.At)
+}
+
+// startOfDay returns midnight of the given day in its location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+)
+
+func TestShortcutSchedule(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule config.ScheduleEntry
+		wantErr  bool
+	}{
+		{"cron", config.ScheduleEntry{Cron: "30 9 * * 1-5"}, false},
+		{"weekdays", config.ScheduleEntry{Days: []string{"mon"}, At: "09:30"}, false},
+		{"every day", config.ScheduleEntry{At: "17:00"}, false},
+		{"both cron and at", config.ScheduleEntry{Cron: "30 9 * * *", At: "09:30"}, true},
+		{"empty", config.ScheduleEntry{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := config.ShortcutEntry{Name: "daily", Schedule: &tt.schedule}
+			_, err := shortcutSchedule(sc)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error=%v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDueRuns_OncePerDay(t *testing.T) {
+	sc := config.ShortcutEntry{Schedule: &config.ScheduleEntry{Cron: "0 9,14 * * *"}}
+	sched, err := shortcutSchedule(sc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	windowStart := time.Date(2025, 1, 14, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 1, 15, 15, 0, 0, 0, time.UTC)
+
+	runs := dueRuns(sched, windowStart, now)
+	if len(runs) != 2 {
+		t.Fatalf("expected 2 runs (one per day), got %d: %v", len(runs), runs)
+	}
+	if runs[0].Hour() != 9 || runs[1].Hour() != 9 {
+		t.Errorf("expected the first run of each day, got %v", runs)
+	}
+}
+
+func TestNextRun_SkipsHolidays(t *testing.T) {
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{Holidays: []string{"2025-01-16"}}}
+	sc := config.ShortcutEntry{Schedule: &config.ScheduleEntry{At: "09:30"}}
+	sched, err := shortcutSchedule(sc)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+	expected := time.Date(2025, 1, 17, 9, 30, 0, 0, time.UTC)
+	if got := nextRun(cfg, sched, now); !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestShortcutLogged_ManualEntry(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	sc := config.ShortcutEntry{Name: "daily", Task: "proj-123", Time: "30m"}
+	now := time.Now()
+
+	if logged, err := shortcutLogged(store, sc, now); err != nil || logged {
+		t.Fatalf("expected nothing logged yet, got %v (%v)", logged, err)
+	}
+
+	// Logged by hand with 'tasklog log -t PROJ-123 -d 30m'
+	if err := store.AddTimeEntry(&storage.TimeEntry{
+		IssueKey:         "PROJ-123",
+		IssueSummary:     "Daily standup",
+		TimeSpentSeconds: 1800,
+		TimeSpent:        "30m",
+		Started:          now,
+	}); err != nil {
+		t.Fatalf("failed to add time entry: %v", err)
+	}
+
+	if logged, err := shortcutLogged(store, sc, now); err != nil || !logged {
+		t.Errorf("expected the manually logged entry to cover the day, got %v (%v)", logged, err)
+	}
+	if logged, err := shortcutLogged(store, sc, now.AddDate(0, 0, 1)); err != nil || logged {
+		t.Errorf("expected the next day not to be covered, got %v (%v)", logged, err)
+	}
+}
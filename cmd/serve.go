@@ -0,0 +1,406 @@
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/api"
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/secrets"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+// apiTokenKey is the secret store key of the API token
+const apiTokenKey = "api-token"
+
+// apiTokenEnv overrides the stored API token
+const apiTokenEnv = "TASKLOG_API_TOKEN"
+
+// defaultListen is the address 'tasklog serve' listens on by default
+const defaultListen = "127.0.0.1:8766"
+
+var (
+	serveListen      string
+	serveTokenRotate bool
+)
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve a local HTTP/JSON API for editor and tool integrations",
+	Long: `Serves a local HTTP/JSON API to log time, run timers, list entries, shortcuts and
+the daily summary, and sync, so editors and other tools can use tasklog without
+the terminal prompts.
+
+The API only listens on a Unix socket or a loopback address. Requests must send
+the token printed by 'tasklog serve token' as a bearer token:
+
+  curl -H "Authorization: Bearer $TOKEN" http://127.0.0.1:8766/api/v1/summary
+
+The token is generated on first use and kept in the secret store; set
+TASKLOG_API_TOKEN to use your own instead. The OpenAPI spec is served without
+a token at /api/v1/openapi.yaml.
+
+Examples:
+  tasklog serve                                              # Listen on 127.0.0.1:8766
+  tasklog serve --listen 127.0.0.1:9000                      # Listen on another port
+  tasklog serve --listen unix:///run/user/1000/tasklog.sock  # Listen on a Unix socket
+  tasklog serve token                                        # Print the API token
+  tasklog serve token --rotate                               # Replace the API token` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runServe,
+}
+
+var serveTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Print the API token",
+	Args:  cobra.NoArgs,
+	RunE:  runServeToken,
+}
+
+func init() {
+	rootCmd.AddCommand(serveCmd)
+	serveCmd.AddCommand(serveTokenCmd)
+
+	serveCmd.Flags().StringVar(&serveListen, "listen", defaultListen, "Address to listen on: unix:///path/to/socket or 127.0.0.1:port")
+	serveTokenCmd.Flags().BoolVar(&serveTokenRotate, "rotate", false, "Generate a new token, invalidating the current one")
+}
+
+func runServe(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	token, err := apiToken(false)
+	if err != nil {
+		return err
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	listener, err := api.Listen(serveListen)
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{
+		Handler:           api.NewServer(store, token, cfg.Jira.Shortcuts, serveActions(cfg, store, jiraClient)),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.Serve(listener)
+	}()
+	fmt.Printf("tasklog API listening on %s, press Ctrl+C to stop\n", serveListen)
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("failed to stop the API server: %w", err)
+	}
+	return nil
+}
+
+func runServeToken(cmd *cobra.Command, args []string) error {
+	if os.Getenv(apiTokenEnv) != "" && !serveTokenRotate {
+		fmt.Println(os.Getenv(apiTokenEnv))
+		return nil
+	}
+
+	token, err := apiToken(serveTokenRotate)
+	if err != nil {
+		return err
+	}
+	if serveTokenRotate && os.Getenv(apiTokenEnv) != "" {
+		fmt.Fprintf(os.Stderr, "⚠ %s is set and overrides the stored token\n", apiTokenEnv)
+	}
+	fmt.Println(token)
+	return nil
+}
+
+// apiToken returns the API token, from TASKLOG_API_TOKEN or else the secret store
+// A token is generated and stored when none exists or rotate is set
+func apiToken(rotate bool) (string, error) {
+	if token := os.Getenv(apiTokenEnv); token != "" && !rotate {
+		return token, nil
+	}
+
+	store, err := newSecretStore()
+	if err != nil {
+		return "", err
+	}
+
+	if !rotate {
+		token, err := store.Get(apiTokenKey)
+		if err == nil {
+			return token, nil
+		}
+		if !errors.Is(err, secrets.ErrNotFound) {
+			return "", fmt.Errorf("failed to read the API token: %w", err)
+		}
+	}
+
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate the API token: %w", err)
+	}
+	token := hex.EncodeToString(buf)
+	if err := store.Set(apiTokenKey, token); err != nil {
+		return "", fmt.Errorf("failed to save the API token: %w", err)
+	}
+	return token, nil
+}
+
+// serveActions implements the API operations that reach Jira or Tempo with the same helpers as the commands
+func serveActions(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client) api.Actions {
+	return api.Actions{
+		Log: func(req api.LogRequest) (*storage.TimeEntry, error) {
+			return apiLogEntry(cfg, store, jiraClient, req)
+		},
+		Delete: func(entry storage.TimeEntry) error {
+			return removeEntry(store, jiraClient, entry)
+		},
+		StartTimer: func(req api.TimerRequest) (*storage.Timer, error) {
+			return apiStartTimer(cfg, store, jiraClient, req)
+		},
+		StopTimer: func(req api.StopTimerRequest) (*storage.TimeEntry, error) {
+			return apiStopTimer(cfg, store, jiraClient, req)
+		},
+		Sync: func() (*api.SyncResult, error) {
+			synced, unsynced, err := syncAndCount(cfg, store, jiraClient, os.Stdout)
+			if errors.Is(err, errSyncRunning) {
+				return nil, api.Conflict("%v", err)
+			}
+			if err != nil {
+				return nil, err
+			}
+			return &api.SyncResult{Synced: synced, Unsynced: unsynced}, nil
+		},
+	}
+}
+
+// apiLogEntry logs time like 'tasklog log' without prompts
+func apiLogEntry(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, req api.LogRequest) (*storage.TimeEntry, error) {
+	var opts jira.WorklogOptions
+	var transition string
+	if req.Shortcut != "" {
+		shortcut, found := cfg.GetShortcut(req.Shortcut)
+		if !found {
+			return nil, api.NotFound("shortcut '%s' not found in configuration", req.Shortcut)
+		}
+		if req.IssueKey == "" {
+			req.IssueKey = shortcut.Task
+		}
+		if req.TimeSpent == "" {
+			req.TimeSpent = shortcut.Time
+		}
+		if req.Label == "" {
+			req.Label = shortcut.Label
+		}
+		if req.Account == "" {
+			req.Account = shortcut.Account
+		}
+		opts = jira.WorklogOptions{AdjustEstimate: shortcut.AdjustEstimate, Estimate: shortcut.Estimate}
+		transition = shortcut.Transition
+	}
+
+	if req.IssueKey == "" {
+		return nil, api.Invalid("issue_key is required")
+	}
+	if req.TimeSpent == "" {
+		return nil, api.Invalid("time_spent is required")
+	}
+	timeSeconds, err := timeparse.Parse(req.TimeSpent)
+	if err != nil {
+		return nil, api.Invalid("invalid time_spent: %v", err)
+	}
+	applyTempoLabels(cfg)
+	if req.Label == "" && len(cfg.Labels.AllowedLabels) > 0 {
+		return nil, api.Invalid("label is required")
+	}
+	if req.Label != "" && !cfg.IsLabelAllowed(req.Label) {
+		return nil, api.Invalid("label '%s' is not in the allowed labels list", req.Label)
+	}
+	if req.Account != "" && cfg.Tempo.Mode != "direct" {
+		return nil, api.Invalid("accounts can only be set when tempo.mode is direct")
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, api.Invalid("shortcut '%s': %v", req.Shortcut, err)
+	}
+
+	issue, pending, err := apiIssue(store, jiraClient, req.IssueKey)
+	if err != nil {
+		return nil, err
+	}
+
+	started := time.Now()
+	if req.Started != nil {
+		started = *req.Started
+	}
+
+	entry := &storage.TimeEntry{
+		IssueKey:          issue.Key,
+		IssueSummary:      issue.Fields.Summary,
+		TimeSpentSeconds:  timeSeconds,
+		TimeSpent:         timeparse.Format(timeSeconds),
+		Label:             req.Label,
+		Comment:           req.Comment,
+		Started:           started,
+		Shortcut:          req.Shortcut,
+		Account:           req.Account,
+		PendingValidation: pending,
+	}
+	if err := submitEntry(cfg, store, jiraClient, entry, opts); err != nil {
+		return nil, err
+	}
+
+	if transition != "" && !pending {
+		if err := transitionIssue(jiraClient, issue, transition); err != nil {
+			log.Error().Err(err).Str("issue", issue.Key).Msg("Failed to transition issue")
+		}
+	}
+	return entry, nil
+}
+
+// apiStartTimer starts a timer like 'tasklog timer start'
+func apiStartTimer(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, req api.TimerRequest) (*storage.Timer, error) {
+	if req.IssueKey == "" {
+		return nil, api.Invalid("issue_key is required")
+	}
+	if req.Label != "" {
+		applyTempoLabels(cfg)
+		if !cfg.IsLabelAllowed(req.Label) {
+			return nil, api.Invalid("label '%s' is not in the allowed labels list", req.Label)
+		}
+	}
+
+	if active, err := store.GetActiveTimer(); err != nil {
+		return nil, err
+	} else if active != nil {
+		return nil, api.Conflict("timer already running on %s since %s, stop it first", active.IssueKey, active.Started.Format("15:04"))
+	}
+
+	// Timers are only started on issues checked against Jira, like 'tasklog timer start'
+	issue, pending, err := apiIssue(store, jiraClient, req.IssueKey)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, fmt.Errorf("failed to fetch task %s: Jira is unreachable", req.IssueKey)
+	}
+
+	timer := &storage.Timer{
+		IssueKey:     issue.Key,
+		IssueSummary: issue.Fields.Summary,
+		Label:        req.Label,
+		Started:      time.Now(),
+	}
+	if err := store.StartTimer(timer); err != nil {
+		if errors.Is(err, storage.ErrTimerRunning) {
+			return nil, api.Conflict("%v", err)
+		}
+		return nil, err
+	}
+	setWorkStatus(cfg, issue.Key, issue.Fields.Summary, 0)
+	return timer, nil
+}
+
+// apiStopTimer stops the running timer like 'tasklog timer stop', returning nil when it is discarded
+func apiStopTimer(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, req api.StopTimerRequest) (*storage.TimeEntry, error) {
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		return nil, err
+	}
+	if timer == nil {
+		return nil, api.NotFound("no timer running")
+	}
+
+	if req.Discard {
+		if _, err := store.StopTimer(); err != nil {
+			return nil, err
+		}
+		clearWorkStatus(cfg, timer)
+		return nil, nil
+	}
+
+	label := timer.Label
+	if label == "" {
+		label = req.Label
+	}
+	applyTempoLabels(cfg)
+	if label == "" && len(cfg.Labels.AllowedLabels) > 0 {
+		return nil, api.Invalid("the timer was started without a label, label is required")
+	}
+	if label != "" && !cfg.IsLabelAllowed(label) {
+		return nil, api.Invalid("label '%s' is not in the allowed labels list", label)
+	}
+
+	// Only remove the timer once everything needed to log it is known
+	if _, err := store.StopTimer(); err != nil {
+		return nil, err
+	}
+	clearWorkStatus(cfg, timer)
+
+	timeSeconds := timeparse.RoundSeconds(timerElapsedSeconds(timer, time.Now()))
+	entry := timerEntry(timer, label, req.Comment, timeSeconds)
+	if err := submitEntry(cfg, store, jiraClient, entry, jira.WorklogOptions{}); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// apiIssue fetches an issue, falling back to the issue cache when Jira is unreachable
+// Returns whether the issue could not be checked against Jira
+func apiIssue(store *storage.Storage, jiraClient *jira.Client, key string) (*jira.Issue, bool, error) {
+	issue, err := jiraClient.GetIssue(strings.ToUpper(key))
+	switch {
+	case err == nil:
+		if err := store.SaveIssue(toCachedIssue(*issue)); err != nil {
+			log.Debug().Err(err).Str("key", issue.Key).Msg("Failed to cache issue")
+		}
+		return issue, false, nil
+	case jira.IsNotFound(err):
+		return nil, false, api.NotFound("issue %s does not exist or is not visible to you", key)
+	case jira.IsUnreachable(err):
+		log.Debug().Err(err).Str("task", key).Msg("Jira is unreachable, deferring validation")
+		return offlineIssue(store, key), true, nil
+	default:
+		return nil, false, fmt.Errorf("failed to fetch task %s: %w", key, err)
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestAPIToken(t *testing.T) {
+	t.Setenv("TASKLOG_CONFIG", filepath.Join(t.TempDir(), "config.yaml"))
+	t.Setenv(apiTokenEnv, "")
+
+	token, err := apiToken(false)
+	if err != nil {
+		t.Fatalf("apiToken failed: %v", err)
+	}
+	if len(token) != 64 {
+		t.Errorf("expected a 64 character token, got %q", token)
+	}
+
+	again, err := apiToken(false)
+	if err != nil {
+		t.Fatalf("apiToken failed: %v", err)
+	}
+	if again != token {
+		t.Error("expected the stored token to be reused")
+	}
+
+	rotated, err := apiToken(true)
+	if err != nil {
+		t.Fatalf("apiToken failed: %v", err)
+	}
+	if rotated == token {
+		t.Error("expected rotate to generate a new token")
+	}
+
+	t.Setenv(apiTokenEnv, "from-env")
+	if token, _ := apiToken(false); token != "from-env" {
+		t.Errorf("expected %s to override the stored token, got %q", apiTokenEnv, token)
+	}
+}
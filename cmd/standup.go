@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/notify"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+// standupLookback is how many days back the previous work day is looked for
+const standupLookback = 7
+
+var (
+	standupChannel string
+	standupNoEdit  bool
+	standupDryRun  bool
+)
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Short: "Post a daily standup summary to Slack and other notifiers",
+	Long: `Builds a "Yesterday / Today / Blockers" standup message and posts it to Slack.
+
+Yesterday lists the time logged on your previous work day (the last day with
+entries), grouped by issue with totals. Today lists your in-progress issues.
+
+The message opens in your editor ($VISUAL or $EDITOR) for tweaks before it is
+posted to slack.channel_id and the notifiers set up for standups. Blank lines
+separate the sections of the message.
+
+Examples:
+  tasklog standup                     # Edit and post the standup
+  tasklog standup --channel C0123456  # Post to another channel
+  tasklog standup --dry-run           # Print the standup without posting
+  tasklog standup --no-edit           # Post without editing or confirming` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runStandup,
+}
+
+func init() {
+	rootCmd.AddCommand(standupCmd)
+
+	standupCmd.Flags().StringVar(&standupChannel, "channel", "", "Slack channel ID to post to (defaults to slack.channel_id)")
+	standupCmd.Flags().BoolVar(&standupNoEdit, "no-edit", false, "Post without opening the editor or confirming")
+	standupCmd.Flags().BoolVar(&standupDryRun, "dry-run", false, "Print the standup without posting it")
+}
+
+func runStandup(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Post to another Slack channel
+	if standupChannel != "" {
+		cfg.Slack.ChannelID = standupChannel
+		for i := range cfg.Notifiers {
+			if cfg.Notifiers[i].Type == "slack" {
+				cfg.Notifiers[i].Channel = standupChannel
+			}
+		}
+	}
+
+	notifiers, err := newNotifiers(cfg, notify.EventStandup, nil)
+	if err != nil {
+		return err
+	}
+	if !standupDryRun && len(notifiers) == 0 {
+		return fmt.Errorf("nothing to post the standup to, configure slack.user_token and slack.channel_id or a notifier for standups")
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	day, entries, err := previousWorkday(store, now)
+	if err != nil {
+		return err
+	}
+
+	inProgress, err := jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to fetch in-progress issues, using the issue cache")
+		fmt.Printf("⚠ Failed to fetch in-progress tasks, using the issue cache: %v\n", err)
+		cached, err := store.GetInProgressCachedIssues()
+		if err != nil {
+			return err
+		}
+		inProgress = fromCachedIssues(cached)
+	}
+
+	message := buildStandup(day, now, entries, inProgress)
+
+	if standupDryRun {
+		fmt.Println(message)
+		return nil
+	}
+
+	if !standupNoEdit {
+		message, err = ui.EditText("Edit the standup:", message)
+		if err != nil {
+			return fmt.Errorf("failed to edit the standup: %w", err)
+		}
+		message = strings.TrimSpace(message)
+		if message == "" {
+			fmt.Println("Empty standup, cancelled.")
+			return nil
+		}
+
+		fmt.Printf("\n%s\n\n", message)
+		confirmed, err := ui.Confirm("Post this standup?")
+		if err != nil {
+			return fmt.Errorf("failed to confirm: %w", err)
+		}
+		if !confirmed {
+			fmt.Println("Cancelled.")
+			return nil
+		}
+	}
+
+	msg := notify.Message{Title: "Standup " + now.Format("Mon Jan 2"), Text: message}
+	if postToNotifiers(notifiers, msg) == 0 {
+		return fmt.Errorf("failed to post the standup")
+	}
+	return nil
+}
+
+// previousWorkday returns the last day before now with time entries and its entries,
+// looking back standupLookback days; without entries it returns yesterday
+func previousWorkday(store *storage.Storage, now time.Time) (time.Time, []storage.TimeEntry, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	entries, err := store.GetEntriesBetween(today.AddDate(0, 0, -standupLookback), today)
+	if err != nil {
+		return time.Time{}, nil, err
+	}
+	if len(entries) == 0 {
+		return today.AddDate(0, 0, -1), nil, nil
+	}
+
+	last := entries[len(entries)-1].Started.In(now.Location())
+	day := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, now.Location())
+
+	var dayEntries []storage.TimeEntry
+	for _, entry := range entries {
+		if !entry.Started.Before(day) {
+			dayEntries = append(dayEntries, entry)
+		}
+	}
+	return day, dayEntries, nil
+}
+
+// buildStandup writes the standup message in Slack mrkdwn, one paragraph per section
+// The previous work day is called yesterday, with its date when it is further back
+func buildStandup(day, now time.Time, entries []storage.TimeEntry, inProgress []jira.Issue) string {
+	var b strings.Builder
+
+	lines, total := issueLines(entries)
+
+	yesterday := now.AddDate(0, 0, -1)
+	if day.Year() == yesterday.Year() && day.YearDay() == yesterday.YearDay() {
+		b.WriteString("*Yesterday*")
+	} else {
+		fmt.Fprintf(&b, "*Yesterday* (%s)", day.Format("Mon Jan 2"))
+	}
+	if total > 0 {
+		fmt.Fprintf(&b, " — %s", timeparse.Format(total))
+	}
+	b.WriteString("\n")
+	if len(lines) == 0 {
+		b.WriteString("• Nothing logged\n")
+	}
+	for _, line := range lines {
+		b.WriteString(line + "\n")
+	}
+
+	b.WriteString("\n*Today*\n")
+	if len(inProgress) == 0 {
+		b.WriteString("• Nothing in progress\n")
+	}
+	for _, issue := range inProgress {
+		fmt.Fprintf(&b, "• %s %s\n", issue.Key, issue.Fields.Summary)
+	}
+
+	b.WriteString("\n*Blockers*\n• None")
+	return b.String()
+}
+
+// issueLines lists the time logged per issue as "• KEY Summary — 1h" lines, most time first
+// Returns the lines and the total time in seconds
+func issueLines(entries []storage.TimeEntry) ([]string, int) {
+	type issueTotal struct {
+		key, summary string
+		seconds      int
+	}
+	totals := map[string]*issueTotal{}
+	var keys []string
+	total := 0
+	for _, entry := range entries {
+		t := totals[entry.IssueKey]
+		if t == nil {
+			t = &issueTotal{key: entry.IssueKey, summary: entry.IssueSummary}
+			totals[entry.IssueKey] = t
+			keys = append(keys, entry.IssueKey)
+		}
+		t.seconds += entry.TimeSpentSeconds
+		total += entry.TimeSpentSeconds
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return totals[keys[i]].seconds > totals[keys[j]].seconds })
+
+	lines := make([]string, len(keys))
+	for i, key := range keys {
+		t := totals[key]
+		lines[i] = fmt.Sprintf("• %s %s — %s", t.key, t.summary, timeparse.Format(t.seconds))
+	}
+	return lines, total
+}
@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+)
+
+func TestPreviousWorkday(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	monday := time.Date(2026, 3, 9, 9, 0, 0, 0, time.Local)
+
+	day, entries, err := previousWorkday(store, monday)
+	if err != nil {
+		t.Fatalf("previousWorkday failed: %v", err)
+	}
+	if !day.Equal(time.Date(2026, 3, 8, 0, 0, 0, 0, time.Local)) || len(entries) != 0 {
+		t.Errorf("without entries, got %s with %d entries, want yesterday without entries", day, len(entries))
+	}
+
+	friday := time.Date(2026, 3, 6, 10, 0, 0, 0, time.Local)
+	for _, entry := range []storage.TimeEntry{
+		{IssueKey: "PROJ-1", TimeSpentSeconds: 3600, Started: friday.AddDate(0, 0, -1)},
+		{IssueKey: "PROJ-1", TimeSpentSeconds: 3600, Started: friday},
+		{IssueKey: "PROJ-2", TimeSpentSeconds: 1800, Started: friday.Add(3 * time.Hour)},
+		{IssueKey: "PROJ-3", TimeSpentSeconds: 1800, Started: monday},
+	} {
+		if err := store.AddTimeEntry(&entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	day, entries, err = previousWorkday(store, monday)
+	if err != nil {
+		t.Fatalf("previousWorkday failed: %v", err)
+	}
+	if !day.Equal(time.Date(2026, 3, 6, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("day = %s, want Friday", day)
+	}
+	if len(entries) != 2 {
+		t.Errorf("got %d entries, want Friday's 2", len(entries))
+	}
+}
+
+func TestBuildStandup(t *testing.T) {
+	now := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	yesterday := time.Date(2026, 3, 9, 0, 0, 0, 0, time.Local)
+	entries := []storage.TimeEntry{
+		{IssueKey: "PROJ-1", IssueSummary: "Login fix", TimeSpentSeconds: 3600},
+		{IssueKey: "PROJ-2", IssueSummary: "Payments", TimeSpentSeconds: 7200},
+		{IssueKey: "PROJ-1", IssueSummary: "Login fix", TimeSpentSeconds: 1800},
+	}
+	inProgress := []jira.Issue{{Key: "PROJ-2", Fields: jira.IssueFields{Summary: "Payments"}}}
+
+	got := buildStandup(yesterday, now, entries, inProgress)
+	want := `*Yesterday* — 3h 30m
+• PROJ-2 Payments — 2h
+• PROJ-1 Login fix — 1h 30m
+
+*Today*
+• PROJ-2 Payments
+
+*Blockers*
+• None`
+	if got != want {
+		t.Errorf("buildStandup() =\n%s\nwant\n%s", got, want)
+	}
+
+	friday := time.Date(2026, 3, 6, 0, 0, 0, 0, time.Local)
+	got = buildStandup(friday, now, nil, nil)
+	if !strings.HasPrefix(got, "*Yesterday* (Fri Mar 6)\n• Nothing logged") {
+		t.Errorf("expected the date of an older work day and no entries, got\n%s", got)
+	}
+	if !strings.Contains(got, "• Nothing in progress") {
+		t.Errorf("expected no in-progress issues, got\n%s", got)
+	}
+}
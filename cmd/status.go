@@ -0,0 +1,183 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+var (
+	statusFormat  string
+	statusSnippet string
+)
+
+// defaultStatusFormat is used when --format is not given
+const defaultStatusFormat = `{{.Today}}{{if .Active}} | {{.Active}}{{end}}`
+
+// statusSnippets are ready-made integrations printed by 'tasklog status --snippet'
+var statusSnippets = map[string]string{
+	"bash": `# tasklog: show today's total and the running timer in the prompt
+__tasklog_ps1() {
+  local s
+  s="$(tasklog status 2>/dev/null)" && [ -n "$s" ] && printf ' [%s]' "$s"
+}
+PS1='\u@\h:\w$(__tasklog_ps1)\$ '
+`,
+	"zsh": `# tasklog: show today's total and the running timer in the right prompt
+setopt PROMPT_SUBST
+__tasklog_prompt() {
+  local s
+  s="$(tasklog status 2>/dev/null)" && [ -n "$s" ] && print -n "[$s]"
+}
+RPROMPT='$(__tasklog_prompt)'
+`,
+	"fish": `# tasklog: show today's total and the running timer in the right prompt
+function fish_right_prompt
+    set -l s (tasklog status 2>/dev/null)
+    and test -n "$s"
+    and printf '[%s]' $s
+end
+`,
+	"tmux": `# tasklog: show today's total and the running timer in the status bar
+set -g status-interval 30
+set -g status-right '#(tasklog status --format "{{.Today}}{{if .Active}} ⏱ {{.ActiveIssue}} {{.Elapsed}}{{end}}" 2>/dev/null) | %H:%M'
+`,
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Print a one-line status for shell prompts and status bars",
+	Long: `Prints today's logged total and the running timer on a single line.
+
+Reads only the local database: no network calls and no update check, so it is
+fast enough to run on every shell prompt.
+
+The output can be customised with a Go template. Available fields:
+  {{.Today}}          Time logged today (e.g., 3h 15m)
+  {{.TodaySeconds}}   Time logged today in seconds
+  {{.Active}}         Running timer as "PROJ-123 45m" (empty if no timer runs)
+  {{.ActiveIssue}}    Issue key of the running timer
+  {{.ActiveSummary}}  Issue summary of the running timer
+  {{.Elapsed}}        Time elapsed on the running timer
+  {{.Unsynced}}       Number of entries not yet synced
+
+Examples:
+  tasklog status
+  tasklog status --format '{{.Today}} {{.Active}}'
+  tasklog status --snippet zsh >> ~/.zshrc` + configHelp,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	// Override the root update check to keep prompts fast and offline
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	RunE:             runStatus,
+}
+
+func init() {
+	rootCmd.AddCommand(statusCmd)
+
+	statusCmd.Flags().StringVarP(&statusFormat, "format", "f", defaultStatusFormat, "Go template for the output")
+	statusCmd.Flags().StringVar(&statusSnippet, "snippet", "", "Print an integration snippet ("+strings.Join(statusSnippetNames(), ", ")+")")
+}
+
+// statusInfo is the data available to the status format template
+type statusInfo struct {
+	Today          string
+	TodaySeconds   int
+	Active         string
+	ActiveIssue    string
+	ActiveSummary  string
+	Elapsed        string
+	ElapsedSeconds int
+	Unsynced       int
+}
+
+func runStatus(cmd *cobra.Command, args []string) error {
+	if statusSnippet != "" {
+		snippet, ok := statusSnippets[statusSnippet]
+		if !ok {
+			return fmt.Errorf("unknown snippet '%s', available: %s", statusSnippet, strings.Join(statusSnippetNames(), ", "))
+		}
+		fmt.Fprint(cmd.OutOrStdout(), snippet)
+		return nil
+	}
+
+	tmpl, err := template.New("status").Parse(statusFormat)
+	if err != nil {
+		return fmt.Errorf("invalid format: %w", err)
+	}
+
+	// Load configuration quietly, the output usually ends up in a prompt
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	info, err := loadStatusInfo(store, time.Now())
+	if err != nil {
+		return err
+	}
+
+	if err := tmpl.Execute(cmd.OutOrStdout(), info); err != nil {
+		return fmt.Errorf("failed to render status: %w", err)
+	}
+	fmt.Fprintln(cmd.OutOrStdout())
+	return nil
+}
+
+// loadStatusInfo collects the status data from the local database
+func loadStatusInfo(store *storage.Storage, now time.Time) (*statusInfo, error) {
+	todaySeconds, err := store.GetTodayTotalSeconds()
+	if err != nil {
+		return nil, err
+	}
+
+	unsynced, err := store.GetUnsyncedEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	info := &statusInfo{
+		Today:        timeparse.Format(todaySeconds),
+		TodaySeconds: todaySeconds,
+		Unsynced:     len(unsynced),
+	}
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		return nil, err
+	}
+	if timer != nil {
+		info.ElapsedSeconds = timerElapsedSeconds(timer, now)
+		info.Elapsed = timeparse.Format(info.ElapsedSeconds)
+		info.ActiveIssue = timer.IssueKey
+		info.ActiveSummary = timer.IssueSummary
+		info.Active = fmt.Sprintf("%s %s", timer.IssueKey, info.Elapsed)
+	}
+
+	return info, nil
+}
+
+// statusSnippetNames returns the available snippet names in sorted order
+func statusSnippetNames() []string {
+	names := make([]string, 0, len(statusSnippets))
+	for name := range statusSnippets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
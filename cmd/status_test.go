@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"text/template"
+	"time"
+
+	"tasklog/internal/storage"
+)
+
+func TestLoadStatusInfo(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	if err := store.AddTimeEntry(&storage.TimeEntry{
+		IssueKey:         "PROJ-1",
+		IssueSummary:     "Logged issue",
+		TimeSpentSeconds: 5400,
+		TimeSpent:        "1h 30m",
+		Label:            "development",
+		Started:          now,
+	}); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+
+	info, err := loadStatusInfo(store, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Today != "1h 30m" || info.Active != "" || info.Unsynced != 1 {
+		t.Errorf("unexpected status without timer: %+v", info)
+	}
+
+	if err := store.StartTimer(&storage.Timer{IssueKey: "PROJ-2", IssueSummary: "Running", Started: now.Add(-45 * time.Minute)}); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+
+	info, err = loadStatusInfo(store, now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Active != "PROJ-2 45m" || info.ActiveIssue != "PROJ-2" || info.Elapsed != "45m" {
+		t.Errorf("unexpected status with timer: %+v", info)
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{defaultStatusFormat, "1h 30m | PROJ-2 45m"},
+		{"{{.Today}} {{.Active}}", "1h 30m PROJ-2 45m"},
+		{"{{.ActiveSummary}} ({{.Unsynced}} unsynced)", "Running (1 unsynced)"},
+	}
+	for _, tt := range tests {
+		var buf bytes.Buffer
+		if err := template.Must(template.New("status").Parse(tt.format)).Execute(&buf, info); err != nil {
+			t.Fatalf("failed to render %q: %v", tt.format, err)
+		}
+		if buf.String() != tt.expected {
+			t.Errorf("format %q rendered %q, want %q", tt.format, buf.String(), tt.expected)
+		}
+	}
+}
+
+func TestTimerElapsedSeconds(t *testing.T) {
+	now := time.Now()
+	if got := timerElapsedSeconds(&storage.Timer{Started: now.Add(-90 * time.Second)}, now); got != 90 {
+		t.Errorf("expected 90 seconds, got %d", got)
+	}
+	if got := timerElapsedSeconds(&storage.Timer{Started: now.Add(time.Minute)}, now); got != 0 {
+		t.Errorf("expected 0 seconds for a timer in the future, got %d", got)
+	}
+}
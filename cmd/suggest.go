@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/gitinfo"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+var (
+	suggestDate   string
+	suggestRepos  []string
+	suggestAuthor string
+	suggestLabel  string
+)
+
+// maxSuggestionCommentLength limits the generated worklog comment
+const maxSuggestionCommentLength = 200
+
+var suggestCmd = &cobra.Command{
+	Use:   "suggest [repo...]",
+	Short: "Draft a day's timesheet from git activity",
+	Long: `Scans local git repositories for your commits on a given day, groups them by
+the issue keys found in branch names and commit messages, and estimates time blocks
+from the commit timestamps. Review and edit the suggestions, then log the accepted ones.
+
+Commits further apart than 2 hours start a new block, and 30 minutes of work is
+assumed before the first commit of each block.
+
+Examples:
+  tasklog suggest                                  # Current repository, yesterday
+  tasklog suggest --date yesterday --repos ~/code/*
+  tasklog suggest --date "2 days ago" ~/code/api ~/code/web` + configHelp,
+	Args: cobra.ArbitraryArgs,
+	RunE: runSuggest,
+}
+
+func init() {
+	rootCmd.AddCommand(suggestCmd)
+
+	suggestCmd.Flags().StringVar(&suggestDate, "date", "yesterday", "Day to build suggestions for (e.g., yesterday, 2 days ago)")
+	suggestCmd.Flags().StringSliceVarP(&suggestRepos, "repos", "r", nil, "Repositories to scan; globs like ~/code/* are expanded (default: current directory)")
+	suggestCmd.Flags().StringVar(&suggestAuthor, "author", "", "Author email to filter commits by (default: git user.email)")
+	suggestCmd.Flags().StringVarP(&suggestLabel, "label", "l", "", "Work log label for accepted entries")
+}
+
+func runSuggest(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	if suggestLabel != "" {
+		applyTempoLabels(cfg)
+		if !cfg.IsLabelAllowed(suggestLabel) {
+			return fmt.Errorf("label '%s' is not in the allowed labels list", suggestLabel)
+		}
+	}
+
+	parsedDate, err := timeparse.ParseDateTime(suggestDate)
+	if err != nil {
+		return fmt.Errorf("invalid date: %w", err)
+	}
+	day := startOfDay(parsedDate)
+
+	repos, err := expandRepoPaths(append(suggestRepos, args...))
+	if err != nil {
+		return err
+	}
+
+	author := suggestAuthor
+	if author == "" {
+		for _, repo := range repos {
+			if author, err = gitinfo.GitUserEmail(repo); err == nil {
+				break
+			}
+		}
+		if author == "" {
+			return fmt.Errorf("could not determine author email, use --author")
+		}
+	}
+
+	// Collect commits from all repositories
+	var commits []gitinfo.Commit
+	for _, repo := range repos {
+		repoCommits, err := gitinfo.AuthorCommits(repo, author, cfg.Jira.ProjectKey, day, day.AddDate(0, 0, 1))
+		if err != nil {
+			log.Debug().Err(err).Str("repo", repo).Msg("Skipping repository")
+			continue
+		}
+		commits = append(commits, repoCommits...)
+	}
+
+	suggestions := gitinfo.EstimateBlocks(commits, gitinfo.DefaultEstimateOptions())
+	if len(suggestions) == 0 {
+		fmt.Printf("No commits with %s issue keys found for %s by %s in %d repositories\n",
+			cfg.Jira.ProjectKey, day.Format("Mon Jan 2"), author, len(repos))
+		return nil
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	// Time already logged that day, to flag suggestions that may be duplicates
+	existing, err := store.GetEntriesBetween(day, day.AddDate(0, 0, 1))
+	if err != nil {
+		return fmt.Errorf("failed to get existing entries: %w", err)
+	}
+	loggedSeconds := map[string]int{}
+	for _, entry := range existing {
+		loggedSeconds[entry.IssueKey] += entry.TimeSpentSeconds
+	}
+
+	// Resolve issue summaries, dropping keys that do not exist
+	issues := map[string]*jira.Issue{}
+	var items []ui.ReviewItem
+	var validSuggestions []gitinfo.Suggestion
+	for _, s := range suggestions {
+		issue, found := issues[s.IssueKey]
+		if !found {
+			issue, err = jiraClient.GetIssue(s.IssueKey)
+			if err != nil {
+				fmt.Printf("⚠ Skipping %s: %v\n", s.IssueKey, err)
+			}
+			issues[s.IssueKey] = issue
+		}
+		if issue == nil {
+			continue
+		}
+
+		details := fmt.Sprintf("%s, %d commits", s.Started.Format("15:04"), len(s.Commits))
+		if logged := loggedSeconds[s.IssueKey]; logged > 0 {
+			details += fmt.Sprintf(", %s already logged", timeparse.Format(logged))
+		}
+
+		items = append(items, ui.ReviewItem{
+			Title:     fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary),
+			Details:   details,
+			TimeSpent: timeparse.Format(s.TimeSpentSeconds),
+			Comment:   suggestionComment(s.Commits),
+		})
+		validSuggestions = append(validSuggestions, s)
+	}
+
+	if len(items) == 0 {
+		fmt.Println("No suggestions left to review")
+		return nil
+	}
+
+	fmt.Printf("📝 %d suggestions for %s from %d commits\n\n", len(items), day.Format("Mon Jan 2"), len(commits))
+
+	accepted, err := ui.ReviewItems(items)
+	if err != nil {
+		return fmt.Errorf("failed to review suggestions: %w", err)
+	}
+	if len(accepted) == 0 {
+		fmt.Println("Nothing selected.")
+		return nil
+	}
+
+	selectedLabel := suggestLabel
+	if selectedLabel == "" {
+		applyTempoLabels(cfg)
+		selectedLabel, err = ui.SelectLabel(cfg.Labels.AllowedLabels)
+		if err != nil {
+			return fmt.Errorf("failed to select label: %w", err)
+		}
+		if !cfg.IsLabelAllowed(selectedLabel) {
+			return fmt.Errorf("label '%s' is not allowed", selectedLabel)
+		}
+	}
+
+	// Confirm before logging
+	fmt.Printf("\n")
+	var total int
+	entries := make([]*storage.TimeEntry, 0, len(accepted))
+	for _, idx := range accepted {
+		item := items[idx]
+		s := validSuggestions[idx]
+		issue := issues[s.IssueKey]
+
+		timeSeconds, err := timeparse.Parse(item.TimeSpent)
+		if err != nil {
+			return fmt.Errorf("invalid time format: %w", err)
+		}
+		total += timeSeconds
+
+		entries = append(entries, &storage.TimeEntry{
+			IssueKey:         issue.Key,
+			IssueSummary:     issue.Fields.Summary,
+			TimeSpentSeconds: timeSeconds,
+			TimeSpent:        timeparse.Format(timeSeconds),
+			Label:            selectedLabel,
+			Comment:          item.Comment,
+			Started:          s.Started,
+		})
+		fmt.Printf("  %s - %-10s %s\n", s.Started.Format("15:04"), timeparse.Format(timeSeconds), issue.Key)
+	}
+	fmt.Printf("\nTotal: %s [%s]\n\n", timeparse.Format(total), selectedLabel)
+
+	confirmed, err := ui.Confirm(fmt.Sprintf("Log these %d entries?", len(entries)))
+	if err != nil {
+		return fmt.Errorf("failed to confirm: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	for _, entry := range entries {
+		fmt.Printf("\n%s - %s\n", entry.IssueKey, entry.TimeSpent)
+		if err := submitEntry(cfg, store, jiraClient, entry, jira.WorklogOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// expandRepoPaths expands ~ and glob patterns and keeps only directories
+// Returns the current directory if no paths are given
+func expandRepoPaths(paths []string) ([]string, error) {
+	if len(paths) == 0 {
+		dir, err := os.Getwd()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get working directory: %w", err)
+		}
+		return []string{dir}, nil
+	}
+
+	homeDir, _ := os.UserHomeDir()
+
+	var repos []string
+	seen := map[string]bool{}
+	for _, p := range paths {
+		if p == "~" || strings.HasPrefix(p, "~/") {
+			p = filepath.Join(homeDir, strings.TrimPrefix(p, "~"))
+		}
+
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository pattern %q: %w", p, err)
+		}
+
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() || seen[match] {
+				continue
+			}
+			seen[match] = true
+			repos = append(repos, match)
+		}
+	}
+
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repositories found matching %s", strings.Join(paths, ", "))
+	}
+	return repos, nil
+}
+
+// suggestionComment builds a worklog comment from commit subjects
+func suggestionComment(commits []gitinfo.Commit) string {
+	subjects := make([]string, 0, len(commits))
+	for _, c := range commits {
+		subjects = append(subjects, c.Subject)
+	}
+
+	comment := []rune(strings.Join(subjects, "; "))
+	if len(comment) > maxSuggestionCommentLength {
+		return string(comment[:maxSuggestionCommentLength-3]) + "..."
+	}
+	return string(comment)
+}
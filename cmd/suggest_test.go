@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"tasklog/internal/gitinfo"
+)
+
+func TestExpandRepoPaths(t *testing.T) {
+	root := t.TempDir()
+	for _, name := range []string{"api", "web"} {
+		if err := os.Mkdir(filepath.Join(root, name), 0750); err != nil {
+			t.Fatalf("failed to create dir: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(root, "notes.txt"), []byte("x"), 0600); err != nil {
+		t.Fatalf("failed to create file: %v", err)
+	}
+
+	repos, err := expandRepoPaths([]string{filepath.Join(root, "*"), filepath.Join(root, "api")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(repos) != 2 {
+		t.Fatalf("expected 2 directories without duplicates, got %v", repos)
+	}
+
+	if _, err := expandRepoPaths([]string{filepath.Join(root, "missing-*")}); err == nil {
+		t.Error("expected error when nothing matches")
+	}
+}
+
+func TestSuggestionComment(t *testing.T) {
+	comment := suggestionComment([]gitinfo.Commit{{Subject: "Add login"}, {Subject: "Fix tests"}})
+	if comment != "Add login; Fix tests" {
+		t.Errorf("unexpected comment %q", comment)
+	}
+
+	long := suggestionComment([]gitinfo.Commit{{Subject: strings.Repeat("é", 300)}})
+	if len([]rune(long)) != maxSuggestionCommentLength || !strings.HasSuffix(long, "...") {
+		t.Errorf("expected truncated comment of %d runes, got %d", maxSuggestionCommentLength, len([]rune(long)))
+	}
+}
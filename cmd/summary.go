@@ -2,23 +2,37 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 
-	"tasklog/internal/jira"
+	"tasklog/internal/config"
+	"tasklog/internal/notify"
 	"tasklog/internal/storage"
 	"tasklog/internal/tempo"
+	"tasklog/internal/timeparse"
 )
 
+var summaryPost bool
+
 var summaryCmd = &cobra.Command{
 	Use:   "summary",
 	Short: "Show today's time tracking summary",
-	Long:  `Displays a summary of all time entries logged today.` + configHelp,
-	RunE:  runSummary,
+	Long: `Displays a summary of all time entries logged today.
+
+Worklogs are read from Tempo when it is enabled, otherwise from Jira, and compared
+with the local cache.
+
+With --post, today's local entries are also posted to Slack and the notifiers set
+up for daily summaries.` + configHelp,
+	RunE: runSummary,
 }
 
 func init() {
 	rootCmd.AddCommand(summaryCmd)
+
+	summaryCmd.Flags().BoolVar(&summaryPost, "post", false, "Post today's summary to Slack and other notifiers")
 }
 
 func runSummary(cmd *cobra.Command, args []string) error {
@@ -28,13 +42,11 @@ func runSummary(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
-	// Tempo is required for summary
-	if !cfg.Tempo.Enabled || cfg.Tempo.APIToken == "" {
-		return fmt.Errorf("tempo must be enabled and configured to use summary command")
-	}
-
 	// Initialize clients
-	jiraClient := jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
 	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
 
 	// Initialize storage
@@ -44,5 +56,44 @@ func runSummary(cmd *cobra.Command, args []string) error {
 	}
 	defer store.Close()
 
-	return showTodaySummary(store, jiraClient, tempoClient, cfg)
+	if err := showTodaySummary(store, jiraClient, tempoClient, cfg); err != nil {
+		return err
+	}
+
+	if summaryPost {
+		return postDailySummary(cfg, store, time.Now())
+	}
+	return nil
+}
+
+// postDailySummary posts the time logged today per issue to the notifiers set up for daily summaries
+func postDailySummary(cfg *config.Config, store *storage.Storage, now time.Time) error {
+	notifiers, err := newNotifiers(cfg, notify.EventSummary, nil)
+	if err != nil {
+		return err
+	}
+	if len(notifiers) == 0 {
+		return fmt.Errorf("nothing to post the summary to, configure slack.user_token and slack.channel_id or a notifier for summaries")
+	}
+
+	entries, err := store.GetTodayEntries()
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+	msg := notify.Message{Title: "Daily summary " + now.Format("Mon Jan 2"), Text: buildDailySummary(entries)}
+	if postToNotifiers(notifiers, msg) == 0 {
+		return fmt.Errorf("failed to post the summary")
+	}
+	return nil
+}
+
+// buildDailySummary writes the time logged per issue in Slack mrkdwn
+func buildDailySummary(entries []storage.TimeEntry) string {
+	lines, total := issueLines(entries)
+	if len(lines) == 0 {
+		return "Nothing logged today"
+	}
+	return fmt.Sprintf("*Total* — %s\n%s", timeparse.Format(total), strings.Join(lines, "\n"))
 }
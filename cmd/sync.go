@@ -1,20 +1,41 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
 
 	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 
+	"tasklog/internal/config"
 	"tasklog/internal/jira"
+	"tasklog/internal/lock"
 	"tasklog/internal/storage"
 )
 
+// syncLockWait is how long sending entries waits for another tasklog process to finish sending its own
+const syncLockWait = 30 * time.Second
+
+// errSyncRunning is returned when another tasklog process keeps sending entries for longer than syncLockWait
+var errSyncRunning = errors.New("another tasklog process is sending entries, try again shortly")
+
 var syncCmd = &cobra.Command{
 	Use:   "sync",
 	Short: "Sync unsynced time entries to Jira and Tempo",
-	Long:  `Attempts to sync any time entries that failed to sync to Jira or Tempo.` + configHelp,
-	RunE:  runSync,
+	Long: `Attempts to sync any time entries that failed to sync to Jira or Tempo.
+
+Entries logged while Jira was unreachable are validated first: their issue key is
+checked and their summary filled in. Entries with an issue key that does not exist
+are reported and kept until the key is corrected in 'tasklog tui' or the entry is
+deleted.
+
+When labels.propagate is set, labels of entries already in Jira that were not
+sent yet are sent as well, including entries logged before it was set.` + configHelp,
+	RunE: runSync,
 }
 
 func init() {
@@ -29,7 +50,10 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	// Initialize clients
-	jiraClient := jira.NewClient(cfg.Jira.URL, cfg.Jira.Username, cfg.Jira.APIToken, cfg.Jira.ProjectKey)
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
 
 	// Initialize storage
 	store, err := storage.NewStorage(cfg.Database.Path)
@@ -38,6 +62,18 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 	defer store.Close()
 
+	return syncEntries(cfg, store, jiraClient, os.Stdout)
+}
+
+// syncEntries retries all entries that are not fully synced and prints the progress to out
+// It holds the sync lock, so a daemon and a foreground sync never send the same entry twice
+func syncEntries(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, out io.Writer) error {
+	syncLock, err := acquireSyncLock(cfg, syncLockWait)
+	if err != nil {
+		return err
+	}
+	defer syncLock.Release()
+
 	// Get unsynced entries
 	entries, err := store.GetUnsyncedEntries()
 	if err != nil {
@@ -45,35 +81,61 @@ func runSync(cmd *cobra.Command, args []string) error {
 	}
 
 	if len(entries) == 0 {
-		fmt.Println("✓ All entries are synced")
-		return nil
+		fmt.Fprintln(out, "✓ All entries are synced")
+		return backfillLabels(cfg, store, jiraClient, out)
 	}
 
-	fmt.Printf("Found %d unsynced entries\n\n", len(entries))
+	fmt.Fprintf(out, "Found %d unsynced entries\n\n", len(entries))
 
 	successCount := 0
 	failureCount := 0
+	var invalid []storage.TimeEntry
 
 	for i, entry := range entries {
-		fmt.Printf("[%d/%d] Syncing %s - %s\n", i+1, len(entries), entry.IssueKey, entry.TimeSpent)
+		fmt.Fprintf(out, "[%d/%d] Syncing %s - %s\n", i+1, len(entries), entry.IssueKey, entry.TimeSpent)
+
+		// Check entries logged offline before sending them
+		if entry.PendingValidation {
+			if err := validateEntry(store, jiraClient, &entry); err != nil {
+				log.Error().Err(err).Int64("id", entry.ID).Msg("Failed to validate entry")
+				if jira.IsNotFound(err) {
+					fmt.Fprintf(out, "  ✗ %s does not exist or is not visible to you\n", entry.IssueKey)
+					invalid = append(invalid, entry)
+				} else {
+					fmt.Fprintf(out, "  ✗ Failed to validate %s: %v\n", entry.IssueKey, err)
+				}
+				failureCount++
+				continue
+			}
+			fmt.Fprintf(out, "  ✓ Validated %s - %s\n", entry.IssueKey, entry.IssueSummary)
+		}
+
+		// Sync to Tempo in direct mode, Tempo creates the Jira worklog
+		if !entry.SyncedToJira && cfg.Tempo.Mode == "direct" {
+			log.Debug().Int64("id", entry.ID).Msg("Syncing to Tempo")
+			if err := logToTempo(cfg, jiraClient, &entry); err != nil {
+				log.Error().Err(err).Int64("id", entry.ID).Msg("Failed to sync to Tempo")
+				fmt.Fprintf(out, "  ✗ Failed to sync to Tempo: %v\n", err)
+				failureCount++
+			} else {
+				fmt.Fprintln(out, "  ✓ Synced to Tempo (Jira worklog created by Tempo)")
+			}
+		}
 
 		// Sync to Jira if not synced
-		if !entry.SyncedToJira {
+		if !entry.SyncedToJira && cfg.Tempo.Mode != "direct" {
 			log.Debug().Int64("id", entry.ID).Msg("Syncing to Jira")
-			worklog, err := jiraClient.AddWorklog(entry.IssueKey, entry.TimeSpentSeconds, entry.Started, entry.Comment)
-			if err != nil {
+			if err := addJiraWorklog(cfg, jiraClient, &entry, jira.WorklogOptions{}); err != nil {
 				log.Error().Err(err).Int64("id", entry.ID).Msg("Failed to sync to Jira")
-				fmt.Printf("  ✗ Failed to sync to Jira: %v\n", err)
+				fmt.Fprintf(out, "  ✗ Failed to sync to Jira: %v\n", err)
 				failureCount++
 			} else {
-				entry.SyncedToJira = true
-				entry.JiraWorklogID = &worklog.ID
-				fmt.Println("  ✓ Synced to Jira")
+				fmt.Fprintln(out, "  ✓ Synced to Jira")
 
 				// If Tempo is enabled, Jira automatically creates a Tempo worklog
 				if cfg.Tempo.Enabled {
 					entry.SyncedToTempo = true
-					fmt.Println("  ✓ Tempo worklog created automatically by Jira")
+					fmt.Fprintln(out, "  ✓ Tempo worklog created automatically by Jira")
 				}
 			}
 		}
@@ -93,8 +155,74 @@ func runSync(cmd *cobra.Command, args []string) error {
 		}
 	}
 
-	fmt.Printf("\n")
-	fmt.Printf("Sync complete: %d successful, %d failed\n", successCount, failureCount)
+	fmt.Fprintf(out, "\n")
+	fmt.Fprintf(out, "Sync complete: %d successful, %d failed\n", successCount, failureCount)
+
+	if len(invalid) > 0 {
+		fmt.Fprintf(out, "\n%d entries have an invalid issue key, correct them in 'tasklog tui' or delete them:\n", len(invalid))
+		for _, entry := range invalid {
+			fmt.Fprintf(out, "  %s  %s  %s\n", entry.Started.Format("Mon Jan 2 15:04"), entry.IssueKey, entry.TimeSpent)
+		}
+	}
+
+	return backfillLabels(cfg, store, jiraClient, out)
+}
+
+// syncAndCount syncs like syncEntries and returns how many entries were synced and how many are still unsynced
+func syncAndCount(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, out io.Writer) (synced, unsynced int, err error) {
+	before, err := store.GetUnsyncedEntries()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch unsynced entries: %w", err)
+	}
+
+	if err := syncEntries(cfg, store, jiraClient, out); err != nil {
+		return 0, 0, err
+	}
 
+	after, err := store.GetUnsyncedEntries()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch unsynced entries: %w", err)
+	}
+	return len(before) - len(after), len(after), nil
+}
+
+// validateEntry checks the issue key of an entry logged offline and fills in its summary
+// The entry is updated in place; storing it is left to the caller
+func validateEntry(store *storage.Storage, jiraClient *jira.Client, entry *storage.TimeEntry) error {
+	issue, err := jiraClient.GetIssue(entry.IssueKey)
+	if err != nil {
+		return err
+	}
+
+	if err := store.SaveIssue(toCachedIssue(*issue)); err != nil {
+		log.Debug().Err(err).Str("key", issue.Key).Msg("Failed to cache issue")
+	}
+
+	entry.IssueKey = issue.Key
+	entry.IssueSummary = issue.Fields.Summary
+	entry.PendingValidation = false
 	return nil
 }
+
+// acquireSyncLock takes the lock held while entries are sent to Jira or Tempo, waiting up to wait for another process
+// On platforms without file locks, no lock is taken and a nil lock is returned
+func acquireSyncLock(cfg *config.Config, wait time.Duration) (*lock.Lock, error) {
+	path := filepath.Join(filepath.Dir(cfg.Database.Path), "sync.lock")
+	deadline := time.Now().Add(wait)
+	for {
+		syncLock, err := lock.Acquire(path)
+		switch {
+		case err == nil:
+			return syncLock, nil
+		case errors.Is(err, lock.ErrUnsupported):
+			log.Debug().Msg("File locks are not supported, sending entries without the sync lock")
+			return nil, nil
+		case !errors.Is(err, lock.ErrLocked):
+			return nil, err
+		case !time.Now().Before(deadline):
+			return nil, errSyncRunning
+		}
+		log.Debug().Str("lock", path).Msg("Waiting for the sync lock")
+		time.Sleep(100 * time.Millisecond)
+	}
+}
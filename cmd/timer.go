@@ -0,0 +1,250 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/ui"
+)
+
+var (
+	timerLabel   string
+	timerComment string
+	timerDiscard bool
+)
+
+var timerCmd = &cobra.Command{
+	Use:   "timer",
+	Short: "Track time on a task with a running timer",
+	Long: `Start a timer when you begin working on a task and stop it when you are done.
+Stopping the timer logs the elapsed time, rounded to the nearest 5 minutes.
+
+Run without a subcommand to show the running timer.
+
+Examples:
+  tasklog timer start PROJ-123          # Start a timer on a task
+  tasklog timer start                   # Start a timer on the current git branch's issue
+  tasklog timer stop -c "Fixed login"   # Stop the timer and log the time
+  tasklog timer stop --discard          # Stop the timer without logging` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runTimerShow,
+}
+
+var timerStartCmd = &cobra.Command{
+	Use:   "start [task]",
+	Short: "Start a timer on a task",
+	Args:  cobra.MaximumNArgs(1),
+	RunE:  runTimerStart,
+}
+
+var timerStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop the running timer and log the elapsed time",
+	Args:  cobra.NoArgs,
+	RunE:  runTimerStop,
+}
+
+func init() {
+	rootCmd.AddCommand(timerCmd)
+	timerCmd.AddCommand(timerStartCmd)
+	timerCmd.AddCommand(timerStopCmd)
+
+	timerStartCmd.Flags().StringVarP(&timerLabel, "label", "l", "", "Work log label (prompted on stop if not set)")
+	timerStopCmd.Flags().StringVarP(&timerComment, "comment", "c", "", "Work log comment")
+	timerStopCmd.Flags().BoolVar(&timerDiscard, "discard", false, "Stop the timer without logging time")
+}
+
+func runTimerShow(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		fmt.Println("No timer running. Start one with 'tasklog timer start <task>'")
+		return nil
+	}
+
+	fmt.Printf("⏱️  %s - %s\n", timer.IssueKey, timer.IssueSummary)
+	fmt.Printf("Started: %s (%s ago)\n", timer.Started.Format("Mon Jan 2 15:04"), timeparse.Format(timerElapsedSeconds(timer, time.Now())))
+	return nil
+}
+
+func runTimerStart(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	if timerLabel != "" {
+		applyTempoLabels(cfg)
+		if !cfg.IsLabelAllowed(timerLabel) {
+			return fmt.Errorf("label '%s' is not in the allowed labels list", timerLabel)
+		}
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	// Fail early instead of after the Jira round trip
+	if active, err := store.GetActiveTimer(); err != nil {
+		return err
+	} else if active != nil {
+		return fmt.Errorf("timer already running on %s since %s, stop it first", active.IssueKey, active.Started.Format("15:04"))
+	}
+
+	key := ""
+	if len(args) > 0 {
+		key = args[0]
+	} else {
+		keys, err := detectGitIssueKeys(cfg)
+		if err != nil {
+			return fmt.Errorf("no task given and no git repository found: %w", err)
+		}
+		if len(keys.FromRef) == 0 {
+			return fmt.Errorf("no task given and no %s issue key found in branch '%s'", cfg.Jira.ProjectKey, keys.Branch)
+		}
+		key = keys.FromRef[0]
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	issue, err := jiraClient.GetIssue(key)
+	if err != nil {
+		return fmt.Errorf("failed to fetch task %s: %w", key, err)
+	}
+
+	timer := &storage.Timer{
+		IssueKey:     issue.Key,
+		IssueSummary: issue.Fields.Summary,
+		Label:        timerLabel,
+		Started:      time.Now(),
+	}
+	if err := store.StartTimer(timer); err != nil {
+		return err
+	}
+
+	fmt.Printf("⏱️  Timer started on %s - %s\n", issue.Key, issue.Fields.Summary)
+	setWorkStatus(cfg, issue.Key, issue.Fields.Summary, 0)
+	return nil
+}
+
+func runTimerStop(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	timer, err := store.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		return fmt.Errorf("no timer running")
+	}
+
+	if timerDiscard {
+		if _, err := store.StopTimer(); err != nil {
+			return err
+		}
+		fmt.Printf("Timer on %s discarded\n", timer.IssueKey)
+		clearWorkStatus(cfg, timer)
+		return nil
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+
+	return logTimer(cfg, store, jiraClient, timer, timerComment)
+}
+
+// logTimer stops the running timer and logs the elapsed time
+// The label is prompted for if it was not set when the timer was started
+func logTimer(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, timer *storage.Timer, comment string) error {
+	timeSeconds := timeparse.RoundSeconds(timerElapsedSeconds(timer, time.Now()))
+
+	selectedLabel := timer.Label
+	if selectedLabel == "" {
+		applyTempoLabels(cfg)
+		var err error
+		selectedLabel, err = ui.SelectLabel(cfg.Labels.AllowedLabels)
+		if err != nil {
+			return fmt.Errorf("failed to select label: %w", err)
+		}
+		if !cfg.IsLabelAllowed(selectedLabel) {
+			return fmt.Errorf("label '%s' is not allowed", selectedLabel)
+		}
+	}
+
+	// Only remove the timer once everything needed to log it is known
+	if _, err := store.StopTimer(); err != nil {
+		return err
+	}
+	clearWorkStatus(cfg, timer)
+
+	entry := timerEntry(timer, selectedLabel, comment, timeSeconds)
+
+	fmt.Printf("⏱️  Timer stopped: %s on %s\n", entry.TimeSpent, entry.IssueKey)
+
+	return submitEntry(cfg, store, jiraClient, entry, jira.WorklogOptions{})
+}
+
+// timerEntry creates the time entry logged for a stopped timer
+func timerEntry(timer *storage.Timer, label, comment string, timeSeconds int) *storage.TimeEntry {
+	return &storage.TimeEntry{
+		IssueKey:         timer.IssueKey,
+		IssueSummary:     timer.IssueSummary,
+		TimeSpentSeconds: timeSeconds,
+		TimeSpent:        timeparse.Format(timeSeconds),
+		Label:            label,
+		Comment:          comment,
+		Started:          timer.Started,
+	}
+}
+
+// timerElapsedSeconds returns the number of seconds a timer has been running at now
+func timerElapsedSeconds(timer *storage.Timer, now time.Time) int {
+	elapsed := int(now.Sub(timer.Started).Seconds())
+	if elapsed < 0 {
+		return 0
+	}
+	return elapsed
+}
@@ -0,0 +1,345 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+
+	"tasklog/internal/config"
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+	"tasklog/internal/tempo"
+	"tasklog/internal/timeparse"
+	"tasklog/internal/tui"
+	"tasklog/internal/ui"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Open the full-screen dashboard",
+	Long: `Opens a full-screen dashboard showing today's entries with their sync state,
+your in-progress Jira issues, the running timer and this week's totals.
+
+Key bindings:
+  ↑/↓, j/k   Move the selection
+  tab        Switch between today's entries and in-progress issues
+  l          Log time (to the selected issue, if any)
+  e          Edit the selected entry
+  d          Delete the selected entry
+  t          Start a timer on the selected issue, or stop the running timer
+  s          Sync unsynced entries
+  b          Take a break
+  r          Refresh
+  q          Quit` + configHelp,
+	Args: cobra.NoArgs,
+	RunE: runTUI,
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+func runTUI(cmd *cobra.Command, args []string) error {
+	// Load configuration
+	cfg, err := checkConfig()
+	if err != nil {
+		return err
+	}
+
+	// Initialize clients
+	jiraClient, err := newJiraClient(cfg)
+	if err != nil {
+		return err
+	}
+	tempoClient := tempo.NewClient(cfg.Tempo.APIToken)
+
+	// Initialize storage
+	store, err := storage.NewStorage(cfg.Database.Path)
+	if err != nil {
+		return fmt.Errorf("failed to initialize storage: %w", err)
+	}
+	defer store.Close()
+
+	// Resolved on first load, only needed for Tempo totals
+	var accountID string
+
+	dashboard := tui.New(tui.Actions{
+		Load: func() (*tui.Data, error) {
+			return loadDashboardData(cfg, store, jiraClient, tempoClient, &accountID)
+		},
+		Log: func(issue *jira.Issue) error {
+			return dashboardLog(cfg, store, jiraClient, issue)
+		},
+		Edit: func(entry storage.TimeEntry) error {
+			return editEntry(cfg, store, jiraClient, entry)
+		},
+		Delete: func(entry storage.TimeEntry) error {
+			return deleteEntry(store, jiraClient, entry)
+		},
+		Sync: func() error {
+			return syncEntries(cfg, store, jiraClient, os.Stdout)
+		},
+		Break: func() error {
+			return dashboardBreak(cfg)
+		},
+		Timer: func(issue *jira.Issue) error {
+			return toggleTimer(cfg, store, jiraClient, issue)
+		},
+	})
+
+	return dashboard.Run()
+}
+
+// loadDashboardData collects the dashboard data from the local database, Jira and Tempo
+func loadDashboardData(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, tempoClient *tempo.Client, accountID *string) (*tui.Data, error) {
+	data := &tui.Data{}
+
+	entries, err := store.GetTodayEntries()
+	if err != nil {
+		return nil, err
+	}
+	data.Entries = entries
+
+	if data.Timer, err = store.GetActiveTimer(); err != nil {
+		return nil, err
+	}
+
+	// Week totals from Monday
+	today := startOfDay(time.Now())
+	monday := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	weekEntries, err := store.GetEntriesBetween(monday, monday.AddDate(0, 0, 7))
+	if err != nil {
+		return nil, err
+	}
+	data.Week = weekTotals(monday, weekEntries)
+
+	data.Issues, data.IssuesErr = jiraClient.GetInProgressIssues(cfg.Jira.TaskStatuses)
+
+	if cfg.Tempo.Enabled && cfg.Tempo.APIToken != "" {
+		if *accountID == "" {
+			if user, err := jiraClient.GetCurrentUser(); err == nil {
+				*accountID = user.AccountID
+			}
+		}
+		if *accountID != "" {
+			worklogs, err := tempoClient.GetTodayWorklogs(*accountID)
+			if err != nil {
+				log.Debug().Err(err).Msg("Failed to fetch Tempo worklogs for dashboard")
+			} else {
+				total := 0
+				for _, wl := range worklogs {
+					total += wl.TimeSpentSeconds
+				}
+				data.TempoToday = timeparse.Format(total)
+			}
+		}
+	}
+
+	return data, nil
+}
+
+// weekTotals sums entries per day for the seven days starting at monday
+func weekTotals(monday time.Time, entries []storage.TimeEntry) []tui.DayTotal {
+	totals := make([]tui.DayTotal, 7)
+	for i := range totals {
+		totals[i].Day = monday.AddDate(0, 0, i)
+	}
+	for _, entry := range entries {
+		idx := int(startOfDay(entry.Started.In(monday.Location())).Sub(monday).Hours() / 24)
+		if idx >= 0 && idx < len(totals) {
+			totals[idx].Seconds += entry.TimeSpentSeconds
+		}
+	}
+	return totals
+}
+
+// dashboardLog logs time interactively, to the given issue or a selected one
+func dashboardLog(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, issue *jira.Issue) error {
+	var err error
+	if issue == nil {
+		if issue, err = selectTask(cfg, store, jiraClient); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Task: %s - %s\n", issue.Key, issue.Fields.Summary)
+
+	timeStr, err := ui.PromptTimeSpent()
+	if err != nil {
+		return fmt.Errorf("failed to get time spent: %w", err)
+	}
+	timeSeconds, err := timeparse.Parse(timeStr)
+	if err != nil {
+		return fmt.Errorf("invalid time format: %w", err)
+	}
+
+	applyTempoLabels(cfg)
+	selectedLabel, err := ui.SelectLabel(cfg.Labels.AllowedLabels)
+	if err != nil {
+		return fmt.Errorf("failed to select label: %w", err)
+	}
+	if !cfg.IsLabelAllowed(selectedLabel) {
+		return fmt.Errorf("label '%s' is not allowed", selectedLabel)
+	}
+
+	comment, err := ui.PromptComment()
+	if err != nil {
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	started, err := ui.PromptStartTime()
+	if err != nil {
+		return fmt.Errorf("failed to get start time: %w", err)
+	}
+
+	confirmed, err := ui.Confirm(fmt.Sprintf("Log %s to %s?", timeparse.Format(timeSeconds), issue.Key))
+	if err != nil {
+		return fmt.Errorf("failed to confirm: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return submitEntry(cfg, store, jiraClient, &storage.TimeEntry{
+		IssueKey:         issue.Key,
+		IssueSummary:     issue.Fields.Summary,
+		TimeSpentSeconds: timeSeconds,
+		TimeSpent:        timeparse.Format(timeSeconds),
+		Label:            selectedLabel,
+		Comment:          comment,
+		Started:          started,
+	}, jira.WorklogOptions{})
+}
+
+// editEntry edits the time and comment of an entry, updating the Jira worklog if it was synced
+func editEntry(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, entry storage.TimeEntry) error {
+	item := ui.ReviewItem{TimeSpent: entry.TimeSpent, Comment: entry.Comment}
+	fmt.Printf("%s - %s (%s)\n", entry.IssueKey, entry.IssueSummary, entry.Started.Format("Mon Jan 2 15:04"))
+
+	// Entries not in Jira yet can move to another issue, e.g. to correct a key typed offline
+	if !entry.SyncedToJira {
+		key, err := ui.PromptIssueKey(entry.IssueKey)
+		if err != nil {
+			return err
+		}
+		if key != entry.IssueKey {
+			entry.IssueKey = key
+			entry.IssueSummary = ""
+			entry.PendingValidation = true
+		}
+	}
+
+	if err := ui.EditItem(&item); err != nil {
+		return err
+	}
+
+	timeSeconds, err := timeparse.Parse(item.TimeSpent)
+	if err != nil {
+		return fmt.Errorf("invalid time format: %w", err)
+	}
+	entry.TimeSpentSeconds = timeSeconds
+	entry.TimeSpent = timeparse.Format(timeSeconds)
+	entry.Comment = item.Comment
+
+	// Update Jira first so the local cache never claims a change Jira does not have
+	if entry.SyncedToJira && entry.JiraWorklogID != nil {
+		if _, err := jiraClient.UpdateWorklog(entry.IssueKey, *entry.JiraWorklogID, entry.TimeSpentSeconds, entry.Started, jiraWorklogComment(cfg, &entry)); err != nil {
+			return err
+		}
+		fmt.Println("✓ Updated in Jira")
+	}
+
+	if err := store.UpdateTimeEntry(&entry); err != nil {
+		return err
+	}
+	fmt.Println("✓ Updated in local cache")
+	return nil
+}
+
+// deleteEntry deletes an entry, removing the Jira worklog if it was synced
+func deleteEntry(store *storage.Storage, jiraClient *jira.Client, entry storage.TimeEntry) error {
+	confirmed, err := ui.Confirm(fmt.Sprintf("Delete %s on %s (%s)?", entry.TimeSpent, entry.IssueKey, entry.Started.Format("15:04")))
+	if err != nil {
+		return fmt.Errorf("failed to confirm: %w", err)
+	}
+	if !confirmed {
+		fmt.Println("Cancelled.")
+		return nil
+	}
+
+	return removeEntry(store, jiraClient, entry)
+}
+
+// removeEntry deletes an entry without confirmation, removing the Jira worklog if it was synced
+func removeEntry(store *storage.Storage, jiraClient *jira.Client, entry storage.TimeEntry) error {
+	if entry.SyncedToJira && entry.JiraWorklogID != nil {
+		if err := jiraClient.DeleteWorklog(entry.IssueKey, *entry.JiraWorklogID); err != nil {
+			return err
+		}
+		fmt.Println("✓ Deleted from Jira")
+	}
+
+	if err := store.DeleteTimeEntry(entry.ID); err != nil {
+		return err
+	}
+	fmt.Println("✓ Deleted from local cache")
+	return nil
+}
+
+// dashboardBreak lets the user pick a configured break and takes it
+func dashboardBreak(cfg *config.Config) error {
+	if len(cfg.Slack.Breaks) == 0 {
+		return fmt.Errorf("no breaks configured, add breaks to your config.yaml file")
+	}
+
+	names := make([]string, len(cfg.Slack.Breaks))
+	for i, b := range cfg.Slack.Breaks {
+		names[i] = b.Name
+	}
+
+	name, err := ui.SelectOption("Select a break:", names)
+	if err != nil {
+		return err
+	}
+
+	runBreak(breakCmd, []string{name})
+	return nil
+}
+
+// toggleTimer stops the running timer, or starts one on the given or a selected issue
+func toggleTimer(cfg *config.Config, store *storage.Storage, jiraClient *jira.Client, issue *jira.Issue) error {
+	active, err := store.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+
+	if active != nil {
+		fmt.Printf("⏱️  Stopping timer on %s - %s\n", active.IssueKey, active.IssueSummary)
+		comment, err := ui.PromptComment()
+		if err != nil {
+			return fmt.Errorf("failed to get comment: %w", err)
+		}
+		return logTimer(cfg, store, jiraClient, active, comment)
+	}
+
+	if issue == nil {
+		if issue, err = selectTask(cfg, store, jiraClient); err != nil {
+			return err
+		}
+	}
+
+	if err := store.StartTimer(&storage.Timer{
+		IssueKey:     issue.Key,
+		IssueSummary: issue.Fields.Summary,
+		Started:      time.Now(),
+	}); err != nil {
+		return err
+	}
+
+	fmt.Printf("⏱️  Timer started on %s - %s\n", issue.Key, issue.Fields.Summary)
+	setWorkStatus(cfg, issue.Key, issue.Fields.Summary, 0)
+	return nil
+}
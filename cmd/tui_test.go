@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"tasklog/internal/storage"
+)
+
+func TestWeekTotals(t *testing.T) {
+	monday := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	entries := []storage.TimeEntry{
+		{TimeSpentSeconds: 3600, Started: time.Date(2025, 1, 13, 9, 0, 0, 0, time.Local)},
+		{TimeSpentSeconds: 1800, Started: time.Date(2025, 1, 13, 23, 30, 0, 0, time.Local)},
+		{TimeSpentSeconds: 7200, Started: time.Date(2025, 1, 17, 14, 0, 0, 0, time.Local)},
+		{TimeSpentSeconds: 600, Started: time.Date(2025, 1, 20, 9, 0, 0, 0, time.Local)}, // Next week
+	}
+
+	totals := weekTotals(monday, entries)
+	if len(totals) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(totals))
+	}
+	if totals[0].Seconds != 5400 {
+		t.Errorf("expected 5400 seconds on Monday, got %d", totals[0].Seconds)
+	}
+	if totals[4].Seconds != 7200 || !totals[4].Day.Equal(time.Date(2025, 1, 17, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("unexpected Friday total: %+v", totals[4])
+	}
+	if totals[6].Seconds != 0 {
+		t.Errorf("expected next week's entry to be ignored, got %d", totals[6].Seconds)
+	}
+}
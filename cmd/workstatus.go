@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"html"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+)
+
+// workStatusWindow is how close to now a logged entry must start to be shown as the working status
+const workStatusWindow = 10 * time.Minute
+
+// maxStatusLength is the longest status text Slack accepts
+const maxStatusLength = 100
+
+// setWorkStatus shows the issue as the Slack status when slack.work_status is enabled
+// A status with no expiration (expirationMinutes <= 0) stays until it is cleared
+// Failures are reported but never stop the work from being tracked
+func setWorkStatus(cfg *config.Config, key, summary string, expirationMinutes int) {
+	if !cfg.Slack.WorkStatus.Enabled {
+		return
+	}
+
+	text := workStatusText(cfg, key, summary)
+	slackClient := newSlackClient(cfg, cfg.Slack.ChannelID)
+	if err := slackClient.SetStatus(text, cfg.Slack.WorkStatus.Emoji, expirationMinutes); err != nil {
+		log.Error().Err(err).Msg("Failed to set Slack working status")
+		fmt.Printf("⚠ Failed to update Slack status: %v\n", err)
+		return
+	}
+	log.Debug().Str("status", text).Msg("Slack working status set")
+	fmt.Println("✓ Slack status updated")
+}
+
+// clearWorkStatus clears the Slack status set for a timer when slack.work_status is enabled
+// A status changed since the timer started, like a break or focus status, is left alone
+// When the status cannot be read (the token lacks users.profile:read), it is cleared regardless
+func clearWorkStatus(cfg *config.Config, timer *storage.Timer) {
+	if !cfg.Slack.WorkStatus.Enabled {
+		return
+	}
+
+	slackClient := newSlackClient(cfg, cfg.Slack.ChannelID)
+	text, emoji, err := slackClient.GetStatus()
+	if err != nil {
+		log.Debug().Err(err).Msg("Failed to read Slack status, clearing it")
+	} else if !isWorkStatus(cfg, timer, text, emoji) {
+		log.Debug().Str("status", text).Msg("Slack status changed since the timer started, leaving it")
+		return
+	}
+
+	if err := slackClient.ClearStatus(); err != nil {
+		log.Error().Err(err).Msg("Failed to clear Slack working status")
+		fmt.Printf("⚠ Failed to clear Slack status: %v\n", err)
+	}
+}
+
+// isWorkStatus reports whether a Slack status is the working status set for a timer
+// Slack returns status texts HTML-escaped
+func isWorkStatus(cfg *config.Config, timer *storage.Timer, text, emoji string) bool {
+	return emoji == cfg.Slack.WorkStatus.Emoji &&
+		html.UnescapeString(text) == workStatusText(cfg, timer.IssueKey, timer.IssueSummary)
+}
+
+// setEntryWorkStatus shows a logged entry as the Slack status until it ends
+// Only entries starting close to now are shown; entries logged after the fact are not
+func setEntryWorkStatus(cfg *config.Config, entry *storage.TimeEntry, now time.Time) {
+	if minutes := entryStatusMinutes(entry, now); minutes > 0 {
+		setWorkStatus(cfg, entry.IssueKey, entry.IssueSummary, minutes)
+	}
+}
+
+// entryStatusMinutes returns how many minutes an entry should be shown as the working status,
+// or 0 when it does not start within workStatusWindow of now
+func entryStatusMinutes(entry *storage.TimeEntry, now time.Time) int {
+	if offset := now.Sub(entry.Started); offset > workStatusWindow || offset < -workStatusWindow {
+		return 0
+	}
+	remaining := entry.Started.Add(time.Duration(entry.TimeSpentSeconds) * time.Second).Sub(now)
+	if remaining <= 0 {
+		return 0
+	}
+	return int(math.Ceil(remaining.Minutes()))
+}
+
+// workStatusText renders the slack.work_status template for an issue
+// Issues of projects not listed in projects show private_text instead
+func workStatusText(cfg *config.Config, key, summary string) string {
+	ws := cfg.Slack.WorkStatus
+	project, _, _ := strings.Cut(key, "-")
+	if len(ws.Projects) > 0 && !slices.ContainsFunc(ws.Projects, func(p string) bool { return strings.EqualFold(p, project) }) {
+		return ws.PrivateText
+	}
+	if ws.MaskSummary {
+		summary = ""
+	}
+
+	text := strings.NewReplacer(
+		"{key}", key,
+		"{summary}", summary,
+		"{project}", project,
+		"{url}", strings.TrimSuffix(cfg.Jira.URL, "/")+"/browse/"+key,
+	).Replace(ws.Template)
+	// Drop separators left over from masked placeholders
+	text = strings.Trim(strings.Join(strings.Fields(text), " "), " -:|")
+
+	if runes := []rune(text); len(runes) > maxStatusLength {
+		text = string(runes[:maxStatusLength-1]) + "…"
+	}
+	return text
+}
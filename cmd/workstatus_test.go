@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+)
+
+func TestWorkStatusText(t *testing.T) {
+	tests := []struct {
+		name       string
+		workStatus config.WorkStatusConfig
+		key        string
+		summary    string
+		want       string
+	}{
+		{
+			name:       "default template",
+			workStatus: config.WorkStatusConfig{Template: "{key} {summary}"},
+			key:        "PROJ-123",
+			summary:    "Login fix",
+			want:       "PROJ-123 Login fix",
+		},
+		{
+			name:       "link",
+			workStatus: config.WorkStatusConfig{Template: "{key} {url}"},
+			key:        "PROJ-123",
+			want:       "PROJ-123 https://example.atlassian.net/browse/PROJ-123",
+		},
+		{
+			name:       "masked summary",
+			workStatus: config.WorkStatusConfig{Template: "{key} - {summary}", MaskSummary: true},
+			key:        "PROJ-123",
+			summary:    "Secret customer",
+			want:       "PROJ-123",
+		},
+		{
+			name:       "listed project",
+			workStatus: config.WorkStatusConfig{Template: "{project}: {summary}", Projects: []string{"proj"}, PrivateText: "Working"},
+			key:        "PROJ-123",
+			summary:    "Login fix",
+			want:       "PROJ: Login fix",
+		},
+		{
+			name:       "other project",
+			workStatus: config.WorkStatusConfig{Template: "{key} {summary}", Projects: []string{"PROJ"}, PrivateText: "Working"},
+			key:        "HR-7",
+			summary:    "Salary review",
+			want:       "Working",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{}
+			cfg.Jira.URL = "https://example.atlassian.net/"
+			cfg.Slack.WorkStatus = tt.workStatus
+
+			if got := workStatusText(cfg, tt.key, tt.summary); got != tt.want {
+				t.Errorf("workStatusText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkStatusText_Truncates(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Slack.WorkStatus.Template = "{key} {summary}"
+
+	text := workStatusText(cfg, "PROJ-1", strings.Repeat("long ", 40))
+	if n := len([]rune(text)); n != maxStatusLength {
+		t.Errorf("expected %d characters, got %d", maxStatusLength, n)
+	}
+	if !strings.HasSuffix(text, "…") {
+		t.Errorf("expected truncated text to end with an ellipsis, got %q", text)
+	}
+}
+
+func TestEntryStatusMinutes(t *testing.T) {
+	now := time.Date(2026, 3, 10, 14, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name    string
+		started time.Time
+		seconds int
+		want    int
+	}{
+		{"starting now", now, 3600, 60},
+		{"started a few minutes ago", now.Add(-5 * time.Minute), 1800, 25},
+		{"starting soon", now.Add(5 * time.Minute), 1800, 35},
+		{"partial minute", now.Add(-30 * time.Second), 600, 10},
+		{"logged after the fact", now.Add(-2 * time.Hour), 3600, 0},
+		{"later today", now.Add(3 * time.Hour), 3600, 0},
+		{"already over", now.Add(-8 * time.Minute), 300, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := &storage.TimeEntry{Started: tt.started, TimeSpentSeconds: tt.seconds}
+			if got := entryStatusMinutes(entry, now); got != tt.want {
+				t.Errorf("entryStatusMinutes() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClearWorkStatus(t *testing.T) {
+	cfg := &config.Config{}
+	cfg.Slack.WorkStatus = config.WorkStatusConfig{Enabled: true, Template: "{key} {summary}", Emoji: ":computer:"}
+	fake := newFakeSlack(t, cfg)
+	timer := &storage.Timer{IssueKey: "PROJ-1", IssueSummary: "Fix <login> & signup"}
+
+	setWorkStatus(cfg, timer.IssueKey, timer.IssueSummary, 0)
+	clearWorkStatus(cfg, timer)
+	if text, emoji := fake.status(); text != "" || emoji != "" {
+		t.Errorf("expected the working status to be cleared, got %q %q", text, emoji)
+	}
+
+	// A break started while the timer ran keeps its status
+	setWorkStatus(cfg, timer.IssueKey, timer.IssueSummary, 0)
+	if err := newSlackClient(cfg, cfg.Slack.ChannelID).SetStatus("On lunch break", ":fork_and_knife:", 60); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+	clearWorkStatus(cfg, timer)
+	if text, _ := fake.status(); text != "On lunch break" {
+		t.Errorf("expected the break status to be kept, got %q", text)
+	}
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+)
+
+// unixPrefix marks a Unix socket listen address
+const unixPrefix = "unix://"
+
+// Listen opens the listener for address, either unix:///path/to/socket or a loopback host:port
+// A stale socket file is removed, but not one another server still answers on
+// Other hosts are refused, the API is meant for local tools only
+func Listen(address string) (net.Listener, error) {
+	if path, ok := strings.CutPrefix(address, unixPrefix); ok {
+		return listenUnix(path)
+	}
+
+	hostPort := strings.TrimPrefix(address, "tcp://")
+	host, _, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return nil, fmt.Errorf("invalid listen address %q, use unix:///path or 127.0.0.1:port: %w", address, err)
+	}
+	if !isLoopback(host) {
+		return nil, fmt.Errorf("refusing to listen on %s, only loopback addresses are allowed", host)
+	}
+	return net.Listen("tcp", hostPort)
+}
+
+// listenUnix listens on a Unix socket readable only by the current user
+func listenUnix(path string) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("missing socket path in unix:// address")
+	}
+
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.DialTimeout("unix", path, time.Second); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("another server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("failed to remove stale socket: %w", err)
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to restrict socket permissions: %w", err)
+	}
+	return listener, nil
+}
+
+// isLoopback reports whether host is localhost or a loopback IP
+func isLoopback(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+// tokenEqual compares tokens in constant time
+func tokenEqual(got, want string) bool {
+	return want != "" && subtle.ConstantTimeCompare([]byte(got), []byte(want)) == 1
+}
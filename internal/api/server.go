@@ -0,0 +1,480 @@
+// Package api serves tasklog over a local HTTP/JSON API for editor and tool integrations
+package api
+
+import (
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+// dateFormat is the format of date query parameters
+const dateFormat = "2006-01-02"
+
+// Spec is the OpenAPI description of the API, served at /api/v1/openapi.yaml
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+// LogRequest is the body of POST /api/v1/entries
+// With a shortcut, the issue, time and label default to the shortcut's
+type LogRequest struct {
+	IssueKey  string     `json:"issue_key"`
+	TimeSpent string     `json:"time_spent"` // e.g. "1h 30m"
+	Label     string     `json:"label"`
+	Comment   string     `json:"comment"`
+	Started   *time.Time `json:"started"` // Defaults to now
+	Account   string     `json:"account"` // Tempo account key, direct mode only
+	Shortcut  string     `json:"shortcut"`
+}
+
+// TimerRequest is the body of POST /api/v1/timer
+type TimerRequest struct {
+	IssueKey string `json:"issue_key"`
+	Label    string `json:"label"`
+}
+
+// StopTimerRequest is the body of POST /api/v1/timer/stop
+type StopTimerRequest struct {
+	Comment string `json:"comment"`
+	Label   string `json:"label"`   // Required if the timer was started without a label
+	Discard bool   `json:"discard"` // Stop without logging
+}
+
+// SyncResult is the response of POST /api/v1/sync
+type SyncResult struct {
+	Synced   int `json:"synced"`
+	Unsynced int `json:"unsynced"`
+}
+
+// Actions are the operations that reach Jira or Tempo; everything else is served from storage
+// A nil action responds with 501 Not Implemented
+type Actions struct {
+	Log        func(req LogRequest) (*storage.TimeEntry, error)
+	Delete     func(entry storage.TimeEntry) error
+	StartTimer func(req TimerRequest) (*storage.Timer, error)
+	StopTimer  func(req StopTimerRequest) (*storage.TimeEntry, error)
+	Sync       func() (*SyncResult, error)
+}
+
+// Error is an error with the HTTP status to respond with
+type Error struct {
+	Status int
+	Err    error
+}
+
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Invalid returns an error responded to with 400 Bad Request
+func Invalid(format string, args ...interface{}) error {
+	return &Error{Status: http.StatusBadRequest, Err: fmt.Errorf(format, args...)}
+}
+
+// NotFound returns an error responded to with 404 Not Found
+func NotFound(format string, args ...interface{}) error {
+	return &Error{Status: http.StatusNotFound, Err: fmt.Errorf(format, args...)}
+}
+
+// Conflict returns an error responded to with 409 Conflict
+func Conflict(format string, args ...interface{}) error {
+	return &Error{Status: http.StatusConflict, Err: fmt.Errorf(format, args...)}
+}
+
+// Server is the tasklog HTTP API
+type Server struct {
+	store     *storage.Storage
+	token     string
+	shortcuts []config.ShortcutEntry
+	actions   Actions
+	mux       *http.ServeMux
+	mu        sync.Mutex // Serializes requests that change data
+}
+
+// route is an API endpoint
+type route struct {
+	method  string
+	path    string
+	public  bool // Served without the token
+	handler func(s *Server, w http.ResponseWriter, r *http.Request)
+}
+
+// routes lists every endpoint; each one is described in openapi.yaml
+var routes = []route{
+	{method: http.MethodGet, path: "/api/v1/openapi.yaml", public: true, handler: (*Server).handleSpec},
+	{method: http.MethodGet, path: "/api/v1/entries", handler: (*Server).handleListEntries},
+	{method: http.MethodPost, path: "/api/v1/entries", handler: (*Server).handleLogEntry},
+	{method: http.MethodGet, path: "/api/v1/entries/{id}", handler: (*Server).handleGetEntry},
+	{method: http.MethodDelete, path: "/api/v1/entries/{id}", handler: (*Server).handleDeleteEntry},
+	{method: http.MethodGet, path: "/api/v1/timer", handler: (*Server).handleGetTimer},
+	{method: http.MethodPost, path: "/api/v1/timer", handler: (*Server).handleStartTimer},
+	{method: http.MethodPost, path: "/api/v1/timer/stop", handler: (*Server).handleStopTimer},
+	{method: http.MethodGet, path: "/api/v1/shortcuts", handler: (*Server).handleShortcuts},
+	{method: http.MethodGet, path: "/api/v1/summary", handler: (*Server).handleSummary},
+	{method: http.MethodPost, path: "/api/v1/sync", handler: (*Server).handleSync},
+}
+
+// NewServer creates an API server requiring token as a bearer token
+func NewServer(store *storage.Storage, token string, shortcuts []config.ShortcutEntry, actions Actions) *Server {
+	s := &Server{
+		store:     store,
+		token:     token,
+		shortcuts: shortcuts,
+		actions:   actions,
+		mux:       http.NewServeMux(),
+	}
+	for _, rt := range routes {
+		handler := rt.handler
+		h := func(w http.ResponseWriter, r *http.Request) { handler(s, w, r) }
+		if !rt.public {
+			h = s.authenticate(h)
+		}
+		s.mux.HandleFunc(rt.method+" "+rt.path, h)
+	}
+	return s
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log.Debug().Str("method", r.Method).Str("path", r.URL.Path).Msg("API request")
+	s.mux.ServeHTTP(w, r)
+}
+
+// authenticate rejects requests without the bearer token
+func (s *Server) authenticate(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || !tokenEqual(token, s.token) {
+			writeError(w, &Error{Status: http.StatusUnauthorized, Err: errors.New("missing or invalid token")})
+			return
+		}
+		next(w, r)
+	}
+}
+
+func (s *Server) handleSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/yaml")
+	_, _ = w.Write(Spec)
+}
+
+func (s *Server) handleListEntries(w http.ResponseWriter, r *http.Request) {
+	today := startOfDay(time.Now())
+	from, err := dateParam(r, "from", today)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	to, err := dateParam(r, "to", from)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if to.Before(from) {
+		writeError(w, Invalid("to must not be before from"))
+		return
+	}
+
+	entries, err := s.store.GetEntriesBetween(from, to.AddDate(0, 0, 1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if entries == nil {
+		entries = []storage.TimeEntry{}
+	}
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) handleLogEntry(w http.ResponseWriter, r *http.Request) {
+	var req LogRequest
+	if !decodeBody(w, r, &req) || !s.implemented(w, s.actions.Log != nil) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.actions.Log(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+func (s *Server) handleGetEntry(w http.ResponseWriter, r *http.Request) {
+	entry, err := s.entry(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, entry)
+}
+
+func (s *Server) handleDeleteEntry(w http.ResponseWriter, r *http.Request) {
+	if !s.implemented(w, s.actions.Delete != nil) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.entry(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if err := s.actions.Delete(*entry); err != nil {
+		writeError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// timerResponse is the response of GET /api/v1/timer
+type timerResponse struct {
+	Timer          *storage.Timer `json:"timer"` // null when no timer runs
+	ElapsedSeconds int            `json:"elapsed_seconds"`
+}
+
+func (s *Server) handleGetTimer(w http.ResponseWriter, r *http.Request) {
+	timer, err := s.store.GetActiveTimer()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	resp := timerResponse{Timer: timer}
+	if timer != nil {
+		resp.ElapsedSeconds = max(0, int(time.Since(timer.Started).Seconds()))
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) handleStartTimer(w http.ResponseWriter, r *http.Request) {
+	var req TimerRequest
+	if !decodeBody(w, r, &req) || !s.implemented(w, s.actions.StartTimer != nil) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	timer, err := s.actions.StartTimer(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, timer)
+}
+
+func (s *Server) handleStopTimer(w http.ResponseWriter, r *http.Request) {
+	var req StopTimerRequest
+	if !decodeBody(w, r, &req) || !s.implemented(w, s.actions.StopTimer != nil) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry, err := s.actions.StopTimer(req)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	if entry == nil {
+		// Discarded
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusCreated, entry)
+}
+
+// Shortcut is a configured shortcut in GET /api/v1/shortcuts
+type Shortcut struct {
+	Name    string `json:"name"`
+	Task    string `json:"task"`
+	Time    string `json:"time"`
+	Label   string `json:"label"`
+	Account string `json:"account"`
+}
+
+func (s *Server) handleShortcuts(w http.ResponseWriter, r *http.Request) {
+	shortcuts := make([]Shortcut, len(s.shortcuts))
+	for i, sc := range s.shortcuts {
+		shortcuts[i] = Shortcut{Name: sc.Name, Task: sc.Task, Time: sc.Time, Label: sc.Label, Account: sc.Account}
+	}
+	writeJSON(w, http.StatusOK, shortcuts)
+}
+
+// IssueTotal is the time logged to one issue in a summary
+type IssueTotal struct {
+	IssueKey     string `json:"issue_key"`
+	IssueSummary string `json:"issue_summary"`
+	Seconds      int    `json:"seconds"`
+	TimeSpent    string `json:"time_spent"`
+}
+
+// Summary is the response of GET /api/v1/summary
+type Summary struct {
+	Date         string       `json:"date"`
+	TotalSeconds int          `json:"total_seconds"`
+	Total        string       `json:"total"`
+	Unsynced     int          `json:"unsynced"` // Entries not yet in Jira or Tempo
+	Issues       []IssueTotal `json:"issues"`   // Most time first
+}
+
+func (s *Server) handleSummary(w http.ResponseWriter, r *http.Request) {
+	day, err := dateParam(r, "date", startOfDay(time.Now()))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	entries, err := s.store.GetEntriesBetween(day, day.AddDate(0, 0, 1))
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, summarize(day, entries))
+}
+
+func (s *Server) handleSync(w http.ResponseWriter, r *http.Request) {
+	if !s.implemented(w, s.actions.Sync != nil) {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	result, err := s.actions.Sync()
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, result)
+}
+
+// entry loads the entry named by the {id} path value
+func (s *Server) entry(r *http.Request) (*storage.TimeEntry, error) {
+	id, err := strconv.ParseInt(r.PathValue("id"), 10, 64)
+	if err != nil {
+		return nil, Invalid("invalid entry id %q", r.PathValue("id"))
+	}
+	entry, err := s.store.GetTimeEntry(id)
+	if err != nil {
+		return nil, err
+	}
+	if entry == nil {
+		return nil, NotFound("entry %d not found", id)
+	}
+	return entry, nil
+}
+
+// implemented responds with 501 Not Implemented unless ok
+func (s *Server) implemented(w http.ResponseWriter, ok bool) bool {
+	if !ok {
+		writeError(w, &Error{Status: http.StatusNotImplemented, Err: errors.New("not supported by this server")})
+	}
+	return ok
+}
+
+// summarize totals entries per issue
+func summarize(day time.Time, entries []storage.TimeEntry) Summary {
+	summary := Summary{Date: day.Format(dateFormat), Issues: []IssueTotal{}}
+	byIssue := map[string]*IssueTotal{}
+	for _, entry := range entries {
+		summary.TotalSeconds += entry.TimeSpentSeconds
+		if !entry.SyncedToJira || !entry.SyncedToTempo {
+			summary.Unsynced++
+		}
+
+		total := byIssue[entry.IssueKey]
+		if total == nil {
+			total = &IssueTotal{IssueKey: entry.IssueKey, IssueSummary: entry.IssueSummary}
+			byIssue[entry.IssueKey] = total
+		}
+		total.Seconds += entry.TimeSpentSeconds
+	}
+
+	for _, total := range byIssue {
+		total.TimeSpent = timeparse.Format(total.Seconds)
+		summary.Issues = append(summary.Issues, *total)
+	}
+	sort.Slice(summary.Issues, func(i, j int) bool {
+		if summary.Issues[i].Seconds != summary.Issues[j].Seconds {
+			return summary.Issues[i].Seconds > summary.Issues[j].Seconds
+		}
+		return summary.Issues[i].IssueKey < summary.Issues[j].IssueKey
+	})
+	summary.Total = timeparse.Format(summary.TotalSeconds)
+	return summary
+}
+
+// dateParam parses a YYYY-MM-DD query parameter in local time, returning def when it is not set
+func dateParam(r *http.Request, name string, def time.Time) (time.Time, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	day, err := time.ParseInLocation(dateFormat, value, time.Local)
+	if err != nil {
+		return time.Time{}, Invalid("invalid %s %q, use YYYY-MM-DD", name, value)
+	}
+	return day, nil
+}
+
+// decodeBody decodes a JSON request body, responding with 400 Bad Request when it is invalid
+func decodeBody(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		writeError(w, Invalid("invalid request body: %v", err))
+		return false
+	}
+	return true
+}
+
+// writeJSON writes v as a JSON response
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Debug().Err(err).Msg("Failed to write API response")
+	}
+}
+
+// writeError writes err as a JSON error response, with the status of an *Error or 500 otherwise
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var apiErr *Error
+	if errors.As(err, &apiErr) {
+		status = apiErr.Status
+	}
+	if status == http.StatusInternalServerError {
+		log.Error().Err(err).Msg("API request failed")
+	}
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// startOfDay returns midnight of the given day in its location
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,323 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/config"
+	"tasklog/internal/storage"
+)
+
+const testToken = "secret"
+
+func newTestServer(t *testing.T, actions Actions) (*Server, *storage.Storage) {
+	t.Helper()
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+
+	shortcuts := []config.ShortcutEntry{{Name: "daily", Task: "PROJ-1", Time: "15m", Label: "meeting"}}
+	return NewServer(store, testToken, shortcuts, actions), store
+}
+
+func request(t *testing.T, s *Server, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+testToken)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func addEntry(t *testing.T, store *storage.Storage, key string, seconds int, started time.Time) *storage.TimeEntry {
+	t.Helper()
+	entry := &storage.TimeEntry{
+		IssueKey:         key,
+		IssueSummary:     "Summary of " + key,
+		TimeSpentSeconds: seconds,
+		TimeSpent:        "x",
+		Started:          started,
+		SyncedToJira:     true,
+		SyncedToTempo:    true,
+	}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+	return entry
+}
+
+func TestAuthentication(t *testing.T) {
+	s, _ := newTestServer(t, Actions{})
+
+	tests := []struct {
+		name   string
+		path   string
+		header string
+		want   int
+	}{
+		{"missing token", "/api/v1/entries", "", http.StatusUnauthorized},
+		{"wrong token", "/api/v1/entries", "Bearer wrong", http.StatusUnauthorized},
+		{"not a bearer token", "/api/v1/entries", testToken, http.StatusUnauthorized},
+		{"valid token", "/api/v1/entries", "Bearer " + testToken, http.StatusOK},
+		{"spec is public", "/api/v1/openapi.yaml", "", http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			s.ServeHTTP(rec, req)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+		})
+	}
+}
+
+func TestEmptyTokenRejectsAll(t *testing.T) {
+	store, err := storage.NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+	s := NewServer(store, "", nil, Actions{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/entries", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
+
+func TestListEntries(t *testing.T) {
+	s, store := newTestServer(t, Actions{})
+	day := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	addEntry(t, store, "PROJ-1", 1800, day)
+	addEntry(t, store, "PROJ-2", 3600, day.AddDate(0, 0, 1))
+
+	var entries []storage.TimeEntry
+	rec := request(t, s, http.MethodGet, "/api/v1/entries?from=2026-03-10", "")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(entries) != 1 || entries[0].IssueKey != "PROJ-1" {
+		t.Errorf("entries = %+v, want only PROJ-1", entries)
+	}
+
+	rec = request(t, s, http.MethodGet, "/api/v1/entries?from=2026-03-10&to=2026-03-11", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &entries); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(entries) != 2 {
+		t.Errorf("got %d entries, want 2", len(entries))
+	}
+
+	rec = request(t, s, http.MethodGet, "/api/v1/entries?from=2026-03-10&to=2026-03-09", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("to before from: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = request(t, s, http.MethodGet, "/api/v1/entries?from=yesterday", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid date: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if !strings.Contains(rec.Body.String(), `"error"`) {
+		t.Errorf("expected JSON error, got %s", rec.Body)
+	}
+}
+
+func TestEntryByID(t *testing.T) {
+	var deleted int64
+	s, store := newTestServer(t, Actions{
+		Delete: func(entry storage.TimeEntry) error {
+			deleted = entry.ID
+			return nil
+		},
+	})
+	entry := addEntry(t, store, "PROJ-1", 1800, time.Now())
+
+	rec := request(t, s, http.MethodGet, "/api/v1/entries/999", "")
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("missing entry: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+	rec = request(t, s, http.MethodGet, "/api/v1/entries/abc", "")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("invalid id: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+
+	rec = request(t, s, http.MethodDelete, "/api/v1/entries/"+strconv.FormatInt(entry.ID, 10), "")
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if deleted != entry.ID {
+		t.Errorf("deleted entry %d, want %d", deleted, entry.ID)
+	}
+}
+
+func TestLogEntry(t *testing.T) {
+	var got LogRequest
+	s, _ := newTestServer(t, Actions{
+		Log: func(req LogRequest) (*storage.TimeEntry, error) {
+			got = req
+			if req.IssueKey == "NOPE-1" {
+				return nil, NotFound("issue %s does not exist", req.IssueKey)
+			}
+			return &storage.TimeEntry{ID: 1, IssueKey: req.IssueKey}, nil
+		},
+	})
+
+	rec := request(t, s, http.MethodPost, "/api/v1/entries", `{"issue_key": "PROJ-1", "time_spent": "1h", "label": "dev"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("status = %d: %s", rec.Code, rec.Body)
+	}
+	if got.IssueKey != "PROJ-1" || got.TimeSpent != "1h" || got.Label != "dev" {
+		t.Errorf("request = %+v", got)
+	}
+
+	rec = request(t, s, http.MethodPost, "/api/v1/entries", `{"issue_key": "NOPE-1"}`)
+	if rec.Code != http.StatusNotFound {
+		t.Errorf("action error: status = %d, want %d", rec.Code, http.StatusNotFound)
+	}
+
+	rec = request(t, s, http.MethodPost, "/api/v1/entries", `{"issue": "PROJ-1"}`)
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("unknown field: status = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+}
+
+func TestActionErrors(t *testing.T) {
+	s, _ := newTestServer(t, Actions{
+		Sync: func() (*SyncResult, error) {
+			return nil, errors.New("boom")
+		},
+	})
+
+	rec := request(t, s, http.MethodPost, "/api/v1/sync", "")
+	if rec.Code != http.StatusInternalServerError {
+		t.Errorf("failed action: status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+
+	rec = request(t, s, http.MethodPost, "/api/v1/timer", `{"issue_key": "PROJ-1"}`)
+	if rec.Code != http.StatusNotImplemented {
+		t.Errorf("missing action: status = %d, want %d", rec.Code, http.StatusNotImplemented)
+	}
+}
+
+func TestTimer(t *testing.T) {
+	s, store := newTestServer(t, Actions{})
+
+	var resp timerResponse
+	rec := request(t, s, http.MethodGet, "/api/v1/timer", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Timer != nil {
+		t.Errorf("expected no timer, got %+v", resp.Timer)
+	}
+
+	if err := store.StartTimer(&storage.Timer{IssueKey: "PROJ-1", Started: time.Now().Add(-10 * time.Minute)}); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	rec = request(t, s, http.MethodGet, "/api/v1/timer", "")
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if resp.Timer == nil || resp.Timer.IssueKey != "PROJ-1" {
+		t.Fatalf("timer = %+v, want PROJ-1", resp.Timer)
+	}
+	if resp.ElapsedSeconds < 600 {
+		t.Errorf("elapsed = %d, want at least 600", resp.ElapsedSeconds)
+	}
+}
+
+func TestShortcuts(t *testing.T) {
+	s, _ := newTestServer(t, Actions{})
+
+	rec := request(t, s, http.MethodGet, "/api/v1/shortcuts", "")
+	var shortcuts []Shortcut
+	if err := json.Unmarshal(rec.Body.Bytes(), &shortcuts); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+	if len(shortcuts) != 1 || shortcuts[0].Name != "daily" || shortcuts[0].Task != "PROJ-1" {
+		t.Errorf("shortcuts = %+v", shortcuts)
+	}
+}
+
+func TestSummary(t *testing.T) {
+	s, store := newTestServer(t, Actions{})
+	day := time.Date(2026, 3, 10, 9, 0, 0, 0, time.Local)
+	addEntry(t, store, "PROJ-1", 1800, day)
+	addEntry(t, store, "PROJ-2", 3600, day.Add(time.Hour))
+	unsynced := addEntry(t, store, "PROJ-1", 3600, day.Add(2*time.Hour))
+	unsynced.SyncedToJira = false
+	if err := store.UpdateTimeEntry(unsynced); err != nil {
+		t.Fatalf("failed to update entry: %v", err)
+	}
+
+	rec := request(t, s, http.MethodGet, "/api/v1/summary?date=2026-03-10", "")
+	var summary Summary
+	if err := json.Unmarshal(rec.Body.Bytes(), &summary); err != nil {
+		t.Fatalf("invalid response: %v", err)
+	}
+
+	if summary.TotalSeconds != 9000 || summary.Total != "2h 30m" {
+		t.Errorf("total = %d (%s), want 9000 (2h 30m)", summary.TotalSeconds, summary.Total)
+	}
+	if summary.Unsynced != 1 {
+		t.Errorf("unsynced = %d, want 1", summary.Unsynced)
+	}
+	if len(summary.Issues) != 2 || summary.Issues[0].IssueKey != "PROJ-1" || summary.Issues[0].Seconds != 5400 {
+		t.Errorf("issues = %+v, want PROJ-1 with 5400s first", summary.Issues)
+	}
+}
+
+func TestSpecCoversRoutes(t *testing.T) {
+	spec := string(Spec)
+	for _, rt := range routes {
+		if !strings.Contains(spec, "\n  "+rt.path+":") {
+			t.Errorf("route %s %s is not described in openapi.yaml", rt.method, rt.path)
+		}
+	}
+}
+
+func TestListen(t *testing.T) {
+	for _, address := range []string{"0.0.0.0:0", "192.168.1.10:8766", "example.com:80", "no-port"} {
+		if l, err := Listen(address); err == nil {
+			l.Close()
+			t.Errorf("Listen(%q) should fail", address)
+		}
+	}
+
+	l, err := Listen("tcp://127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen on loopback failed: %v", err)
+	}
+	l.Close()
+
+	socket := filepath.Join(t.TempDir(), "tasklog.sock")
+	l, err = Listen("unix://" + socket)
+	if err != nil {
+		t.Fatalf("Listen on socket failed: %v", err)
+	}
+	if _, err := Listen("unix://" + socket); err == nil {
+		t.Error("expected second listener on a live socket to fail")
+	}
+	l.Close()
+}
@@ -18,6 +18,12 @@ func TestCompareWithExample(t *testing.T) {
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  flavor: "cloud"
+  auth: "token"
+  oauth:
+    client_id: ""
+    client_secret: ""
+    callback_port: 8765
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
@@ -27,14 +33,38 @@ jira:
 tempo:
   enabled: false
   api_token: ""
+  mode: "jira"
+  label_attribute: ""
+  label_values: {}
+  account_attribute: ""
+  project_accounts: {}
 labels:
   allowed_labels: []
+  from_tempo: false
+  cache_ttl: "24h"
+  propagate: none
 database:
   path: ""
 slack:
   user_token: "token"
   channel_id: "C123"
   breaks: []
+  work_status:
+    enabled: false
+    template: "{key} {summary}"
+    emoji: ":computer:"
+    projects: []
+    private_text: "Working"
+    mask_summary: false
+notifiers: []
+scheduler:
+  holidays: []
+  catch_up_days: 0
+daemon:
+  sync_interval: "5m"
+  refresh_interval: "15m"
+  log_file: ""
+  reminders: []
 update:
   disabled: false
   check_interval: "24h"
@@ -47,6 +77,12 @@ update:
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  flavor: "cloud"
+  auth: "token"
+  oauth:
+    client_id: ""
+    client_secret: ""
+    callback_port: 8765
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
@@ -56,28 +92,63 @@ jira:
 tempo:
   enabled: false
   api_token: ""
+  mode: "jira"
+  label_attribute: ""
+  label_values: {}
+  account_attribute: ""
+  project_accounts: {}
 `,
 			expectUpToDate:    false,
-			expectMissingKeys: []string{"labels", "database", "slack", "update"},
+			expectMissingKeys: []string{"labels", "database", "slack", "notifiers", "scheduler", "daemon", "update"},
 		},
 		{
 			name: "missing nested fields",
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  flavor: "cloud"
+  auth: "token"
+  oauth:
+    client_id: ""
+    client_secret: ""
+    callback_port: 8765
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
 tempo:
   enabled: false
   api_token: ""
+  mode: "jira"
+  label_attribute: ""
+  label_values: {}
+  account_attribute: ""
+  project_accounts: {}
 labels:
   allowed_labels: []
+  from_tempo: false
+  cache_ttl: "24h"
+  propagate: none
 database:
   path: ""
 slack:
   user_token: "token"
   channel_id: "C123"
+  work_status:
+    enabled: false
+    template: "{key} {summary}"
+    emoji: ":computer:"
+    projects: []
+    private_text: "Working"
+    mask_summary: false
+notifiers: []
+scheduler:
+  holidays: []
+  catch_up_days: 0
+daemon:
+  sync_interval: "5m"
+  refresh_interval: "15m"
+  log_file: ""
+  reminders: []
 update:
   disabled: false
   check_interval: "24h"
@@ -90,6 +161,12 @@ update:
 			userConfig: `version: 1
 jira:
   url: "https://example.com"
+  flavor: "cloud"
+  auth: "token"
+  oauth:
+    client_id: ""
+    client_secret: ""
+    callback_port: 8765
   username: "user@example.com"
   api_token: "token"
   project_key: "PROJ"
@@ -99,14 +176,38 @@ jira:
 tempo:
   enabled: false
   api_token: ""
+  mode: "jira"
+  label_attribute: ""
+  label_values: {}
+  account_attribute: ""
+  project_accounts: {}
 labels:
   allowed_labels: []
+  from_tempo: false
+  cache_ttl: "24h"
+  propagate: none
 database:
   path: ""
 slack:
   user_token: "token"
   channel_id: "C123"
   breaks: []
+  work_status:
+    enabled: false
+    template: "{key} {summary}"
+    emoji: ":computer:"
+    projects: []
+    private_text: "Working"
+    mask_summary: false
+notifiers: []
+scheduler:
+  holidays: []
+  catch_up_days: 0
+daemon:
+  sync_interval: "5m"
+  refresh_interval: "15m"
+  log_file: ""
+  reminders: []
 update:
   disabled: false
   check_interval: "24h"
@@ -5,6 +5,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/rs/zerolog/log"
@@ -17,42 +20,76 @@ const CurrentConfigVersion = 1
 
 // Config represents the application configuration
 type Config struct {
-	Version  int            `yaml:"version,omitempty"` // Schema version for migrations
-	Jira     JiraConfig     `yaml:"jira"`
-	Tempo    TempoConfig    `yaml:"tempo"`
-	Labels   LabelsConfig   `yaml:"labels"`
-	Database DatabaseConfig `yaml:"database"`
-	Slack    SlackConfig    `yaml:"slack"`
-	Update   UpdateConfig   `yaml:"update"` // Update checking configuration (optional)
+	Version   int             `yaml:"version,omitempty"` // Schema version for migrations
+	Jira      JiraConfig      `yaml:"jira"`
+	Tempo     TempoConfig     `yaml:"tempo"`
+	Labels    LabelsConfig    `yaml:"labels"`
+	Database  DatabaseConfig  `yaml:"database"`
+	Slack     SlackConfig     `yaml:"slack"`
+	Notifiers []NotifierEntry `yaml:"notifiers" validate:"dive"` // Other chat services to notify (optional)
+	Scheduler SchedulerConfig `yaml:"scheduler"`                 // Scheduled shortcut settings (optional)
+	Daemon    DaemonConfig    `yaml:"daemon"`                    // Background daemon settings (optional)
+	Update    UpdateConfig    `yaml:"update"`                    // Update checking configuration (optional)
 }
 
-// JiraConfig contains Jira API configuration (all fields required)
+// JiraConfig contains Jira API configuration
 type JiraConfig struct {
-	URL          string          `yaml:"url" validate:"required,url"`        // Jira instance URL (required)
-	Username     string          `yaml:"username" validate:"required,email"` // Jira username/email (required)
-	APIToken     string          `yaml:"api_token" validate:"required"`      // Jira API token (required)
-	ProjectKey   string          `yaml:"project_key" validate:"required"`    // Project key to filter tasks (required)
-	TaskStatuses []string        `yaml:"task_statuses"`                      // Task statuses to include (optional, defaults to ["In Progress"])
-	Shortcuts    []ShortcutEntry `yaml:"shortcuts"`                          // Predefined shortcuts for quick time logging (optional)
+	URL          string          `yaml:"url" validate:"required,url"`                                                      // Jira instance URL (required)
+	Flavor       string          `yaml:"flavor" validate:"omitempty,oneof=cloud datacenter"`                               // Jira deployment: "cloud" or "datacenter" (optional, defaults to "cloud")
+	Auth         string          `yaml:"auth" validate:"omitempty,oneof=token oauth"`                                      // Authentication: "token" or "oauth" (optional, defaults to "token", oauth is cloud only)
+	Username     string          `yaml:"username" validate:"required_unless=Flavor datacenter Auth oauth,omitempty,email"` // Jira username/email (required for cloud token auth)
+	APIToken     string          `yaml:"api_token" validate:"required_unless=Auth oauth"`                                  // Jira API token, or Personal Access Token for datacenter (required for token auth)
+	OAuth        JiraOAuthConfig `yaml:"oauth"`                                                                            // OAuth app settings (required for oauth auth)
+	ProjectKey   string          `yaml:"project_key" validate:"required"`                                                  // Project key to filter tasks (required)
+	TaskStatuses []string        `yaml:"task_statuses"`                                                                    // Task statuses to include (optional, defaults to ["In Progress"])
+	Shortcuts    []ShortcutEntry `yaml:"shortcuts"`                                                                        // Predefined shortcuts for quick time logging (optional)
+}
+
+// JiraOAuthConfig contains the OAuth 2.0 (3LO) app used by 'tasklog login jira'
+type JiraOAuthConfig struct {
+	ClientID     string `yaml:"client_id"`     // OAuth app client ID from the Atlassian developer console
+	ClientSecret string `yaml:"client_secret"` // OAuth app client secret (optional if the app does not require one)
+	CallbackPort int    `yaml:"callback_port"` // Loopback port of the registered callback URL http://localhost:<port>/callback (default: 8765)
 }
 
 // TempoConfig contains Tempo API configuration (optional)
 type TempoConfig struct {
-	APIToken string `yaml:"api_token" validate:"required_if=Enabled true"` // Tempo API token (optional - only if logging separately to Tempo)
-	Enabled  bool   `yaml:"enabled"`                                       // Whether to log to Tempo separately (optional, default: false)
+	APIToken         string            `yaml:"api_token" validate:"required_if=Enabled true"` // Tempo API token (optional - only if logging separately to Tempo)
+	Enabled          bool              `yaml:"enabled"`                                       // Whether to log to Tempo separately (optional, default: false)
+	Mode             string            `yaml:"mode" validate:"omitempty,oneof=jira direct"`   // "jira" logs to Jira and lets Tempo pick it up, "direct" logs to Tempo (optional, default: "jira")
+	LabelAttribute   string            `yaml:"label_attribute"`                               // Tempo work attribute key that receives the label in direct mode (e.g., "_WorkType_")
+	LabelValues      map[string]string `yaml:"label_values"`                                  // Tempo attribute value per tasklog label (optional, defaults to the label itself)
+	AccountAttribute string            `yaml:"account_attribute"`                             // Tempo work attribute key that receives the account in direct mode (default: "_Account_")
+	ProjectAccounts  map[string]string `yaml:"project_accounts"`                              // Default Tempo account key per Jira project key (optional, e.g., PROJ: ACME)
 }
 
 // LabelsConfig contains label filtering configuration (optional)
 type LabelsConfig struct {
-	AllowedLabels []string `yaml:"allowed_labels"` // List of allowed labels from Jira (optional)
+	AllowedLabels []string `yaml:"allowed_labels"`                                                   // List of allowed labels from Jira (optional)
+	FromTempo     bool     `yaml:"from_tempo"`                                                       // Use the values of tempo.label_attribute as allowed labels, fetched live (optional, default: false)
+	CacheTTL      string   `yaml:"cache_ttl"`                                                        // How long fetched labels are cached, like "24h" or "1d" (default: "24h")
+	Propagate     string   `yaml:"propagate" validate:"omitempty,oneof=none property comment tempo"` // How the label reaches Jira: "none", "property", "comment" or "tempo" (default: "none")
 }
 
 // ShortcutEntry represents a predefined shortcut for quick time logging (optional)
 type ShortcutEntry struct {
-	Name  string `yaml:"name"`  // Shortcut name (e.g., "daily")
-	Task  string `yaml:"task"`  // Jira task key (e.g., "PROJ-123")
-	Time  string `yaml:"time"`  // Optional: predefined time (e.g., "30m")
-	Label string `yaml:"label"` // Work log label
+	Name           string         `yaml:"name"`                      // Shortcut name (e.g., "daily")
+	Task           string         `yaml:"task"`                      // Jira task key (e.g., "PROJ-123")
+	Time           string         `yaml:"time"`                      // Optional: predefined time (e.g., "30m")
+	Label          string         `yaml:"label"`                     // Work log label
+	Account        string         `yaml:"account,omitempty"`         // Optional: Tempo account key to bill the work to
+	AdjustEstimate string         `yaml:"adjust_estimate,omitempty"` // Optional: "auto", "new", "leave" or "manual" remaining estimate adjustment
+	Estimate       string         `yaml:"estimate,omitempty"`        // Optional: new remaining estimate ("new") or amount to reduce by ("manual")
+	Transition     string         `yaml:"transition,omitempty"`      // Optional: status or transition to move the issue to (e.g., "In Progress")
+	Schedule       *ScheduleEntry `yaml:"schedule,omitempty"`        // Optional: log automatically with 'tasklog schedule run'
+}
+
+// ScheduleEntry defines when a shortcut is logged automatically (optional)
+// Use either a cron expression or a list of weekdays plus a time of day
+type ScheduleEntry struct {
+	Cron string   `yaml:"cron,omitempty"` // Cron expression (e.g., "30 9 * * 1-5")
+	Days []string `yaml:"days,omitempty"` // Weekdays (e.g., ["mon", "tue"]), empty means every day
+	At   string   `yaml:"at,omitempty"`   // Time of day in HH:MM (e.g., "09:30")
 }
 
 // DatabaseConfig contains SQLite database configuration (optional)
@@ -62,9 +99,23 @@ type DatabaseConfig struct {
 
 // SlackConfig contains Slack integration configuration (optional)
 type SlackConfig struct {
-	UserToken string       `yaml:"user_token"` // Slack user OAuth token (optional)
-	ChannelID string       `yaml:"channel_id"` // Channel ID for break messages (optional)
-	Breaks    []BreakEntry `yaml:"breaks"`     // Predefined break types (optional)
+	UserToken string       `yaml:"user_token"`                                  // Slack user OAuth token (optional)
+	ChannelID string       `yaml:"channel_id"`                                  // Channel ID for break messages (optional)
+	Breaks    []BreakEntry `yaml:"breaks"`                                      // Predefined break types (optional)
+	BaseURL   string       `yaml:"base_url,omitempty" validate:"omitempty,url"` // Slack API URL, e.g. an Enterprise Grid proxy (default: https://slack.com/api)
+
+	WorkStatus WorkStatusConfig `yaml:"work_status"` // Status showing the task being worked on (optional)
+}
+
+// WorkStatusConfig sets the Slack status to the task being worked on (optional)
+// The status is set when a timer starts or time is logged starting now
+type WorkStatusConfig struct {
+	Enabled     bool     `yaml:"enabled"`      // Set the working status (default: false)
+	Template    string   `yaml:"template"`     // Status text with {key}, {summary}, {project} and {url} (default: "{key} {summary}")
+	Emoji       string   `yaml:"emoji"`        // Status emoji (default: ":computer:")
+	Projects    []string `yaml:"projects"`     // Project keys shown in the status, others show private_text (optional, defaults to all)
+	PrivateText string   `yaml:"private_text"` // Status text for issues of projects not shown (default: "Working")
+	MaskSummary bool     `yaml:"mask_summary"` // Leave issue summaries out of the status (default: false)
 }
 
 // BreakEntry represents a predefined break type (optional)
@@ -72,6 +123,42 @@ type BreakEntry struct {
 	Name     string `yaml:"name"`     // Break name (e.g., "lunch", "prayer")
 	Duration int    `yaml:"duration"` // Duration in minutes
 	Emoji    string `yaml:"emoji"`    // Emoji for Slack status (optional)
+
+	Notifiers []string `yaml:"notifiers,omitempty"` // Names of the notifiers to notify (optional, defaults to all notified of breaks)
+}
+
+// NotifierEntry is a chat service notified of breaks, standups and daily summaries (optional)
+// Slack is notified of every event without an entry; add a slack entry to change its channel or events
+type NotifierEntry struct {
+	Name    string            `yaml:"name" validate:"required"`                                              // Name referenced by breaks (e.g., "team-chat")
+	Type    string            `yaml:"type" validate:"required,oneof=slack teams mattermost discord webhook"` // Chat service
+	URL     string            `yaml:"url,omitempty" validate:"omitempty,url"`                                // Incoming webhook URL (required except for slack)
+	Channel string            `yaml:"channel,omitempty"`                                                     // Channel ID (slack only, defaults to slack.channel_id)
+	Body    string            `yaml:"body,omitempty"`                                                        // JSON body template, e.g. '{"text": {{json .Text}}}' (webhook only)
+	Headers map[string]string `yaml:"headers,omitempty"`                                                     // Extra HTTP headers (webhook only)
+	Events  []string          `yaml:"events,omitempty" validate:"dive,oneof=break standup summary"`          // Events to notify of (optional, defaults to all)
+}
+
+// SchedulerConfig contains settings for scheduled shortcuts (optional)
+type SchedulerConfig struct {
+	Holidays    []string `yaml:"holidays"`      // Dates to skip in YYYY-MM-DD format (optional)
+	CatchUpDays int      `yaml:"catch_up_days"` // Past days to catch up on missed runs (default: 0, today only)
+}
+
+// DaemonConfig contains settings for 'tasklog daemon' (optional)
+type DaemonConfig struct {
+	SyncInterval    string          `yaml:"sync_interval"`    // How often unsynced entries are retried, like "5m" (default: "5m")
+	RefreshInterval string          `yaml:"refresh_interval"` // How often the issue cache is refreshed, like "15m" (default: "15m")
+	LogFile         string          `yaml:"log_file"`         // Daemon log file (optional, defaults to ~/.tasklog/daemon.log)
+	Reminders       []ReminderEntry `yaml:"reminders"`        // Desktop reminders fired by the daemon (optional)
+}
+
+// ReminderEntry is a desktop notification fired by 'tasklog daemon' on a schedule
+type ReminderEntry struct {
+	Name      string        `yaml:"name"`                 // Reminder name (e.g., "end-of-day")
+	Message   string        `yaml:"message"`              // Notification text
+	MinLogged string        `yaml:"min_logged,omitempty"` // Optional: only remind when less than this is logged today (e.g., "8h")
+	Schedule  ScheduleEntry `yaml:"schedule"`             // When to remind, with cron or days/at like shortcut schedules
 }
 
 // UpdateConfig contains update checking configuration (optional)
@@ -108,6 +195,50 @@ func Load() (*Config, error) {
 		config.Database.Path = filepath.Join(getDefaultConfigDir(), "tasklog.db")
 	}
 
+	if config.Jira.Flavor == "" {
+		config.Jira.Flavor = "cloud"
+	}
+	if config.Jira.Auth == "" {
+		config.Jira.Auth = "token"
+	}
+	if config.Jira.OAuth.CallbackPort == 0 {
+		config.Jira.OAuth.CallbackPort = 8765
+	}
+	if config.Tempo.Mode == "" {
+		config.Tempo.Mode = "jira"
+	}
+	if config.Tempo.AccountAttribute == "" {
+		config.Tempo.AccountAttribute = "_Account_"
+	}
+	if config.Labels.CacheTTL == "" {
+		config.Labels.CacheTTL = "24h"
+	}
+	if config.Labels.Propagate == "" {
+		config.Labels.Propagate = "none"
+	}
+
+	// Set Slack working status defaults
+	if config.Slack.WorkStatus.Template == "" {
+		config.Slack.WorkStatus.Template = "{key} {summary}"
+	}
+	if config.Slack.WorkStatus.Emoji == "" {
+		config.Slack.WorkStatus.Emoji = ":computer:"
+	}
+	if config.Slack.WorkStatus.PrivateText == "" {
+		config.Slack.WorkStatus.PrivateText = "Working"
+	}
+
+	// Set daemon config defaults
+	if config.Daemon.SyncInterval == "" {
+		config.Daemon.SyncInterval = "5m"
+	}
+	if config.Daemon.RefreshInterval == "" {
+		config.Daemon.RefreshInterval = "15m"
+	}
+	if config.Daemon.LogFile == "" {
+		config.Daemon.LogFile = filepath.Join(getDefaultConfigDir(), "daemon.log")
+	}
+
 	// Set update config defaults
 	if config.Update.CheckInterval == "" {
 		config.Update.CheckInterval = "24h" // Default: check once per day
@@ -135,12 +266,14 @@ func (c *Config) Validate() error {
 				field := convertFieldNameToYAMLPath(fieldErr.Namespace())
 
 				switch fieldErr.Tag() {
-				case "required":
+				case "required", "required_unless":
 					return fmt.Errorf("%s is required", field)
 				case "url":
 					return fmt.Errorf("%s must be a valid URL", field)
 				case "email":
 					return fmt.Errorf("%s must be a valid email address", field)
+				case "oneof":
+					return fmt.Errorf("%s must be one of: %s", field, fieldErr.Param())
 				case "required_if":
 					// Extract the field name from the parameter (e.g., "Enabled true" -> "enabled is true")
 					return fmt.Errorf("%s is required when %s.enabled is true", field, "tempo")
@@ -151,6 +284,40 @@ func (c *Config) Validate() error {
 		}
 		return err
 	}
+
+	if c.Jira.Auth == "oauth" {
+		if c.Jira.Flavor == "datacenter" {
+			return fmt.Errorf("jira.auth oauth is only supported for jira.flavor cloud")
+		}
+		if c.Jira.OAuth.ClientID == "" {
+			return fmt.Errorf("jira.oauth.client_id is required when jira.auth is oauth")
+		}
+	}
+
+	if c.Tempo.Mode == "direct" && !c.Tempo.Enabled {
+		return fmt.Errorf("tempo.enabled must be true when tempo.mode is direct")
+	}
+
+	// Accounts are sent as a Tempo work attribute, which only direct mode logs
+	if c.Tempo.Mode != "direct" {
+		for _, shortcut := range c.Jira.Shortcuts {
+			if shortcut.Account != "" {
+				return fmt.Errorf("shortcut '%s': account can only be set when tempo.mode is direct", shortcut.Name)
+			}
+		}
+	}
+
+	if c.Slack.WorkStatus.Enabled && c.Slack.UserToken == "" {
+		return fmt.Errorf("slack.user_token is required when slack.work_status.enabled is true")
+	}
+
+	if err := c.validateNotifiers(); err != nil {
+		return err
+	}
+
+	if c.Labels.Propagate == "tempo" && (!c.Tempo.Enabled || c.Tempo.LabelAttribute == "") {
+		return fmt.Errorf("labels.propagate tempo requires tempo.enabled and tempo.label_attribute")
+	}
 	return nil
 }
 
@@ -213,6 +380,20 @@ func (c *Config) IsLabelAllowed(label string) bool {
 	return false
 }
 
+// TempoLabelValue returns the Tempo work attribute value for a tasklog label
+func (c *Config) TempoLabelValue(label string) string {
+	if value, ok := c.Tempo.LabelValues[label]; ok {
+		return value
+	}
+	return label
+}
+
+// ProjectAccount returns the default Tempo account key for the project of an issue key
+func (c *Config) ProjectAccount(issueKey string) string {
+	projectKey, _, _ := strings.Cut(issueKey, "-")
+	return c.Tempo.ProjectAccounts[projectKey]
+}
+
 // GetBreak returns a break by name
 func (c *Config) GetBreak(name string) (*BreakEntry, bool) {
 	for _, breakEntry := range c.Slack.Breaks {
@@ -223,6 +404,17 @@ func (c *Config) GetBreak(name string) (*BreakEntry, bool) {
 	return nil, false
 }
 
+// IsHoliday checks if the given day is listed in the scheduler holidays
+func (c *Config) IsHoliday(day time.Time) bool {
+	date := day.Format("2006-01-02")
+	for _, holiday := range c.Scheduler.Holidays {
+		if holiday == date {
+			return true
+		}
+	}
+	return false
+}
+
 // getDefaultConfigDir returns the configuration directory path
 func getDefaultConfigDir() string {
 	homeDir, err := os.UserHomeDir()
@@ -271,3 +463,65 @@ func EnsureConfigDir() error {
 	}
 	return nil
 }
+
+// HasNotifierEntry reports whether a notifier of the given type is configured
+func (c *Config) HasNotifierEntry(notifierType string) bool {
+	for _, n := range c.Notifiers {
+		if n.Type == notifierType {
+			return true
+		}
+	}
+	return false
+}
+
+// NotifierNames returns the names of all notifiers, including the built-in slack notifier
+func (c *Config) NotifierNames() []string {
+	var names []string
+	if c.Slack.UserToken != "" && !c.HasNotifierEntry("slack") {
+		names = append(names, "slack")
+	}
+	for _, n := range c.Notifiers {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+// validateNotifiers checks the notifier entries and the notifiers referenced by breaks
+func (c *Config) validateNotifiers() error {
+	seen := map[string]bool{}
+	for _, n := range c.Notifiers {
+		if seen[n.Name] {
+			return fmt.Errorf("notifier '%s' is defined twice", n.Name)
+		}
+		seen[n.Name] = true
+
+		if n.Type == "slack" {
+			if c.Slack.UserToken == "" {
+				return fmt.Errorf("notifier '%s': slack.user_token is required for slack notifiers", n.Name)
+			}
+			if n.Channel == "" && c.Slack.ChannelID == "" {
+				return fmt.Errorf("notifier '%s': channel or slack.channel_id is required", n.Name)
+			}
+			continue
+		}
+		if n.Name == "slack" {
+			return fmt.Errorf("notifier name 'slack' is reserved for slack notifiers")
+		}
+		if n.URL == "" {
+			return fmt.Errorf("notifier '%s': url is required for %s notifiers", n.Name, n.Type)
+		}
+		if n.Type == "webhook" && n.Body == "" {
+			return fmt.Errorf("notifier '%s': body is required for webhook notifiers", n.Name)
+		}
+	}
+
+	names := c.NotifierNames()
+	for _, b := range c.Slack.Breaks {
+		for _, name := range b.Notifiers {
+			if !slices.Contains(names, name) {
+				return fmt.Errorf("break '%s': notifier '%s' is not configured", b.Name, name)
+			}
+		}
+	}
+	return nil
+}
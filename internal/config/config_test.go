@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 )
 
 func TestValidate(t *testing.T) {
@@ -58,6 +59,70 @@ func TestValidate(t *testing.T) {
 			wantError: true,
 			errorMsg:  "jira.username is required",
 		},
+		{
+			name: "datacenter without username",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://jira.example.com",
+					Flavor:     "datacenter",
+					APIToken:   "personal-access-token",
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid jira flavor",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Flavor:     "server",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: true,
+			errorMsg:  "jira.flavor must be one of: cloud datacenter",
+		},
+		{
+			name: "oauth without username and api token",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Auth:       "oauth",
+					OAuth:      JiraOAuthConfig{ClientID: "client-1"},
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "oauth without client id",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Auth:       "oauth",
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: true,
+			errorMsg:  "jira.oauth.client_id is required when jira.auth is oauth",
+		},
+		{
+			name: "oauth on datacenter",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://jira.example.com",
+					Flavor:     "datacenter",
+					Auth:       "oauth",
+					OAuth:      JiraOAuthConfig{ClientID: "client-1"},
+					ProjectKey: "PROJ",
+				},
+			},
+			wantError: true,
+			errorMsg:  "jira.auth oauth is only supported for jira.flavor cloud",
+		},
 		{
 			name: "missing jira api token",
 			config: Config{
@@ -88,6 +153,55 @@ func TestValidate(t *testing.T) {
 			wantError: true,
 			errorMsg:  "jira.project_key is required",
 		},
+		{
+			name: "direct tempo mode without tempo enabled",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Tempo: TempoConfig{
+					APIToken: "tempo-token",
+					Mode:     "direct",
+				},
+			},
+			wantError: true,
+			errorMsg:  "tempo.enabled must be true when tempo.mode is direct",
+		},
+		{
+			name: "label propagation to tempo without label attribute",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Tempo: TempoConfig{
+					APIToken: "tempo-token",
+					Enabled:  true,
+				},
+				Labels: LabelsConfig{Propagate: "tempo"},
+			},
+			wantError: true,
+			errorMsg:  "labels.propagate tempo requires tempo.enabled and tempo.label_attribute",
+		},
+		{
+			name: "invalid label propagation",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Labels: LabelsConfig{Propagate: "description"},
+			},
+			wantError: true,
+			errorMsg:  "labels.propagate must be one of: none property comment tempo",
+		},
 		{
 			name: "missing tempo api token",
 			config: Config{
@@ -104,6 +218,113 @@ func TestValidate(t *testing.T) {
 			wantError: true,
 			errorMsg:  "tempo.api_token is required when tempo.enabled is true",
 		},
+		{
+			name: "shortcut account without direct mode",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+					Shortcuts:  []ShortcutEntry{{Name: "acme", Task: "PROJ-1", Account: "ACME"}},
+				},
+				Tempo: TempoConfig{Mode: "jira"},
+			},
+			wantError: true,
+			errorMsg:  "shortcut 'acme': account can only be set when tempo.mode is direct",
+		},
+		{
+			name: "valid notifiers",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Slack: SlackConfig{
+					UserToken: "xoxp-token",
+					ChannelID: "C123",
+					Breaks:    []BreakEntry{{Name: "lunch", Duration: 60, Notifiers: []string{"slack", "team-chat"}}},
+				},
+				Notifiers: []NotifierEntry{
+					{Name: "team-chat", Type: "teams", URL: "https://example.webhook.office.com/hook", Events: []string{"break", "standup"}},
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "notifier without url",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Notifiers: []NotifierEntry{{Name: "chat", Type: "discord"}},
+			},
+			wantError: true,
+			errorMsg:  "notifier 'chat': url is required for discord notifiers",
+		},
+		{
+			name: "invalid notifier type",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Notifiers: []NotifierEntry{{Name: "chat", Type: "irc", URL: "https://example.com"}},
+			},
+			wantError: true,
+			errorMsg:  "notifiers[0].type must be one of: slack teams mattermost discord webhook",
+		},
+		{
+			name: "invalid notifier event",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Notifiers: []NotifierEntry{{Name: "chat", Type: "discord", URL: "https://example.com", Events: []string{"lunch"}}},
+			},
+			wantError: true,
+			errorMsg:  "notifiers[0].events[0] must be one of: break standup summary",
+		},
+		{
+			name: "break with unknown notifier",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Slack: SlackConfig{
+					Breaks: []BreakEntry{{Name: "lunch", Duration: 60, Notifiers: []string{"slack"}}},
+				},
+			},
+			wantError: true,
+			errorMsg:  "break 'lunch': notifier 'slack' is not configured",
+		},
+		{
+			name: "invalid slack base url",
+			config: Config{
+				Jira: JiraConfig{
+					URL:        "https://example.atlassian.net",
+					Username:   "user@example.com",
+					APIToken:   "token123",
+					ProjectKey: "PROJ",
+				},
+				Slack: SlackConfig{BaseURL: "slack-proxy"},
+			},
+			wantError: true,
+			errorMsg:  "slack.base_url must be a valid URL",
+		},
 	}
 
 	for _, tt := range tests {
@@ -438,3 +659,51 @@ func TestEnsureConfigDir_RespectsEnvVar(t *testing.T) {
 		t.Errorf("expected directory %s to be created, but it does not exist", expectedDir)
 	}
 }
+
+func TestConfig_IsHoliday(t *testing.T) {
+	config := &Config{
+		Scheduler: SchedulerConfig{
+			Holidays: []string{"2025-12-25", "2026-01-01"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		day      time.Time
+		expected bool
+	}{
+		{"listed holiday", time.Date(2025, 12, 25, 9, 30, 0, 0, time.Local), true},
+		{"another holiday", time.Date(2026, 1, 1, 0, 0, 0, 0, time.Local), true},
+		{"working day", time.Date(2025, 12, 24, 9, 30, 0, 0, time.Local), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.IsHoliday(tt.day); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestConfig_TempoLabelValue(t *testing.T) {
+	cfg := &Config{Tempo: TempoConfig{LabelValues: map[string]string{"development": "Development"}}}
+
+	if got := cfg.TempoLabelValue("development"); got != "Development" {
+		t.Errorf("expected mapped value, got %q", got)
+	}
+	if got := cfg.TempoLabelValue("meeting"); got != "meeting" {
+		t.Errorf("expected unmapped label to be used as is, got %q", got)
+	}
+}
+
+func TestConfig_ProjectAccount(t *testing.T) {
+	cfg := &Config{Tempo: TempoConfig{ProjectAccounts: map[string]string{"PROJ": "ACME"}}}
+
+	if got := cfg.ProjectAccount("PROJ-123"); got != "ACME" {
+		t.Errorf("expected the project's account, got %q", got)
+	}
+	if got := cfg.ProjectAccount("OTHER-1"); got != "" {
+		t.Errorf("expected no account for an unmapped project, got %q", got)
+	}
+}
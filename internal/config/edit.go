@@ -0,0 +1,113 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Update sets a config value by its dot-separated path (e.g., "labels.allowed_labels")
+type Update struct {
+	Path  string
+	Value interface{}
+}
+
+// UpdateFile applies updates to the config file at path, keeping comments and all other settings
+// Missing sections along an update path are created
+func UpdateFile(path string, updates ...Update) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	updated, err := updateYAML(data, updates...)
+	if err != nil {
+		return err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+	if err := os.WriteFile(path, updated, info.Mode().Perm()); err != nil {
+		return fmt.Errorf("failed to write config file: %w", err)
+	}
+	return nil
+}
+
+// updateYAML applies updates to a YAML document
+func updateYAML(data []byte, updates ...Update) ([]byte, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	if doc.Kind == 0 {
+		// Empty file
+		doc = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("config file must contain a mapping")
+	}
+
+	for _, update := range updates {
+		var value yaml.Node
+		if err := value.Encode(update.Value); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", update.Path, err)
+		}
+		if err := setNode(doc.Content[0], strings.Split(update.Path, "."), &value); err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", update.Path, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode config file: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode config file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// setNode sets the value at keys below a mapping node, creating mappings as needed
+func setNode(mapping *yaml.Node, keys []string, value *yaml.Node) error {
+	for i := 0; i < len(mapping.Content); i += 2 {
+		keyNode, valueNode := mapping.Content[i], mapping.Content[i+1]
+		if keyNode.Value != keys[0] {
+			continue
+		}
+
+		if len(keys) == 1 {
+			// Keep comments attached to the old value
+			value.HeadComment = valueNode.HeadComment
+			value.LineComment = valueNode.LineComment
+			value.FootComment = valueNode.FootComment
+			mapping.Content[i+1] = value
+			return nil
+		}
+
+		if valueNode.Kind != yaml.MappingNode {
+			if valueNode.Tag != "!!null" {
+				return fmt.Errorf("%s is not a mapping", keys[0])
+			}
+			// An empty section ("labels:") becomes a mapping
+			*valueNode = yaml.Node{Kind: yaml.MappingNode, HeadComment: valueNode.HeadComment, LineComment: valueNode.LineComment}
+		}
+		return setNode(valueNode, keys[1:], value)
+	}
+
+	// Key not found, append it
+	keyNode := &yaml.Node{Kind: yaml.ScalarNode, Value: keys[0]}
+	if len(keys) == 1 {
+		mapping.Content = append(mapping.Content, keyNode, value)
+		return nil
+	}
+	child := &yaml.Node{Kind: yaml.MappingNode}
+	mapping.Content = append(mapping.Content, keyNode, child)
+	return setNode(child, keys[1:], value)
+}
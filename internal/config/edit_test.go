@@ -0,0 +1,82 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func TestUpdateYAML(t *testing.T) {
+	input := `# Tasklog Configuration
+jira:
+  url: "https://example.atlassian.net" # Site URL
+  project_key: "PROJ"
+
+# Allowed labels
+labels:
+  allowed_labels:
+    - old
+tempo:
+`
+
+	output, err := updateYAML([]byte(input),
+		Update{Path: "labels.allowed_labels", Value: []string{"Development", "Meeting"}},
+		Update{Path: "tempo.label_attribute", Value: "_WorkType_"},
+		Update{Path: "slack.channel_id", Value: "C123"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	text := string(output)
+	for _, want := range []string{"# Tasklog Configuration", "# Site URL", "# Allowed labels"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("expected comment %q to be kept\n%s", want, text)
+		}
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(output, &cfg); err != nil {
+		t.Fatalf("output is not valid YAML: %v\n%s", err, text)
+	}
+	if strings.Join(cfg.Labels.AllowedLabels, ",") != "Development,Meeting" {
+		t.Errorf("unexpected allowed labels: %v", cfg.Labels.AllowedLabels)
+	}
+	if cfg.Tempo.LabelAttribute != "_WorkType_" {
+		t.Errorf("expected empty tempo section to be filled, got %q", cfg.Tempo.LabelAttribute)
+	}
+	if cfg.Slack.ChannelID != "C123" {
+		t.Errorf("expected missing slack section to be created, got %q", cfg.Slack.ChannelID)
+	}
+	if cfg.Jira.ProjectKey != "PROJ" {
+		t.Errorf("expected other settings to be kept, got %q", cfg.Jira.ProjectKey)
+	}
+}
+
+func TestUpdateYAML_NotAMapping(t *testing.T) {
+	if _, err := updateYAML([]byte("labels: none\n"), Update{Path: "labels.allowed_labels", Value: []string{"a"}}); err == nil {
+		t.Error("expected an error when a section is not a mapping")
+	}
+}
+
+func TestUpdateFile_KeepsPermissions(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("labels:\n  allowed_labels: []\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := UpdateFile(path, Update{Path: "labels.allowed_labels", Value: []string{"dev"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected permissions 0600, got %v", info.Mode().Perm())
+	}
+}
@@ -12,9 +12,14 @@ func GenerateExampleConfig() ([]byte, error) {
 	exampleConfig := Config{
 		Version: CurrentConfigVersion,
 		Jira: JiraConfig{
-			URL:        "https://your-domain.atlassian.net",
-			Username:   "your-email@example.com",
-			APIToken:   "your-jira-api-token",
+			URL:      "https://your-domain.atlassian.net",
+			Flavor:   "cloud",
+			Auth:     "token",
+			Username: "your-email@example.com",
+			APIToken: "your-jira-api-token",
+			OAuth: JiraOAuthConfig{
+				CallbackPort: 8765,
+			},
 			ProjectKey: "PROJ",
 			TaskStatuses: []string{
 				"In Progress",
@@ -26,6 +31,10 @@ func GenerateExampleConfig() ([]byte, error) {
 					Task:  "PROJ-123",
 					Time:  "30m",
 					Label: "meeting",
+					Schedule: &ScheduleEntry{
+						Days: []string{"mon", "tue", "wed", "thu", "fri"},
+						At:   "09:30",
+					},
 				},
 				{
 					Name:  "standup",
@@ -42,8 +51,13 @@ func GenerateExampleConfig() ([]byte, error) {
 			},
 		},
 		Tempo: TempoConfig{
-			Enabled:  false,
-			APIToken: "",
+			Enabled:          false,
+			APIToken:         "",
+			Mode:             "jira",
+			LabelAttribute:   "",
+			LabelValues:      map[string]string{},
+			AccountAttribute: "_Account_",
+			ProjectAccounts:  map[string]string{},
 		},
 		Labels: LabelsConfig{
 			AllowedLabels: []string{
@@ -54,6 +68,9 @@ func GenerateExampleConfig() ([]byte, error) {
 				"documentation",
 				"bug-fix",
 			},
+			FromTempo: false,
+			CacheTTL:  "24h",
+			Propagate: "none",
 		},
 		Database: DatabaseConfig{
 			Path: "",
@@ -78,6 +95,35 @@ func GenerateExampleConfig() ([]byte, error) {
 					Emoji:    ":coffee:",
 				},
 			},
+			WorkStatus: WorkStatusConfig{
+				Enabled:     false,
+				Template:    "{key} {summary}",
+				Emoji:       ":computer:",
+				Projects:    []string{},
+				PrivateText: "Working",
+				MaskSummary: false,
+			},
+		},
+		Notifiers: []NotifierEntry{},
+		Scheduler: SchedulerConfig{
+			Holidays:    []string{"2025-12-25"},
+			CatchUpDays: 0,
+		},
+		Daemon: DaemonConfig{
+			SyncInterval:    "5m",
+			RefreshInterval: "15m",
+			LogFile:         "",
+			Reminders: []ReminderEntry{
+				{
+					Name:      "end-of-day",
+					Message:   "Log your time before you leave",
+					MinLogged: "8h",
+					Schedule: ScheduleEntry{
+						Days: []string{"mon", "tue", "wed", "thu", "fri"},
+						At:   "17:30",
+					},
+				},
+			},
 		},
 		Update: UpdateConfig{
 			Disabled:      false, // false = update checks enabled (default)
@@ -128,7 +174,13 @@ func addConfigComments(node *yaml.Node) {
 		case "database":
 			valueNode.HeadComment = "Database configuration (optional)"
 		case "slack":
-			valueNode.HeadComment = "Slack integration for break notifications (optional)"
+			valueNode.HeadComment = "Slack integration for break notifications and working status (optional)"
+		case "notifiers":
+			valueNode.HeadComment = "Other chat services notified of breaks, standups and daily summaries (optional)\nTypes: slack, teams, mattermost, discord or webhook"
+		case "scheduler":
+			valueNode.HeadComment = "Scheduled shortcuts run by 'tasklog schedule run' (optional)"
+		case "daemon":
+			valueNode.HeadComment = "Background sync and reminders run by 'tasklog daemon' (optional)"
 		case "update":
 			valueNode.HeadComment = "Update checking configuration (optional)"
 		}
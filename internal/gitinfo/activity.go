@@ -0,0 +1,157 @@
+package gitinfo
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"tasklog/internal/timeparse"
+)
+
+// fieldSeparator separates fields in the git log output format
+const fieldSeparator = "\x1f"
+
+// Commit represents a commit found in a local repository
+type Commit struct {
+	Repo      string
+	Hash      string
+	Time      time.Time // Author date
+	Subject   string
+	Ref       string   // Ref through which the commit was reached (usually a branch)
+	IssueKeys []string // Issue keys found in the ref name and subject
+}
+
+// EstimateOptions controls how commits are turned into time blocks
+type EstimateOptions struct {
+	MaxGap     time.Duration // Commits further apart than this start a new block
+	LeadTime   time.Duration // Time assumed to be spent before the first commit of a block
+	MinimumLen time.Duration // Minimum length of a block
+}
+
+// DefaultEstimateOptions returns the default estimation settings
+func DefaultEstimateOptions() EstimateOptions {
+	return EstimateOptions{
+		MaxGap:     2 * time.Hour,
+		LeadTime:   30 * time.Minute,
+		MinimumLen: 15 * time.Minute,
+	}
+}
+
+// Suggestion is a proposed time entry built from a block of commits on one issue
+type Suggestion struct {
+	IssueKey         string
+	Started          time.Time
+	TimeSpentSeconds int // Rounded to the nearest 5 minutes
+	Commits          []Commit
+}
+
+// AuthorCommits returns the commits authored by authorEmail in the repository at dir
+// between from (inclusive) and to (exclusive), across all local branches
+func AuthorCommits(dir, authorEmail, projectKey string, from, to time.Time) ([]Commit, error) {
+	format := strings.Join([]string{"%H", "%aI", "%s", "%S"}, fieldSeparator)
+	out, err := runGit(dir, "log", "--all",
+		"--author="+regexp.QuoteMeta(authorEmail),
+		"--since="+from.Add(-24*time.Hour).Format(time.RFC3339),
+		"--until="+to.Add(24*time.Hour).Format(time.RFC3339),
+		"--format="+format,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(out, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		fields := strings.Split(line, fieldSeparator)
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected git log output: %q", line)
+		}
+
+		authored, err := time.Parse(time.RFC3339, fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse commit date %q: %w", fields[1], err)
+		}
+		// git filters by committer date; filter by author date here
+		authored = authored.In(from.Location())
+		if authored.Before(from) || !authored.Before(to) {
+			continue
+		}
+
+		ref := strings.TrimPrefix(strings.TrimPrefix(fields[3], "refs/heads/"), "refs/remotes/")
+		commits = append(commits, Commit{
+			Repo:      dir,
+			Hash:      fields[0],
+			Time:      authored,
+			Subject:   fields[2],
+			Ref:       ref,
+			IssueKeys: uniqueKeys(append(ExtractIssueKeys(fields[2], projectKey), ExtractIssueKeys(ref, projectKey)...)),
+		})
+	}
+
+	return commits, nil
+}
+
+// GitUserEmail returns the git user.email configured for the repository at dir
+func GitUserEmail(dir string) (string, error) {
+	email, err := runGit(dir, "config", "user.email")
+	if err != nil {
+		return "", fmt.Errorf("failed to read git user.email: %w", err)
+	}
+	return email, nil
+}
+
+// EstimateBlocks groups commits by issue key and estimates time blocks from their timestamps
+// Commits without an issue key are ignored; a commit mentioning several keys counts towards the first
+// Suggestions are returned in chronological order
+func EstimateBlocks(commits []Commit, opts EstimateOptions) []Suggestion {
+	byIssue := map[string][]Commit{}
+	for _, c := range commits {
+		if len(c.IssueKeys) == 0 {
+			continue
+		}
+		byIssue[c.IssueKeys[0]] = append(byIssue[c.IssueKeys[0]], c)
+	}
+
+	var suggestions []Suggestion
+	for key, issueCommits := range byIssue {
+		sort.Slice(issueCommits, func(i, j int) bool {
+			return issueCommits[i].Time.Before(issueCommits[j].Time)
+		})
+
+		block := []Commit{issueCommits[0]}
+		for _, c := range issueCommits[1:] {
+			if c.Time.Sub(block[len(block)-1].Time) > opts.MaxGap {
+				suggestions = append(suggestions, newSuggestion(key, block, opts))
+				block = nil
+			}
+			block = append(block, c)
+		}
+		suggestions = append(suggestions, newSuggestion(key, block, opts))
+	}
+
+	sort.Slice(suggestions, func(i, j int) bool {
+		return suggestions[i].Started.Before(suggestions[j].Started)
+	})
+
+	return suggestions
+}
+
+// newSuggestion builds a suggestion from a block of commits sorted by time
+func newSuggestion(key string, block []Commit, opts EstimateOptions) Suggestion {
+	started := block[0].Time.Add(-opts.LeadTime)
+	duration := block[len(block)-1].Time.Sub(started)
+	if duration < opts.MinimumLen {
+		duration = opts.MinimumLen
+	}
+
+	return Suggestion{
+		IssueKey:         key,
+		Started:          started,
+		TimeSpentSeconds: timeparse.RoundSeconds(int(duration.Seconds())),
+		Commits:          block,
+	}
+}
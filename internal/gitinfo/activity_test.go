@@ -0,0 +1,117 @@
+package gitinfo
+
+import (
+	"os"
+	"os/exec"
+	"testing"
+	"time"
+)
+
+func TestEstimateBlocks(t *testing.T) {
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return day.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute)
+	}
+
+	commits := []Commit{
+		{Hash: "a", Time: at(9, 40), IssueKeys: []string{"PROJ-1"}},
+		{Hash: "b", Time: at(11, 0), IssueKeys: []string{"PROJ-1"}},
+		{Hash: "c", Time: at(16, 0), IssueKeys: []string{"PROJ-1"}}, // more than 2h later: new block
+		{Hash: "d", Time: at(13, 0), IssueKeys: []string{"PROJ-2", "PROJ-1"}},
+		{Hash: "e", Time: at(14, 0)}, // no issue key
+	}
+
+	suggestions := EstimateBlocks(commits, DefaultEstimateOptions())
+	if len(suggestions) != 3 {
+		t.Fatalf("expected 3 suggestions, got %d: %+v", len(suggestions), suggestions)
+	}
+
+	expected := []struct {
+		key     string
+		started time.Time
+		seconds int
+		commits int
+	}{
+		{"PROJ-1", at(9, 10), 110 * 60, 2}, // 09:10 -> 11:00
+		{"PROJ-2", at(12, 30), 30 * 60, 1}, // lead time only
+		{"PROJ-1", at(15, 30), 30 * 60, 1},
+	}
+
+	for i, exp := range expected {
+		got := suggestions[i]
+		if got.IssueKey != exp.key {
+			t.Errorf("suggestion %d: expected key %s, got %s", i, exp.key, got.IssueKey)
+		}
+		if !got.Started.Equal(exp.started) {
+			t.Errorf("suggestion %d: expected start %s, got %s", i, exp.started, got.Started)
+		}
+		if got.TimeSpentSeconds != exp.seconds {
+			t.Errorf("suggestion %d: expected %d seconds, got %d", i, exp.seconds, got.TimeSpentSeconds)
+		}
+		if len(got.Commits) != exp.commits {
+			t.Errorf("suggestion %d: expected %d commits, got %d", i, exp.commits, len(got.Commits))
+		}
+	}
+}
+
+func TestEstimateBlocks_MinimumLength(t *testing.T) {
+	opts := EstimateOptions{MaxGap: time.Hour, LeadTime: 0, MinimumLen: 15 * time.Minute}
+	commits := []Commit{{Time: time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC), IssueKeys: []string{"PROJ-1"}}}
+
+	suggestions := EstimateBlocks(commits, opts)
+	if len(suggestions) != 1 || suggestions[0].TimeSpentSeconds != 15*60 {
+		t.Errorf("expected a single 15m suggestion, got %+v", suggestions)
+	}
+}
+
+func TestAuthorCommits(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git := func(date string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	git("", "init", "-q")
+	git("", "config", "user.email", "dev@example.com")
+	git("", "config", "user.name", "Dev")
+	git("", "checkout", "-q", "-b", "feature/PROJ-5-search")
+	git("2025-01-14T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "Day before")
+	git("2025-01-15T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "Add search index")
+	git("2025-01-15T11:00:00Z", "commit", "-q", "--allow-empty", "-m", "PROJ-6 tweak ranking")
+	git("", "config", "user.email", "someone-else@example.com")
+	git("2025-01-15T12:00:00Z", "commit", "-q", "--allow-empty", "-m", "Not mine")
+
+	email := "dev@example.com"
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	commits, err := AuthorCommits(dir, email, "PROJ", from, from.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(commits) != 2 {
+		t.Fatalf("expected 2 commits, got %d: %+v", len(commits), commits)
+	}
+
+	for _, c := range commits {
+		switch c.Subject {
+		case "Add search index":
+			if len(c.IssueKeys) != 1 || c.IssueKeys[0] != "PROJ-5" {
+				t.Errorf("expected branch key PROJ-5, got %v", c.IssueKeys)
+			}
+		case "PROJ-6 tweak ranking":
+			if len(c.IssueKeys) != 2 || c.IssueKeys[0] != "PROJ-6" {
+				t.Errorf("expected message key first, got %v", c.IssueKeys)
+			}
+		default:
+			t.Errorf("unexpected commit %q", c.Subject)
+		}
+	}
+}
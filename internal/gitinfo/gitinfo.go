@@ -0,0 +1,123 @@
+package gitinfo
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/rs/zerolog/log"
+)
+
+// defaultCommitLimit is the number of recent commits scanned for issue keys
+const defaultCommitLimit = 20
+
+// genericKeyPattern matches any Jira-style issue key when no project key is configured
+const genericKeyPattern = `[A-Za-z][A-Za-z0-9_]*-[0-9]+`
+
+// IssueKeys contains issue keys detected in a git repository
+type IssueKeys struct {
+	Branch  string   // Current branch name
+	FromRef []string // Keys found in the current branch name
+	FromLog []string // Keys found in recent commit messages (excluding branch keys)
+}
+
+// All returns all detected keys, branch keys first, without duplicates
+func (k *IssueKeys) All() []string {
+	return uniqueKeys(append(append([]string{}, k.FromRef...), k.FromLog...))
+}
+
+// Detect inspects the git repository at dir and extracts issue keys for the given project
+// from the current branch name and recent commit messages
+// Returns an error if dir is not inside a git repository
+func Detect(dir, projectKey string) (*IssueKeys, error) {
+	branch, err := runGit(dir, "rev-parse", "--abbrev-ref", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read current branch: %w", err)
+	}
+
+	keys := &IssueKeys{
+		Branch:  branch,
+		FromRef: ExtractIssueKeys(branch, projectKey),
+	}
+
+	messages, err := runGit(dir, "log", "-n", fmt.Sprint(defaultCommitLimit), "--format=%s")
+	if err != nil {
+		// A new repository without commits has a branch but no log
+		log.Debug().Err(err).Msg("Failed to read git log")
+		return keys, nil
+	}
+
+	for _, key := range ExtractIssueKeys(messages, projectKey) {
+		if !containsKey(keys.FromRef, key) {
+			keys.FromLog = append(keys.FromLog, key)
+		}
+	}
+
+	log.Debug().
+		Str("branch", branch).
+		Strs("branch_keys", keys.FromRef).
+		Strs("commit_keys", keys.FromLog).
+		Msg("Detected issue keys from git")
+
+	return keys, nil
+}
+
+// ExtractIssueKeys returns the unique issue keys found in text, in order of appearance
+// Keys are matched case-insensitively and returned in upper case
+// If projectKey is empty, keys from any project are matched
+func ExtractIssueKeys(text, projectKey string) []string {
+	pattern := genericKeyPattern
+	if projectKey != "" {
+		pattern = regexp.QuoteMeta(projectKey) + `-[0-9]+`
+	}
+	re := regexp.MustCompile(`(?i)` + pattern)
+
+	var keys []string
+	for _, loc := range re.FindAllStringIndex(text, -1) {
+		// Reject matches glued to a preceding word (e.g., "XPROJ-1")
+		if loc[0] > 0 && isAlphanumeric(text[loc[0]-1]) {
+			continue
+		}
+		keys = append(keys, strings.ToUpper(text[loc[0]:loc[1]]))
+	}
+	return uniqueKeys(keys)
+}
+
+// runGit runs a git command in dir and returns its trimmed output
+func runGit(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// uniqueKeys removes duplicate keys while preserving order
+func uniqueKeys(keys []string) []string {
+	var unique []string
+	for _, key := range keys {
+		if !containsKey(unique, key) {
+			unique = append(unique, key)
+		}
+	}
+	return unique
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
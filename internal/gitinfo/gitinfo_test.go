@@ -0,0 +1,92 @@
+package gitinfo
+
+import (
+	"os/exec"
+	"reflect"
+	"testing"
+)
+
+func TestExtractIssueKeys(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       string
+		projectKey string
+		expected   []string
+	}{
+		{"branch name", "feature/PROJ-123-login-fix", "PROJ", []string{"PROJ-123"}},
+		{"lowercase branch", "bugfix/proj-42-crash", "PROJ", []string{"PROJ-42"}},
+		{"other project ignored", "feature/OTHER-1-thing", "PROJ", nil},
+		{"multiple keys", "PROJ-1 PROJ-2, fixes PROJ-1", "PROJ", []string{"PROJ-1", "PROJ-2"}},
+		{"glued prefix ignored", "XPROJ-9 and PROJ-10", "PROJ", []string{"PROJ-10"}},
+		{"any project without key", "ABC-1: fix\nDEF-22 tweak", "", []string{"ABC-1", "DEF-22"}},
+		{"no keys", "main", "PROJ", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractIssueKeys(tt.text, tt.projectKey)
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestIssueKeys_All(t *testing.T) {
+	keys := &IssueKeys{
+		FromRef: []string{"PROJ-1"},
+		FromLog: []string{"PROJ-2", "PROJ-1", "PROJ-3"},
+	}
+
+	expected := []string{"PROJ-1", "PROJ-2", "PROJ-3"}
+	if got := keys.All(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestDetect(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v: %s", args, err, out)
+		}
+	}
+
+	git("init", "-q")
+	git("config", "user.email", "dev@example.com")
+	git("config", "user.name", "Dev")
+	git("checkout", "-q", "-b", "feature/PROJ-123-login-fix")
+	git("commit", "-q", "--allow-empty", "-m", "PROJ-7 groundwork")
+	git("commit", "-q", "--allow-empty", "-m", "PROJ-123 login fix")
+
+	keys, err := Detect(dir, "PROJ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys.Branch != "feature/PROJ-123-login-fix" {
+		t.Errorf("unexpected branch %q", keys.Branch)
+	}
+	if !reflect.DeepEqual(keys.FromRef, []string{"PROJ-123"}) {
+		t.Errorf("unexpected branch keys %v", keys.FromRef)
+	}
+	if !reflect.DeepEqual(keys.FromLog, []string{"PROJ-7"}) {
+		t.Errorf("unexpected commit keys %v", keys.FromLog)
+	}
+}
+
+func TestDetect_NotARepository(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+
+	if _, err := Detect(t.TempDir(), "PROJ"); err == nil {
+		t.Error("expected error outside a git repository")
+	}
+}
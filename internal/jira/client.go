@@ -2,37 +2,115 @@ package jira
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
+// Flavor is the kind of Jira deployment a client talks to
+type Flavor string
+
+const (
+	FlavorCloud      Flavor = "cloud"      // Jira Cloud: REST API v3, basic auth with email and API token, ADF comments
+	FlavorDataCenter Flavor = "datacenter" // Jira Data Center / Server: REST API v2, Personal Access Token, plain-text comments
+)
+
 // Client represents a Jira API client
 type Client struct {
 	baseURL    string
 	username   string
 	apiToken   string
 	projectKey string
+	flavor     Flavor
+	tokens     TokenSource // Set for OAuth clients
 	httpClient *http.Client
 }
 
-// NewClient creates a new Jira API client
+// APIError is returned when Jira answers a request with a non-2xx status
+type APIError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// IsNotFound reports whether err is a Jira 404, e.g. an issue that does not exist or is not visible
+func IsNotFound(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsUnreachable reports whether err means Jira could not be reached: a network failure or a server error
+func IsUnreachable(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return true
+	}
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= http.StatusInternalServerError
+}
+
+// TokenSource provides OAuth access tokens
+type TokenSource interface {
+	AccessToken() (string, error)
+}
+
+// oauthAPIURL is the gateway for OAuth requests, followed by the site's cloud ID
+const oauthAPIURL = "https://api.atlassian.com/ex/jira/"
+
+// NewClient creates a new Jira Cloud API client
 func NewClient(baseURL, username, apiToken, projectKey string) *Client {
 	return &Client{
 		baseURL:    strings.TrimSuffix(baseURL, "/"),
 		username:   username,
 		apiToken:   apiToken,
 		projectKey: projectKey,
+		flavor:     FlavorCloud,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
 	}
 }
 
+// NewDataCenterClient creates a new Jira Data Center / Server API client
+// authenticating with a Personal Access Token
+func NewDataCenterClient(baseURL, personalAccessToken, projectKey string) *Client {
+	client := NewClient(baseURL, "", personalAccessToken, projectKey)
+	client.flavor = FlavorDataCenter
+	return client
+}
+
+// NewOAuthClient creates a new Jira Cloud API client authenticating with OAuth 2.0 access tokens
+// OAuth requests go through api.atlassian.com, addressed by the site's cloud ID
+func NewOAuthClient(cloudID string, tokens TokenSource, projectKey string) *Client {
+	client := NewClient(oauthAPIURL+cloudID, "", "", projectKey)
+	client.tokens = tokens
+	return client
+}
+
+// Flavor returns the kind of Jira deployment the client talks to
+func (c *Client) Flavor() Flavor {
+	return c.flavor
+}
+
+// apiURL returns the REST API base URL for the client's flavor
+func (c *Client) apiURL() string {
+	if c.flavor == FlavorDataCenter {
+		return c.baseURL + "/rest/api/2"
+	}
+	return c.baseURL + "/rest/api/3"
+}
+
 // Issue represents a Jira issue
 type Issue struct {
 	ID     string      `json:"id"` // Numeric ID as string
@@ -42,15 +120,38 @@ type Issue struct {
 
 // IssueFields represents Jira issue fields
 type IssueFields struct {
-	Summary  string       `json:"summary"`
-	Status   IssueStatus  `json:"status"`
-	Assignee *IssueUser   `json:"assignee"`
-	Worklog  *WorklogList `json:"worklog,omitempty"`
+	Summary      string             `json:"summary"`
+	Status       IssueStatus        `json:"status"`
+	Assignee     *IssueUser         `json:"assignee"`
+	Project      *IssueProject      `json:"project,omitempty"`
+	TimeTracking *IssueTimeTracking `json:"timetracking,omitempty"`
+	Worklog      *WorklogList       `json:"worklog,omitempty"`
 }
 
-// WorklogList represents the worklog field in issue response
+// IssueTimeTracking represents the estimates and time spent of an issue
+// Durations are in Jira's format (e.g., "1d 4h"); fields are empty when not set
+type IssueTimeTracking struct {
+	OriginalEstimate         string `json:"originalEstimate,omitempty"`
+	RemainingEstimate        string `json:"remainingEstimate,omitempty"`
+	TimeSpent                string `json:"timeSpent,omitempty"`
+	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds,omitempty"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds,omitempty"`
+	TimeSpentSeconds         int    `json:"timeSpentSeconds,omitempty"`
+}
+
+// WorklogList represents the worklog field in issue response and a page of issue worklogs
+// Jira embeds at most 20 worklogs in issue fields; Total tells whether the list is complete
 type WorklogList struct {
-	Worklogs []Worklog `json:"worklogs"`
+	StartAt    int       `json:"startAt"`
+	MaxResults int       `json:"maxResults"`
+	Total      int       `json:"total"`
+	Worklogs   []Worklog `json:"worklogs"`
+}
+
+// IssueProject represents the project of a Jira issue
+type IssueProject struct {
+	ID  string `json:"id"` // Numeric ID as string
+	Key string `json:"key"`
 }
 
 // IssueStatus represents Jira issue status
@@ -61,20 +162,46 @@ type IssueStatus struct {
 // IssueUser represents a Jira user
 type IssueUser struct {
 	AccountID    string `json:"accountId"`
+	Key          string `json:"key,omitempty"` // Data Center user key (Data Center users have no account ID)
 	DisplayName  string `json:"displayName"`
 	EmailAddress string `json:"emailAddress"`
 }
 
-// SearchResult represents Jira search results
+// ID returns the identifier that matches the user across worklogs: the account ID on Cloud, the user key on Data Center
+func (u IssueUser) ID() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	return u.Key
+}
+
+// SearchResult represents a page of Jira search results
 type SearchResult struct {
-	Issues []Issue `json:"issues"`
-	Total  int     `json:"total"`
+	Issues        []Issue `json:"issues"`
+	StartAt       int     `json:"startAt,omitempty"` // Data Center only
+	Total         int     `json:"total"`
+	NextPageToken string  `json:"nextPageToken,omitempty"`
+	IsLast        bool    `json:"isLast,omitempty"`
 }
 
+const (
+	// searchPageSize is the number of issues requested per search page
+	searchPageSize = 100
+	// searchResultLimit caps free-text search results shown for selection
+	searchResultLimit = 100
+	// worklogPageSize is the number of worklogs requested per page
+	worklogPageSize = 1000
+	// maxPages guards against servers that never report the last page
+	maxPages = 100
+	// jiraTimeFormat is the timestamp format used by Jira worklogs
+	jiraTimeFormat = "2006-01-02T15:04:05.000-0700"
+)
+
 // Worklog represents a Jira worklog entry
 type Worklog struct {
 	ID               string          `json:"id,omitempty"`
 	IssueID          string          `json:"issueId,omitempty"`
+	IssueKey         string          `json:"issueKey,omitempty"` // Not returned by Jira, filled in by GetWorklogs
 	TimeSpent        string          `json:"timeSpent"`
 	TimeSpentSeconds int             `json:"timeSpentSeconds"`
 	Started          string          `json:"started"` // Format: 2024-11-11T10:00:00.000+0000
@@ -110,29 +237,20 @@ func (c *Client) GetInProgressIssues(statuses []string) ([]Issue, error) {
 	}
 	jql = fmt.Sprintf("%s ORDER BY updated DESC", jql)
 
-	// Use POST method with JSON body as recommended by Jira API v3
-	endpoint := fmt.Sprintf("%s/rest/api/3/search/jql", c.baseURL)
-
-	payload := map[string]interface{}{
-		"jql":        jql,
-		"fields":     []string{"summary", "status", "assignee"},
-		"maxResults": 50,
-	}
-
-	var result SearchResult
-	if err := c.doRequest("POST", endpoint, payload, &result); err != nil {
+	issues, err := c.searchAll(jql, []string{"summary", "status", "assignee", "timetracking"}, 0)
+	if err != nil {
 		return nil, fmt.Errorf("failed to fetch in-progress issues: %w", err)
 	}
 
-	log.Debug().Int("count", len(result.Issues)).Msg("Retrieved in-progress issues")
-	return result.Issues, nil
+	log.Debug().Int("count", len(issues)).Msg("Retrieved in-progress issues")
+	return issues, nil
 }
 
 // GetIssue retrieves a specific issue by key
 func (c *Client) GetIssue(issueKey string) (*Issue, error) {
 	log.Debug().Str("key", issueKey).Msg("Fetching issue")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/issue/%s?fields=summary,status,assignee", c.baseURL, issueKey)
+	endpoint := fmt.Sprintf("%s/issue/%s?fields=summary,status,assignee,project,timetracking", c.apiURL(), issueKey)
 
 	var issue Issue
 	if err := c.doRequest("GET", endpoint, nil, &issue); err != nil {
@@ -142,6 +260,26 @@ func (c *Client) GetIssue(issueKey string) (*Issue, error) {
 	return &issue, nil
 }
 
+// GetIssuesByID retrieves issues by their numeric IDs with a single search
+// Issues that do not exist or are not visible are left out
+func (c *Client) GetIssuesByID(ids []int64) ([]Issue, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	log.Debug().Int("count", len(ids)).Msg("Fetching issues by ID")
+
+	idList := make([]string, len(ids))
+	for i, id := range ids {
+		idList[i] = strconv.FormatInt(id, 10)
+	}
+
+	issues, err := c.searchAll(fmt.Sprintf("id in (%s)", strings.Join(idList, ", ")), []string{"summary", "status", "project"}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch issues: %w", err)
+	}
+	return issues, nil
+}
+
 // SearchIssues searches for issues by key or text
 func (c *Client) SearchIssues(searchKey string) ([]Issue, error) {
 	log.Debug().Str("search", searchKey).Msg("Searching issues")
@@ -161,35 +299,164 @@ func (c *Client) SearchIssues(searchKey string) ([]Issue, error) {
 	}
 	jql = fmt.Sprintf("%s ORDER BY updated DESC", jql)
 
+	issues, err := c.searchAll(jql, []string{"summary", "status", "assignee"}, searchResultLimit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues: %w", err)
+	}
+
+	log.Debug().Int("count", len(issues)).Msg("Retrieved search results")
+	return issues, nil
+}
+
+// searchAll runs a JQL search and follows nextPageToken until all results are fetched
+// A limit of 0 fetches all pages; otherwise at most limit issues are returned
+func (c *Client) searchAll(jql string, fields []string, limit int) ([]Issue, error) {
+	if c.flavor == FlavorDataCenter {
+		return c.searchAllByOffset(jql, fields, limit)
+	}
+
 	// Use POST method with JSON body as recommended by Jira API v3
-	endpoint := fmt.Sprintf("%s/rest/api/3/search/jql", c.baseURL)
+	endpoint := fmt.Sprintf("%s/search/jql", c.apiURL())
+
+	var issues []Issue
+	pageToken := ""
+	for page := 0; page < maxPages; page++ {
+		pageSize := searchPageSize
+		if limit > 0 && limit-len(issues) < pageSize {
+			pageSize = limit - len(issues)
+		}
 
-	payload := map[string]interface{}{
-		"jql":        jql,
-		"fields":     []string{"summary", "status", "assignee"},
-		"maxResults": 20,
+		payload := map[string]interface{}{
+			"jql":        jql,
+			"fields":     fields,
+			"maxResults": pageSize,
+		}
+		if pageToken != "" {
+			payload["nextPageToken"] = pageToken
+		}
+
+		var result SearchResult
+		if err := c.doRequest("POST", endpoint, payload, &result); err != nil {
+			return nil, err
+		}
+		issues = append(issues, result.Issues...)
+
+		log.Debug().
+			Int("page", page+1).
+			Int("page_count", len(result.Issues)).
+			Int("total_count", len(issues)).
+			Msg("Retrieved search page")
+
+		if result.IsLast || result.NextPageToken == "" || len(result.Issues) == 0 {
+			return issues, nil
+		}
+		if limit > 0 && len(issues) >= limit {
+			return issues[:limit], nil
+		}
+		pageToken = result.NextPageToken
 	}
 
-	var result SearchResult
-	if err := c.doRequest("POST", endpoint, payload, &result); err != nil {
-		return nil, fmt.Errorf("failed to search issues: %w", err)
+	log.Warn().Int("pages", maxPages).Str("jql", jql).Msg("Search stopped after maximum number of pages")
+	return issues, nil
+}
+
+// searchAllByOffset runs a JQL search on the v2 search endpoint, paging with startAt
+// Data Center has no token-based search; limit behaves as in searchAll
+func (c *Client) searchAllByOffset(jql string, fields []string, limit int) ([]Issue, error) {
+	endpoint := fmt.Sprintf("%s/search", c.apiURL())
+
+	var issues []Issue
+	for page := 0; page < maxPages; page++ {
+		pageSize := searchPageSize
+		if limit > 0 && limit-len(issues) < pageSize {
+			pageSize = limit - len(issues)
+		}
+
+		payload := map[string]interface{}{
+			"jql":        jql,
+			"fields":     fields,
+			"startAt":    len(issues),
+			"maxResults": pageSize,
+		}
+
+		var result SearchResult
+		if err := c.doRequest("POST", endpoint, payload, &result); err != nil {
+			return nil, err
+		}
+		issues = append(issues, result.Issues...)
+
+		log.Debug().
+			Int("page", page+1).
+			Int("page_count", len(result.Issues)).
+			Int("total_count", len(issues)).
+			Msg("Retrieved search page")
+
+		if len(result.Issues) == 0 || len(issues) >= result.Total {
+			return issues, nil
+		}
+		if limit > 0 && len(issues) >= limit {
+			return issues[:limit], nil
+		}
 	}
 
-	log.Debug().Int("count", len(result.Issues)).Msg("Retrieved search results")
-	return result.Issues, nil
+	log.Warn().Int("pages", maxPages).Str("jql", jql).Msg("Search stopped after maximum number of pages")
+	return issues, nil
+}
+
+// WorklogOptions controls how adding a worklog changes the issue's remaining estimate
+// The zero value lets Jira reduce the remaining estimate by the time spent ("auto")
+type WorklogOptions struct {
+	AdjustEstimate string // "auto", "new", "leave" or "manual"
+	Estimate       string // New remaining estimate for "new", amount to reduce by for "manual" (e.g., "2h")
+}
+
+// Validate checks that the adjustment is known and has the estimate it needs
+func (o WorklogOptions) Validate() error {
+	switch o.AdjustEstimate {
+	case "", "auto", "leave":
+		return nil
+	case "new", "manual":
+		if o.Estimate == "" {
+			return fmt.Errorf("adjust estimate %s requires an estimate", o.AdjustEstimate)
+		}
+		return nil
+	default:
+		return fmt.Errorf("invalid adjust estimate %q, use auto, new, leave or manual", o.AdjustEstimate)
+	}
+}
+
+// query returns the worklog endpoint query parameters for the options
+func (o WorklogOptions) query() string {
+	if o.AdjustEstimate == "" {
+		return ""
+	}
+	params := url.Values{}
+	params.Set("adjustEstimate", o.AdjustEstimate)
+	switch o.AdjustEstimate {
+	case "new":
+		params.Set("newEstimate", o.Estimate)
+	case "manual":
+		params.Set("reduceBy", o.Estimate)
+	}
+	return "?" + params.Encode()
 }
 
 // AddWorklog adds a worklog entry to an issue
-func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.Time, comment string) (*Worklog, error) {
+func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.Time, comment string, opts WorklogOptions) (*Worklog, error) {
 	log.Debug().
 		Str("issue", issueKey).
 		Int("seconds", timeSpentSeconds).
+		Str("adjust_estimate", opts.AdjustEstimate).
 		Msg("Adding worklog")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/issue/%s/worklog", c.baseURL, issueKey)
+	if err := opts.Validate(); err != nil {
+		return nil, err
+	}
+
+	endpoint := fmt.Sprintf("%s/issue/%s/worklog%s", c.apiURL(), issueKey, opts.query())
 
 	// Format started time in Jira format
-	startedStr := started.Format("2006-01-02T15:04:05.000-0700")
+	startedStr := started.Format(jiraTimeFormat)
 
 	payload := map[string]interface{}{
 		"timeSpentSeconds": timeSpentSeconds,
@@ -197,21 +464,7 @@ func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.
 	}
 
 	if comment != "" {
-		payload["comment"] = map[string]interface{}{
-			"type":    "doc",
-			"version": 1,
-			"content": []map[string]interface{}{
-				{
-					"type": "paragraph",
-					"content": []map[string]interface{}{
-						{
-							"type": "text",
-							"text": comment,
-						},
-					},
-				},
-			},
-		}
+		payload["comment"] = c.commentBody(comment)
 	}
 
 	var worklog Worklog
@@ -227,89 +480,297 @@ func (c *Client) AddWorklog(issueKey string, timeSpentSeconds int, started time.
 	return &worklog, nil
 }
 
-// GetTodayWorklogs retrieves today's worklogs for the current user
-func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
-	log.Debug().Msg("Fetching today's worklogs")
+// UpdateWorklog replaces the time spent, start time and comment of an existing worklog
+func (c *Client) UpdateWorklog(issueKey, worklogID string, timeSpentSeconds int, started time.Time, comment string) (*Worklog, error) {
+	log.Debug().
+		Str("issue", issueKey).
+		Str("worklog_id", worklogID).
+		Int("seconds", timeSpentSeconds).
+		Msg("Updating worklog")
 
-	// Get issues updated recently - JQL worklogDate filter may not be reliable
-	jql := "assignee = currentUser() AND updated >= -7d"
-	if c.projectKey != "" {
-		jql = fmt.Sprintf("%s AND project = %s", jql, c.projectKey)
+	endpoint := fmt.Sprintf("%s/issue/%s/worklog/%s", c.apiURL(), issueKey, worklogID)
+
+	payload := map[string]interface{}{
+		"timeSpentSeconds": timeSpentSeconds,
+		"started":          started.Format(jiraTimeFormat),
 	}
-	jql = fmt.Sprintf("%s ORDER BY updated DESC", jql)
 
-	log.Debug().Str("jql", jql).Msg("Using JQL query")
+	// An empty comment clears the existing one
+	payload["comment"] = c.commentBody(comment)
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/search/jql", c.baseURL)
-	payload := map[string]interface{}{
-		"jql":        jql,
-		"fields":     []string{"worklog", "summary", "key"},
-		"maxResults": 100,
+	var worklog Worklog
+	if err := c.doRequest("PUT", endpoint, payload, &worklog); err != nil {
+		return nil, fmt.Errorf("failed to update worklog: %w", err)
 	}
 
-	var result SearchResult
-	if err := c.doRequest("POST", endpoint, payload, &result); err != nil {
-		return nil, fmt.Errorf("failed to fetch today's issues: %w", err)
+	log.Info().
+		Str("issue", issueKey).
+		Str("worklog_id", worklogID).
+		Str("time", formatSeconds(timeSpentSeconds)).
+		Msg("Worklog updated successfully")
+
+	return &worklog, nil
+}
+
+// DeleteWorklog deletes a worklog from an issue
+func (c *Client) DeleteWorklog(issueKey, worklogID string) error {
+	log.Debug().
+		Str("issue", issueKey).
+		Str("worklog_id", worklogID).
+		Msg("Deleting worklog")
+
+	endpoint := fmt.Sprintf("%s/issue/%s/worklog/%s", c.apiURL(), issueKey, worklogID)
+
+	if err := c.doRequest("DELETE", endpoint, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete worklog: %w", err)
 	}
 
+	log.Info().
+		Str("issue", issueKey).
+		Str("worklog_id", worklogID).
+		Msg("Worklog deleted successfully")
+
+	return nil
+}
+
+// SetWorklogProperty stores a JSON value as an entity property of a worklog
+func (c *Client) SetWorklogProperty(issueKey, worklogID, propertyKey string, value interface{}) error {
 	log.Debug().
-		Int("total_issues", result.Total).
-		Int("returned_issues", len(result.Issues)).
-		Msg("Search result")
+		Str("issue", issueKey).
+		Str("worklog_id", worklogID).
+		Str("property", propertyKey).
+		Msg("Setting worklog property")
+
+	endpoint := fmt.Sprintf("%s/issue/%s/worklog/%s/properties/%s", c.apiURL(), issueKey, worklogID, url.PathEscape(propertyKey))
+
+	if err := c.doRequest("PUT", endpoint, value, nil); err != nil {
+		return fmt.Errorf("failed to set worklog property: %w", err)
+	}
+	return nil
+}
+
+// Transition represents a workflow transition available on an issue
+type Transition struct {
+	ID   string      `json:"id"`
+	Name string      `json:"name"` // e.g., "Start Progress"
+	To   IssueStatus `json:"to"`   // Status the issue moves to
+}
+
+// GetTransitions retrieves the transitions the current user can perform on an issue
+func (c *Client) GetTransitions(issueKey string) ([]Transition, error) {
+	log.Debug().Str("issue", issueKey).Msg("Fetching transitions")
+
+	endpoint := fmt.Sprintf("%s/issue/%s/transitions", c.apiURL(), issueKey)
+
+	var response struct {
+		Transitions []Transition `json:"transitions"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch transitions of %s: %w", issueKey, err)
+	}
+	return response.Transitions, nil
+}
+
+// TransitionIssue performs a workflow transition on an issue
+func (c *Client) TransitionIssue(issueKey, transitionID string) error {
+	log.Debug().
+		Str("issue", issueKey).
+		Str("transition", transitionID).
+		Msg("Transitioning issue")
+
+	endpoint := fmt.Sprintf("%s/issue/%s/transitions", c.apiURL(), issueKey)
+
+	payload := map[string]interface{}{
+		"transition": map[string]string{"id": transitionID},
+	}
+	if err := c.doRequest("POST", endpoint, payload, nil); err != nil {
+		return fmt.Errorf("failed to transition %s: %w", issueKey, err)
+	}
+
+	log.Info().Str("issue", issueKey).Str("transition", transitionID).Msg("Issue transitioned successfully")
+	return nil
+}
+
+// commentBody encodes a worklog comment for the client's flavor
+// Data Center takes plain text; Cloud takes an Atlassian Document Format document
+func (c *Client) commentBody(text string) interface{} {
+	if c.flavor == FlavorDataCenter {
+		return text
+	}
+	if text == "" {
+		return map[string]interface{}{
+			"type":    "doc",
+			"version": 1,
+			"content": []map[string]interface{}{},
+		}
+	}
+	return adfComment(text)
+}
+
+// adfComment wraps plain text in an Atlassian Document Format document
+func adfComment(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": []map[string]interface{}{
+			{
+				"type": "paragraph",
+				"content": []map[string]interface{}{
+					{
+						"type": "text",
+						"text": text,
+					},
+				},
+			},
+		},
+	}
+}
 
-	// Get current user to filter worklogs
+// GetTodayWorklogs retrieves today's worklogs for the current user
+func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
 	currentUser, err := c.GetCurrentUser()
 	if err != nil {
-		log.Warn().Err(err).Msg("Could not fetch current user, will include all worklogs")
+		return nil, err
 	}
 
-	// Extract worklogs from issues
-	worklogs := []Worklog{}
-	today := time.Now().Format("2006-01-02")
+	now := time.Now()
+	startOfDay := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return c.GetWorklogs(startOfDay, startOfDay.AddDate(0, 0, 1), currentUser.ID())
+}
 
-	for _, issue := range result.Issues {
-		log.Debug().
-			Str("issue_key", issue.Key).
-			Bool("has_worklog", issue.Fields.Worklog != nil).
-			Msg("Processing issue")
+// GetWorklogs retrieves the worklogs of an author started between from (inclusive) and to (exclusive)
+// authorID is the author's IssueUser.ID(); the author must be the current user
+// Issues are found with a worklogAuthor/worklogDate search, so they are found regardless of their assignee or age,
+// and each issue's worklogs are then fetched and filtered by author and start time
+func (c *Client) GetWorklogs(from, to time.Time, authorID string) ([]Worklog, error) {
+	log.Debug().
+		Time("from", from).
+		Time("to", to).
+		Str("author", authorID).
+		Msg("Fetching worklogs from Jira")
+
+	// worklogDate is a day in the Jira user's time zone; search a day wider on each side
+	// and filter on the exact start times below
+	jql := fmt.Sprintf(`worklogAuthor = currentUser() AND worklogDate >= "%s" AND worklogDate < "%s"`,
+		from.AddDate(0, 0, -1).Format("2006-01-02"),
+		to.AddDate(0, 0, 1).Format("2006-01-02"))
+	issues, err := c.searchAll(jql, []string{"summary"}, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search issues with worklogs: %w", err)
+	}
 
-		if issue.Fields.Worklog == nil {
-			continue
+	worklogs := []Worklog{}
+	for _, issue := range issues {
+		issueWorklogs, err := c.GetIssueWorklogs(issue.Key, from, to)
+		if err != nil {
+			return nil, err
 		}
 
-		log.Debug().
-			Str("issue_key", issue.Key).
-			Int("worklog_count", len(issue.Fields.Worklog.Worklogs)).
-			Msg("Issue has worklogs")
-
-		// Filter worklogs to only include today's entries by current user
-		for _, wl := range issue.Fields.Worklog.Worklogs {
-			// Check if this worklog is from today
-			isToday := strings.HasPrefix(wl.Started, today)
-
-			// Check if this worklog is by current user
-			isByCurrentUser := true
-			if currentUser != nil && wl.Author != nil {
-				isByCurrentUser = wl.Author.AccountID == currentUser.AccountID
+		for _, wl := range issueWorklogs {
+			if wl.Author == nil || wl.Author.ID() != authorID {
+				continue
 			}
-
-			log.Debug().
-				Str("issue_key", issue.Key).
-				Str("started", wl.Started).
-				Str("today", today).
-				Bool("is_today", isToday).
-				Bool("is_by_current_user", isByCurrentUser).
-				Msg("Checking worklog")
-
-			if isToday && isByCurrentUser {
-				// Add issue context to worklog
+			started, err := wl.StartedTime()
+			if err != nil {
+				log.Debug().Err(err).Str("worklog_id", wl.ID).Msg("Skipping worklog with invalid start time")
+				continue
+			}
+			if started.Before(from) || !started.Before(to) {
+				continue
+			}
+			wl.IssueKey = issue.Key
+			if wl.IssueID == "" {
 				wl.IssueID = issue.ID
-				worklogs = append(worklogs, wl)
 			}
+			worklogs = append(worklogs, wl)
 		}
 	}
 
-	log.Debug().Int("count", len(worklogs)).Msg("Retrieved today's worklogs")
+	sort.Slice(worklogs, func(i, j int) bool {
+		a, _ := worklogs[i].StartedTime()
+		b, _ := worklogs[j].StartedTime()
+		return a.Before(b)
+	})
+
+	log.Debug().Int("count", len(worklogs)).Msg("Retrieved worklogs from Jira")
+	return worklogs, nil
+}
+
+// StartedTime parses the start time of the worklog
+func (w Worklog) StartedTime() (time.Time, error) {
+	return time.Parse(jiraTimeFormat, w.Started)
+}
+
+// CommentText returns the plain text of the worklog comment
+// Comments are Atlassian Document Format documents on Jira Cloud and plain strings on older APIs
+func (w Worklog) CommentText() string {
+	if len(w.Comment) == 0 {
+		return ""
+	}
+
+	var plain string
+	if err := json.Unmarshal(w.Comment, &plain); err == nil {
+		return plain
+	}
+
+	var doc adfNode
+	if err := json.Unmarshal(w.Comment, &doc); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(doc.text())
+}
+
+// adfNode is a node of an Atlassian Document Format document
+type adfNode struct {
+	Type    string    `json:"type"`
+	Text    string    `json:"text"`
+	Content []adfNode `json:"content"`
+}
+
+// text returns the text of a node and its children, with paragraphs separated by newlines
+func (n adfNode) text() string {
+	var b strings.Builder
+	b.WriteString(n.Text)
+	for i, child := range n.Content {
+		if i > 0 && child.Type == "paragraph" {
+			b.WriteString("\n")
+		}
+		b.WriteString(child.text())
+	}
+	return b.String()
+}
+
+// GetIssueWorklogs retrieves the worklogs of an issue started at or after since and before until, paging through the results
+// A zero since or until leaves that end of the range open; servers ignoring the range return all worklogs
+func (c *Client) GetIssueWorklogs(issueKey string, since, until time.Time) ([]Worklog, error) {
+	log.Debug().Str("issue", issueKey).Time("since", since).Time("until", until).Msg("Fetching issue worklogs")
+
+	var worklogs []Worklog
+	for page := 0; page < maxPages; page++ {
+		params := url.Values{}
+		params.Set("startAt", strconv.Itoa(len(worklogs)))
+		params.Set("maxResults", strconv.Itoa(worklogPageSize))
+		if !since.IsZero() {
+			params.Set("startedAfter", strconv.FormatInt(since.UnixMilli()-1, 10))
+		}
+		if !until.IsZero() {
+			params.Set("startedBefore", strconv.FormatInt(until.UnixMilli(), 10))
+		}
+		endpoint := fmt.Sprintf("%s/issue/%s/worklog?%s", c.apiURL(), issueKey, params.Encode())
+
+		var result WorklogList
+		if err := c.doRequest("GET", endpoint, nil, &result); err != nil {
+			return nil, fmt.Errorf("failed to fetch worklogs for %s: %w", issueKey, err)
+		}
+		worklogs = append(worklogs, result.Worklogs...)
+
+		if len(result.Worklogs) == 0 || len(worklogs) >= result.Total {
+			log.Debug().Str("issue", issueKey).Int("count", len(worklogs)).Msg("Retrieved issue worklogs")
+			return worklogs, nil
+		}
+	}
+
+	log.Warn().Int("pages", maxPages).Str("issue", issueKey).Msg("Worklog listing stopped after maximum number of pages")
 	return worklogs, nil
 }
 
@@ -317,7 +778,7 @@ func (c *Client) GetTodayWorklogs() ([]Worklog, error) {
 func (c *Client) GetCurrentUser() (*IssueUser, error) {
 	log.Debug().Msg("Fetching current user information")
 
-	endpoint := fmt.Sprintf("%s/rest/api/3/myself", c.baseURL)
+	endpoint := fmt.Sprintf("%s/myself", c.apiURL())
 
 	var user IssueUser
 	if err := c.doRequest("GET", endpoint, nil, &user); err != nil {
@@ -348,7 +809,19 @@ func (c *Client) doRequest(method, url string, body interface{}, result interfac
 		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.SetBasicAuth(c.username, c.apiToken)
+	// OAuth and Data Center use bearer tokens, Cloud API tokens use basic auth with the email
+	switch {
+	case c.tokens != nil:
+		accessToken, err := c.tokens.AccessToken()
+		if err != nil {
+			return fmt.Errorf("failed to get access token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+	case c.flavor == FlavorDataCenter:
+		req.Header.Set("Authorization", "Bearer "+c.apiToken)
+	default:
+		req.SetBasicAuth(c.username, c.apiToken)
+	}
 	req.Header.Set("Content-Type", "application/json")
 	req.Header.Set("Accept", "application/json")
 
@@ -368,7 +841,7 @@ func (c *Client) doRequest(method, url string, body interface{}, result interfac
 			Int("status", resp.StatusCode).
 			Str("body", string(respBody)).
 			Msg("API request failed")
-		return fmt.Errorf("API request failed with status %d: %s", resp.StatusCode, string(respBody))
+		return &APIError{StatusCode: resp.StatusCode, Body: string(respBody)}
 	}
 
 	if result != nil {
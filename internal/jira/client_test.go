@@ -2,8 +2,10 @@ package jira
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 )
@@ -293,3 +295,586 @@ func TestGetInProgressIssues_SingleCustomStatus(t *testing.T) {
 		t.Errorf("expected issue key TEST-789, got %s", issues[0].Key)
 	}
 }
+
+func TestUpdateWorklog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/worklog/100" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != "PUT" {
+			t.Errorf("expected PUT request, got %s", r.Method)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if payload["timeSpentSeconds"] != float64(5400) {
+			t.Errorf("expected timeSpentSeconds 5400, got %v", payload["timeSpentSeconds"])
+		}
+		if _, ok := payload["comment"].(map[string]interface{}); !ok {
+			t.Errorf("expected ADF comment, got %v", payload["comment"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Worklog{ID: "100", TimeSpentSeconds: 5400})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	worklog, err := client.UpdateWorklog("TEST-1", "100", 5400, time.Now(), "Edited")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if worklog.ID != "100" || worklog.TimeSpentSeconds != 5400 {
+		t.Errorf("unexpected worklog: %+v", worklog)
+	}
+}
+
+func TestDeleteWorklog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/worklog/100" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.Method != "DELETE" {
+			t.Errorf("expected DELETE request, got %s", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	if err := client.DeleteWorklog("TEST-1", "100"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestSetWorklogProperty(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "PUT" || r.URL.Path != "/rest/api/3/issue/TEST-1/worklog/100/properties/tasklog" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if payload["label"] != "development" {
+			t.Errorf("expected label in property value, got %v", payload)
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	if err := client.SetWorklogProperty("TEST-1", "100", "tasklog", map[string]string{"label": "development"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestAddWorklog_AdjustEstimate(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  WorklogOptions
+		query string
+	}{
+		{"default", WorklogOptions{}, ""},
+		{"leave", WorklogOptions{AdjustEstimate: "leave"}, "adjustEstimate=leave"},
+		{"new", WorklogOptions{AdjustEstimate: "new", Estimate: "2h"}, "adjustEstimate=new&newEstimate=2h"},
+		{"manual", WorklogOptions{AdjustEstimate: "manual", Estimate: "30m"}, "adjustEstimate=manual&reduceBy=30m"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.RawQuery != tt.query {
+					t.Errorf("expected query %q, got %q", tt.query, r.URL.RawQuery)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				json.NewEncoder(w).Encode(Worklog{ID: "100"})
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "user@example.com", "token", "TEST")
+			if _, err := client.AddWorklog("TEST-1", 3600, time.Now(), "", tt.opts); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestWorklogOptions_Validate(t *testing.T) {
+	invalid := []WorklogOptions{
+		{AdjustEstimate: "new"},
+		{AdjustEstimate: "manual"},
+		{AdjustEstimate: "sometimes"},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", opts)
+		}
+	}
+	if err := (WorklogOptions{AdjustEstimate: "auto"}).Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestTransitions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/transitions" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		switch r.Method {
+		case "GET":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"transitions": []map[string]interface{}{
+					{"id": "11", "name": "Start Progress", "to": map[string]string{"name": "In Progress"}},
+				},
+			})
+		case "POST":
+			var payload map[string]map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if payload["transition"]["id"] != "11" {
+				t.Errorf("unexpected transition payload: %v", payload)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+
+	transitions, err := client.GetTransitions("TEST-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transitions) != 1 || transitions[0].To.Name != "In Progress" {
+		t.Fatalf("unexpected transitions: %+v", transitions)
+	}
+
+	if err := client.TransitionIssue("TEST-1", transitions[0].ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestGetIssue_Errors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/TEST-404"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/TEST-503"):
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+
+	_, err := client.GetIssue("TEST-404")
+	if !IsNotFound(err) || IsUnreachable(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if _, err := client.GetIssue("TEST-503"); !IsUnreachable(err) || IsNotFound(err) {
+		t.Errorf("expected an unreachable error for a server error, got %v", err)
+	}
+
+	server.Close()
+	if _, err := client.GetIssue("TEST-1"); !IsUnreachable(err) {
+		t.Errorf("expected an unreachable error when the server is down, got %v", err)
+	}
+}
+
+func TestSearchAll_FollowsNextPageToken(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		var response SearchResult
+		switch payload["nextPageToken"] {
+		case nil:
+			response = SearchResult{Issues: []Issue{{Key: "TEST-1"}, {Key: "TEST-2"}}, NextPageToken: "page2"}
+		case "page2":
+			response = SearchResult{Issues: []Issue{{Key: "TEST-3"}}, IsLast: true}
+		default:
+			t.Errorf("unexpected page token: %v", payload["nextPageToken"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issues, err := client.GetInProgressIssues(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+	if len(issues) != 3 || issues[2].Key != "TEST-3" {
+		t.Errorf("expected 3 issues across pages, got %v", issues)
+	}
+}
+
+func TestSearchAll_RespectsLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+
+		issues := make([]Issue, int(payload["maxResults"].(float64)))
+		for i := range issues {
+			issues[i] = Issue{Key: "TEST-1"}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SearchResult{Issues: issues, NextPageToken: "more"})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issues, err := client.searchAll("project = TEST", []string{"summary"}, 150)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 150 {
+		t.Errorf("expected 150 issues, got %d", len(issues))
+	}
+}
+
+func TestGetIssueWorklogs_Pages(t *testing.T) {
+	since := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/3/issue/TEST-1/worklog" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("startedAfter") != fmt.Sprint(since.UnixMilli()-1) {
+			t.Errorf("unexpected startedAfter: %s", r.URL.Query().Get("startedAfter"))
+		}
+		if r.URL.Query().Get("startedBefore") != "" {
+			t.Error("expected no startedBefore without an end")
+		}
+
+		var response WorklogList
+		switch r.URL.Query().Get("startAt") {
+		case "0":
+			response = WorklogList{StartAt: 0, Total: 3, Worklogs: []Worklog{{ID: "1"}, {ID: "2"}}}
+		case "2":
+			response = WorklogList{StartAt: 2, Total: 3, Worklogs: []Worklog{{ID: "3"}}}
+		default:
+			t.Errorf("unexpected startAt: %s", r.URL.Query().Get("startAt"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	worklogs, err := client.GetIssueWorklogs("TEST-1", since, time.Time{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(worklogs) != 3 || worklogs[2].ID != "3" {
+		t.Errorf("expected 3 worklogs across pages, got %v", worklogs)
+	}
+}
+
+// worklogServer is a Jira answering the worklog search with issues and each issue's worklogs
+func worklogServer(t *testing.T, issues []Issue, worklogs map[string][]Worklog) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/rest/api/3/search/jql" {
+			var payload map[string]interface{}
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			jql, _ := payload["jql"].(string)
+			if !strings.HasPrefix(jql, "worklogAuthor = currentUser() AND worklogDate >= ") {
+				t.Errorf("unexpected JQL: %s", jql)
+			}
+			json.NewEncoder(w).Encode(SearchResult{Issues: issues, IsLast: true})
+			return
+		}
+
+		for key, issueWorklogs := range worklogs {
+			if r.URL.Path == "/rest/api/3/issue/"+key+"/worklog" {
+				if r.URL.Query().Get("startedAfter") == "" || r.URL.Query().Get("startedBefore") == "" {
+					t.Errorf("expected the worklogs of %s to be fetched for the range, got %s", key, r.URL.RawQuery)
+				}
+				json.NewEncoder(w).Encode(WorklogList{Total: len(issueWorklogs), Worklogs: issueWorklogs})
+				return
+			}
+		}
+		t.Errorf("unexpected path: %s", r.URL.Path)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetWorklogs(t *testing.T) {
+	from := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 1)
+	me := &IssueUser{AccountID: "me"}
+
+	server := worklogServer(t,
+		[]Issue{{ID: "10", Key: "TEST-1"}, {ID: "20", Key: "TEST-2"}},
+		map[string][]Worklog{
+			"TEST-1": {
+				{ID: "1", IssueID: "10", Started: "2025-01-15T14:00:00.000+0000", TimeSpentSeconds: 1800, Author: me},
+				{ID: "3", IssueID: "10", Started: "2025-01-15T10:00:00.000+0000", TimeSpentSeconds: 600, Author: &IssueUser{AccountID: "someone-else"}},
+				{ID: "4", IssueID: "10", Started: "2025-01-16T10:00:00.000+0000", TimeSpentSeconds: 600, Author: me},
+			},
+			"TEST-2": {
+				{ID: "2", IssueID: "20", Started: "2025-01-15T09:00:00.000+0000", TimeSpentSeconds: 3600, Author: me,
+					Comment: json.RawMessage(`{"type":"doc","version":1,"content":[{"type":"paragraph","content":[{"type":"text","text":"Pairing"}]}]}`)},
+			},
+		})
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	worklogs, err := client.GetWorklogs(from, to, "me")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(worklogs) != 2 {
+		t.Fatalf("expected 2 worklogs, got %d: %v", len(worklogs), worklogs)
+	}
+
+	// Sorted by start time with issue keys filled in
+	if worklogs[0].ID != "2" || worklogs[0].IssueKey != "TEST-2" {
+		t.Errorf("unexpected first worklog: %+v", worklogs[0])
+	}
+	if worklogs[1].ID != "1" || worklogs[1].IssueKey != "TEST-1" {
+		t.Errorf("unexpected second worklog: %+v", worklogs[1])
+	}
+	if worklogs[0].CommentText() != "Pairing" {
+		t.Errorf("expected comment text 'Pairing', got %q", worklogs[0].CommentText())
+	}
+}
+
+func TestGetWorklogs_JustLogged(t *testing.T) {
+	now := time.Now()
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	me := &IssueUser{AccountID: "me"}
+
+	// Logged a few seconds ago, as right after 'tasklog log'
+	server := worklogServer(t,
+		[]Issue{{ID: "10", Key: "TEST-1"}},
+		map[string][]Worklog{
+			"TEST-1": {{ID: "1", IssueID: "10", Started: now.Add(-10 * time.Second).Format(jiraTimeFormat), TimeSpentSeconds: 1800, Author: me}},
+		})
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	worklogs, err := client.GetWorklogs(from, from.AddDate(0, 0, 1), "me")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(worklogs) != 1 || worklogs[0].ID != "1" {
+		t.Errorf("expected the worklog created less than a minute ago, got %v", worklogs)
+	}
+}
+
+func TestWorklog_CommentText(t *testing.T) {
+	tests := []struct {
+		name     string
+		comment  string
+		expected string
+	}{
+		{"empty", ``, ""},
+		{"plain string", `"Plain comment"`, "Plain comment"},
+		{"adf paragraphs", `{"type":"doc","content":[{"type":"paragraph","content":[{"type":"text","text":"One"}]},{"type":"paragraph","content":[{"type":"text","text":"Two"}]}]}`, "One\nTwo"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wl := Worklog{Comment: json.RawMessage(tt.comment)}
+			if got := wl.CommentText(); got != tt.expected {
+				t.Errorf("CommentText() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewDataCenterClient(t *testing.T) {
+	client := NewDataCenterClient("https://jira.example.com/", "pat123", "PROJ")
+
+	if client.Flavor() != FlavorDataCenter {
+		t.Errorf("expected datacenter flavor, got %s", client.Flavor())
+	}
+	if client.baseURL != "https://jira.example.com" {
+		t.Errorf("expected trailing slash to be trimmed, got %s", client.baseURL)
+	}
+	if client.apiURL() != "https://jira.example.com/rest/api/2" {
+		t.Errorf("expected REST API v2, got %s", client.apiURL())
+	}
+	if NewClient("https://example.atlassian.net", "user@example.com", "token", "PROJ").Flavor() != FlavorCloud {
+		t.Error("expected NewClient to create a cloud client")
+	}
+}
+
+func TestDataCenter_Worklogs(t *testing.T) {
+	var comments []interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if auth := r.Header.Get("Authorization"); auth != "Bearer pat123" {
+			t.Errorf("expected bearer token auth, got %q", auth)
+		}
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/rest/api/2/issue/TEST-1/worklog":
+		case r.Method == "PUT" && r.URL.Path == "/rest/api/2/issue/TEST-1/worklog/100":
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		comments = append(comments, payload["comment"])
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(Worklog{ID: "100", TimeSpentSeconds: 3600})
+	}))
+	defer server.Close()
+
+	client := NewDataCenterClient(server.URL, "pat123", "TEST")
+	if _, err := client.AddWorklog("TEST-1", 3600, time.Now(), "Working on tests", WorklogOptions{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := client.UpdateWorklog("TEST-1", "100", 3600, time.Now(), ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(comments) != 2 || comments[0] != "Working on tests" || comments[1] != "" {
+		t.Errorf("expected plain-text comments, got %v", comments)
+	}
+}
+
+func TestDataCenter_SearchPagesByStartAt(t *testing.T) {
+	var startAts []float64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if _, ok := payload["nextPageToken"]; ok {
+			t.Error("expected no nextPageToken for datacenter")
+		}
+		startAt := payload["startAt"].(float64)
+		startAts = append(startAts, startAt)
+
+		response := SearchResult{StartAt: int(startAt), Total: 3}
+		if startAt == 0 {
+			response.Issues = []Issue{{Key: "TEST-1"}, {Key: "TEST-2"}}
+		} else {
+			response.Issues = []Issue{{Key: "TEST-3"}}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewDataCenterClient(server.URL, "pat123", "TEST")
+	issues, err := client.GetInProgressIssues(nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(startAts) != 2 || startAts[1] != 2 {
+		t.Errorf("expected pages at startAt 0 and 2, got %v", startAts)
+	}
+	if len(issues) != 3 || issues[2].Key != "TEST-3" {
+		t.Errorf("expected 3 issues across pages, got %v", issues)
+	}
+}
+
+func TestIssueUser_ID(t *testing.T) {
+	if id := (IssueUser{AccountID: "abc", Key: "JIRAUSER1"}).ID(); id != "abc" {
+		t.Errorf("expected account ID, got %q", id)
+	}
+	if id := (IssueUser{Key: "JIRAUSER1"}).ID(); id != "JIRAUSER1" {
+		t.Errorf("expected user key, got %q", id)
+	}
+}
+
+type staticTokens string
+
+func (s staticTokens) AccessToken() (string, error) {
+	return string(s), nil
+}
+
+func TestNewOAuthClient(t *testing.T) {
+	client := NewOAuthClient("cloud-1", staticTokens("access-1"), "PROJ")
+	if client.apiURL() != "https://api.atlassian.com/ex/jira/cloud-1/rest/api/3" {
+		t.Errorf("unexpected API URL: %s", client.apiURL())
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/ex/jira/cloud-1/rest/api/3/myself" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer access-1" {
+			t.Errorf("expected OAuth bearer token, got %q", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(IssueUser{AccountID: "abc"})
+	}))
+	defer server.Close()
+
+	client.baseURL = server.URL + "/ex/jira/cloud-1"
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.AccountID != "abc" {
+		t.Errorf("expected account abc, got %s", user.AccountID)
+	}
+}
+
+func TestGetIssuesByID(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if payload["jql"] != "id in (10, 20)" {
+			t.Errorf("unexpected JQL: %v", payload["jql"])
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(SearchResult{Issues: []Issue{{ID: "10", Key: "TEST-1"}, {ID: "20", Key: "TEST-2"}}, IsLast: true})
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "user@example.com", "token", "TEST")
+	issues, err := client.GetIssuesByID([]int64{10, 20})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(issues) != 2 || requests != 1 {
+		t.Errorf("expected 2 issues from a single request, got %d issues from %d requests", len(issues), requests)
+	}
+
+	if issues, err := client.GetIssuesByID(nil); err != nil || issues != nil || requests != 1 {
+		t.Errorf("expected no request without IDs, got %v, %v", issues, err)
+	}
+}
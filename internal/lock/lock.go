@@ -0,0 +1,32 @@
+// Package lock provides exclusive, process-wide file locks
+// Locks are released automatically when the process exits, so a crash never leaves a stale lock behind
+package lock
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrLocked is returned when another process holds the lock
+var ErrLocked = errors.New("lock is held by another process")
+
+// ErrUnsupported is returned on platforms without file locks
+var ErrUnsupported = errors.New("file locks are not supported on this platform")
+
+// Lock is a held file lock
+type Lock struct {
+	file *os.File
+}
+
+// Release releases the lock; releasing a nil lock does nothing
+func (l *Lock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	err := unlock(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
@@ -0,0 +1,14 @@
+//go:build !unix
+
+package lock
+
+import "os"
+
+// Acquire always fails with ErrUnsupported on this platform
+func Acquire(path string) (*Lock, error) {
+	return nil, ErrUnsupported
+}
+
+func unlock(file *os.File) error {
+	return nil
+}
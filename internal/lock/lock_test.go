@@ -0,0 +1,39 @@
+//go:build unix
+
+package lock
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestAcquire(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "test.lock")
+
+	first, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("failed to acquire lock: %v", err)
+	}
+
+	if _, err := Acquire(path); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected ErrLocked while held, got %v", err)
+	}
+
+	if err := first.Release(); err != nil {
+		t.Fatalf("failed to release lock: %v", err)
+	}
+
+	second, err := Acquire(path)
+	if err != nil {
+		t.Fatalf("expected lock to be free after release, got %v", err)
+	}
+	defer second.Release()
+}
+
+func TestRelease_Nil(t *testing.T) {
+	var l *Lock
+	if err := l.Release(); err != nil {
+		t.Errorf("expected releasing a nil lock to succeed, got %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+// Package notify sends break, standup and summary notifications to chat services
+package notify
+
+import (
+	"errors"
+	"regexp"
+)
+
+// Events a notifier can be configured for
+const (
+	EventBreak   = "break"
+	EventStandup = "standup"
+	EventSummary = "summary"
+)
+
+// Events lists every notification event
+var Events = []string{EventBreak, EventStandup, EventSummary}
+
+// ErrUnsupported is returned by notifiers that cannot do an operation, like webhooks setting a status
+var ErrUnsupported = errors.New("not supported by this notifier")
+
+// Message is a chat message in Slack mrkdwn (*bold*, _italic_), converted for each service
+type Message struct {
+	Title string // Optional heading
+	Text  string // Paragraphs are separated by blank lines
+}
+
+// Notifier sends notifications to a chat service
+type Notifier interface {
+	// SetStatus sets the user's status, expiring after expirationMinutes (never when <= 0)
+	SetStatus(text, emoji string, expirationMinutes int) error
+	// PostMessage posts a message
+	PostMessage(msg Message) error
+	// ClearStatus clears the user's status
+	ClearStatus() error
+}
+
+// boldPattern matches Slack mrkdwn bold text
+var boldPattern = regexp.MustCompile(`\*([^*\n]+)\*`)
+
+// markdown converts Slack mrkdwn to standard markdown
+func markdown(text string) string {
+	return boldPattern.ReplaceAllString(text, "**$1**")
+}
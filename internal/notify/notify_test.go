@@ -0,0 +1,155 @@
+package notify
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureServer records the body and headers of the last request
+func captureServer(t *testing.T, status int) (*httptest.Server, *[]byte, *http.Header) {
+	t.Helper()
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header.Clone()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, &body, &header
+}
+
+func TestMarkdown(t *testing.T) {
+	got := markdown("🔔 Taking a *lunch break* — Back at *2:30 PM*")
+	want := "🔔 Taking a **lunch break** — Back at **2:30 PM**"
+	if got != want {
+		t.Errorf("markdown() = %q, want %q", got, want)
+	}
+}
+
+func TestWebhook_StatusUnsupported(t *testing.T) {
+	n := NewDiscord("https://example.com")
+	if err := n.SetStatus("Lunch", ":fork_and_knife:", 60); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("SetStatus() error = %v, want ErrUnsupported", err)
+	}
+	if err := n.ClearStatus(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("ClearStatus() error = %v, want ErrUnsupported", err)
+	}
+}
+
+func TestTeams_PostMessage(t *testing.T) {
+	server, body, _ := captureServer(t, http.StatusAccepted)
+
+	if err := NewTeams(server.URL).PostMessage(Message{Title: "Standup", Text: "*Today*\n• PROJ-1\n\n*Blockers*"}); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+
+	var payload struct {
+		Type        string `json:"type"`
+		Attachments []struct {
+			ContentType string `json:"contentType"`
+			Content     struct {
+				Type string `json:"type"`
+				Body []struct {
+					Text string `json:"text"`
+				} `json:"body"`
+			} `json:"content"`
+		} `json:"attachments"`
+	}
+	if err := json.Unmarshal(*body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if payload.Type != "message" || len(payload.Attachments) != 1 || payload.Attachments[0].Content.Type != "AdaptiveCard" {
+		t.Fatalf("unexpected payload: %s", *body)
+	}
+	blocks := payload.Attachments[0].Content.Body
+	if len(blocks) != 3 || blocks[0].Text != "Standup" || blocks[1].Text != "**Today**\n\n• PROJ-1" || blocks[2].Text != "**Blockers**" {
+		t.Errorf("unexpected card body: %+v", blocks)
+	}
+}
+
+func TestMattermost_PostMessage(t *testing.T) {
+	server, body, header := captureServer(t, http.StatusOK)
+
+	if err := NewMattermost(server.URL).PostMessage(Message{Title: "Standup", Text: "*Today*"}); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	if got := string(*body); got != `{"text":"#### Standup\n\n**Today**"}` {
+		t.Errorf("payload = %s", got)
+	}
+	if header.Get("Content-Type") != "application/json" {
+		t.Errorf("Content-Type = %q", header.Get("Content-Type"))
+	}
+}
+
+func TestDiscord_PostMessage(t *testing.T) {
+	server, body, _ := captureServer(t, http.StatusNoContent)
+
+	if err := NewDiscord(server.URL).PostMessage(Message{Text: strings.Repeat("a", 3000)}); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	var payload map[string]string
+	if err := json.Unmarshal(*body, &payload); err != nil {
+		t.Fatalf("invalid payload: %v", err)
+	}
+	if n := len([]rune(payload["content"])); n != maxDiscordLength {
+		t.Errorf("content length = %d, want %d", n, maxDiscordLength)
+	}
+}
+
+func TestGenericWebhook_PostMessage(t *testing.T) {
+	server, body, header := captureServer(t, http.StatusOK)
+
+	tmpl, err := ParseBodyTemplate(`{"event": {{json .Event}}, "message": {{json .Text}}}`)
+	if err != nil {
+		t.Fatalf("ParseBodyTemplate failed: %v", err)
+	}
+	n := NewGenericWebhook(server.URL, EventBreak, tmpl, map[string]string{"X-Token": "secret"})
+	if err := n.PostMessage(Message{Text: `Lunch "break"`}); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+
+	if got := string(*body); got != `{"event": "break", "message": "Lunch \"break\""}` {
+		t.Errorf("payload = %s", got)
+	}
+	if header.Get("X-Token") != "secret" {
+		t.Errorf("expected custom header to be sent")
+	}
+}
+
+func TestParseBodyTemplate_Invalid(t *testing.T) {
+	if _, err := ParseBodyTemplate(`{"text": {{json .Text}`); err == nil {
+		t.Error("expected an error for an unterminated action")
+	}
+}
+
+func TestWebhook_ErrorStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "invalid webhook", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	err := NewMattermost(server.URL).PostMessage(Message{Text: "hi"})
+	if err == nil || !strings.Contains(err.Error(), "status 400: invalid webhook") {
+		t.Errorf("expected the status and body in the error, got %v", err)
+	}
+}
+
+func TestMessageBlocks(t *testing.T) {
+	blocks := messageBlocks(Message{Title: "Standup Tue Mar 10", Text: "*Yesterday*\n• PROJ-1\n\n\n*Today*\n• PROJ-2\n\n*Blockers*\n• None\n"})
+
+	if len(blocks) != 4 {
+		t.Fatalf("got %d blocks, want a header and 3 sections", len(blocks))
+	}
+	if blocks[0].Type != "header" || blocks[0].Text.Text != "Standup Tue Mar 10" {
+		t.Errorf("header = %+v", blocks[0])
+	}
+	if blocks[2].Type != "section" || blocks[2].Text.Type != "mrkdwn" || blocks[2].Text.Text != "*Today*\n• PROJ-2" {
+		t.Errorf("section = %+v", blocks[2])
+	}
+}
@@ -0,0 +1,61 @@
+package notify
+
+import (
+	"strings"
+
+	"tasklog/internal/slack"
+)
+
+// maxSectionLength is the longest text Slack accepts in a section block
+const maxSectionLength = 3000
+
+// Slack posts to a Slack channel and sets the Slack status
+type Slack struct {
+	client *slack.Client
+}
+
+// NewSlack creates a Slack notifier
+func NewSlack(client *slack.Client) *Slack {
+	return &Slack{client: client}
+}
+
+// SetStatus sets the Slack status
+func (s *Slack) SetStatus(text, emoji string, expirationMinutes int) error {
+	return s.client.SetStatus(text, emoji, expirationMinutes)
+}
+
+// PostMessage posts a message, formatted with Block Kit when it has a title
+func (s *Slack) PostMessage(msg Message) error {
+	if msg.Title == "" {
+		return s.client.PostMessage(msg.Text)
+	}
+	return s.client.PostMessage(msg.Text, messageBlocks(msg)...)
+}
+
+// ClearStatus clears the Slack status
+func (s *Slack) ClearStatus() error {
+	return s.client.ClearStatus()
+}
+
+// messageBlocks formats a message as a header and one section block per paragraph
+func messageBlocks(msg Message) []slack.Block {
+	blocks := []slack.Block{slack.HeaderBlock(msg.Title)}
+	for _, paragraph := range paragraphs(msg.Text) {
+		if runes := []rune(paragraph); len(runes) > maxSectionLength {
+			paragraph = string(runes[:maxSectionLength-1]) + "…"
+		}
+		blocks = append(blocks, slack.SectionBlock(paragraph))
+	}
+	return blocks
+}
+
+// paragraphs splits text on blank lines, dropping empty paragraphs
+func paragraphs(text string) []string {
+	var result []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n\n") {
+		if paragraph = strings.TrimSpace(paragraph); paragraph != "" {
+			result = append(result, paragraph)
+		}
+	}
+	return result
+}
@@ -0,0 +1,164 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxDiscordLength is the longest message Discord accepts
+const maxDiscordLength = 2000
+
+// Webhook posts messages to an incoming webhook
+// Incoming webhooks cannot set a status, so SetStatus and ClearStatus return ErrUnsupported
+type Webhook struct {
+	url        string
+	headers    map[string]string
+	body       func(msg Message) ([]byte, error)
+	httpClient *http.Client
+}
+
+// NewTeams creates a notifier for a Microsoft Teams incoming webhook or workflow, posting Adaptive Cards
+func NewTeams(url string) *Webhook {
+	return newWebhook(url, nil, func(msg Message) ([]byte, error) {
+		var body []map[string]interface{}
+		if msg.Title != "" {
+			body = append(body, map[string]interface{}{"type": "TextBlock", "text": msg.Title, "size": "Large", "weight": "Bolder", "wrap": true})
+		}
+		for _, paragraph := range paragraphs(msg.Text) {
+			body = append(body, map[string]interface{}{"type": "TextBlock", "text": teamsText(paragraph), "wrap": true})
+		}
+		return json.Marshal(map[string]interface{}{
+			"type": "message",
+			"attachments": []map[string]interface{}{{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body":    body,
+				},
+			}},
+		})
+	})
+}
+
+// NewMattermost creates a notifier for a Mattermost incoming webhook
+func NewMattermost(url string) *Webhook {
+	return newWebhook(url, nil, func(msg Message) ([]byte, error) {
+		return json.Marshal(map[string]string{"text": markdown(withTitle(msg, "#### "))})
+	})
+}
+
+// NewDiscord creates a notifier for a Discord webhook
+func NewDiscord(url string) *Webhook {
+	return newWebhook(url, nil, func(msg Message) ([]byte, error) {
+		content := markdown(withTitle(msg, "## "))
+		if runes := []rune(content); len(runes) > maxDiscordLength {
+			content = string(runes[:maxDiscordLength-1]) + "…"
+		}
+		return json.Marshal(map[string]string{"content": content})
+	})
+}
+
+// WebhookData is the data of a generic webhook body template
+type WebhookData struct {
+	Event string // "break", "standup" or "summary"
+	Title string
+	Text  string
+}
+
+// ParseBodyTemplate parses a generic webhook body template
+// Templates use Go template syntax with the fields of WebhookData and a json function
+// that quotes a value as a JSON string, like {"text": {{json .Text}}}
+func ParseBodyTemplate(body string) (*template.Template, error) {
+	return template.New("body").Funcs(template.FuncMap{
+		"json": func(v interface{}) (string, error) {
+			data, err := json.Marshal(v)
+			return string(data), err
+		},
+	}).Option("missingkey=error").Parse(body)
+}
+
+// NewGenericWebhook creates a notifier posting the rendered body template as JSON to url
+func NewGenericWebhook(url, event string, body *template.Template, headers map[string]string) *Webhook {
+	return newWebhook(url, headers, func(msg Message) ([]byte, error) {
+		var buf bytes.Buffer
+		if err := body.Execute(&buf, WebhookData{Event: event, Title: msg.Title, Text: msg.Text}); err != nil {
+			return nil, fmt.Errorf("failed to render webhook body: %w", err)
+		}
+		return buf.Bytes(), nil
+	})
+}
+
+func newWebhook(url string, headers map[string]string, body func(msg Message) ([]byte, error)) *Webhook {
+	return &Webhook{
+		url:     url,
+		headers: headers,
+		body:    body,
+		httpClient: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+	}
+}
+
+// SetStatus returns ErrUnsupported, webhooks cannot set a status
+func (w *Webhook) SetStatus(text, emoji string, expirationMinutes int) error {
+	return ErrUnsupported
+}
+
+// ClearStatus returns ErrUnsupported, webhooks cannot set a status
+func (w *Webhook) ClearStatus() error {
+	return ErrUnsupported
+}
+
+// PostMessage posts a message to the webhook
+func (w *Webhook) PostMessage(msg Message) error {
+	payload, err := w.body(msg)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest("POST", w.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	log.Debug().Int("status", resp.StatusCode).Msg("Message posted to webhook")
+	return nil
+}
+
+// withTitle prepends the title of a message as a markdown heading
+func withTitle(msg Message, heading string) string {
+	if msg.Title == "" {
+		return msg.Text
+	}
+	return heading + msg.Title + "\n\n" + msg.Text
+}
+
+// teamsText converts Slack mrkdwn to Adaptive Card markdown, which needs a blank line between lines
+func teamsText(text string) string {
+	return strings.ReplaceAll(markdown(text), "\n", "\n\n")
+}
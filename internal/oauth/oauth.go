@@ -0,0 +1,361 @@
+package oauth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	"tasklog/internal/secrets"
+)
+
+// Atlassian OAuth 2.0 (3LO) endpoints
+const (
+	AtlassianAuthURL      = "https://auth.atlassian.com/authorize"
+	AtlassianTokenURL     = "https://auth.atlassian.com/oauth/token"
+	AtlassianResourcesURL = "https://api.atlassian.com/oauth/token/accessible-resources"
+)
+
+// JiraScopes are the scopes needed to read issues and manage worklogs
+// offline_access is required to receive a refresh token
+var JiraScopes = []string{"read:jira-work", "write:jira-work", "read:jira-user", "offline_access"}
+
+// expiryMargin refreshes access tokens shortly before they expire
+const expiryMargin = time.Minute
+
+// ErrNotLoggedIn is returned when no session has been stored
+var ErrNotLoggedIn = errors.New("not logged in")
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Config describes an OAuth application and its authorization server
+type Config struct {
+	ClientID     string
+	ClientSecret string // Optional, sent with token requests when set
+	AuthURL      string
+	TokenURL     string
+	ResourcesURL string
+	RedirectURL  string // Loopback URL registered as the app's callback, e.g. http://localhost:8765/callback
+	Scopes       []string
+}
+
+// AtlassianConfig returns the configuration for an Atlassian OAuth app with a loopback callback on port
+func AtlassianConfig(clientID, clientSecret string, port int) Config {
+	return Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		AuthURL:      AtlassianAuthURL,
+		TokenURL:     AtlassianTokenURL,
+		ResourcesURL: AtlassianResourcesURL,
+		RedirectURL:  fmt.Sprintf("http://localhost:%d/callback", port),
+		Scopes:       JiraScopes,
+	}
+}
+
+// Token is an access token and the refresh token that renews it
+type Token struct {
+	AccessToken  string    `json:"access_token"`
+	RefreshToken string    `json:"refresh_token"`
+	ExpiresIn    int       `json:"expires_in"` // Seconds
+	Expiry       time.Time `json:"-"`
+}
+
+// Resource is a site the user granted access to
+type Resource struct {
+	ID     string   `json:"id"` // Cloud ID
+	URL    string   `json:"url"`
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+// Login runs the authorization code flow with PKCE
+// It listens on the loopback redirect URL, calls open with the authorization URL and
+// exchanges the returned code for a token. It gives up when ctx is done.
+func Login(ctx context.Context, cfg Config, open func(authURL string) error) (*Token, error) {
+	redirect, err := url.Parse(cfg.RedirectURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redirect URL: %w", err)
+	}
+
+	// Listen on loopback only; a port of 0 picks a free port (useful in tests)
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", redirect.Port()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen for the OAuth callback on %s: %w", redirect.Host, err)
+	}
+	defer listener.Close()
+	if redirect.Port() == "0" {
+		redirect.Host = net.JoinHostPort(redirect.Hostname(), fmt.Sprint(listener.Addr().(*net.TCPAddr).Port))
+	}
+	redirectURL := redirect.String()
+
+	verifier, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomString(16)
+	if err != nil {
+		return nil, err
+	}
+
+	type callbackResult struct {
+		code string
+		err  error
+	}
+	results := make(chan callbackResult, 1)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(redirect.Path, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		var result callbackResult
+		switch {
+		case query.Get("state") != state:
+			result.err = fmt.Errorf("OAuth callback state does not match")
+		case query.Get("error") != "":
+			result.err = fmt.Errorf("authorization failed: %s %s", query.Get("error"), query.Get("error_description"))
+		case query.Get("code") == "":
+			result.err = fmt.Errorf("OAuth callback is missing the authorization code")
+		default:
+			result.code = query.Get("code")
+		}
+
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadRequest)
+		} else {
+			fmt.Fprintln(w, "Logged in to tasklog. You can close this window.")
+		}
+
+		select {
+		case results <- result:
+		default:
+		}
+	})
+
+	server := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	defer server.Close()
+
+	params := url.Values{}
+	params.Set("audience", "api.atlassian.com")
+	params.Set("client_id", cfg.ClientID)
+	params.Set("scope", strings.Join(cfg.Scopes, " "))
+	params.Set("redirect_uri", redirectURL)
+	params.Set("state", state)
+	params.Set("response_type", "code")
+	params.Set("prompt", "consent")
+	params.Set("code_challenge", codeChallenge(verifier))
+	params.Set("code_challenge_method", "S256")
+
+	if err := open(cfg.AuthURL + "?" + params.Encode()); err != nil {
+		return nil, err
+	}
+
+	log.Debug().Str("redirect_url", redirectURL).Msg("Waiting for OAuth callback")
+
+	select {
+	case <-ctx.Done():
+		return nil, fmt.Errorf("login timed out: %w", ctx.Err())
+	case result := <-results:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return requestToken(cfg, map[string]string{
+			"grant_type":    "authorization_code",
+			"code":          result.code,
+			"redirect_uri":  redirectURL,
+			"code_verifier": verifier,
+		})
+	}
+}
+
+// Refresh exchanges a refresh token for a new access token
+// Atlassian rotates refresh tokens, so the returned token may carry a new refresh token
+func Refresh(cfg Config, refreshToken string) (*Token, error) {
+	return requestToken(cfg, map[string]string{
+		"grant_type":    "refresh_token",
+		"refresh_token": refreshToken,
+	})
+}
+
+// AccessibleResources lists the sites the access token can be used with
+func AccessibleResources(cfg Config, accessToken string) ([]Resource, error) {
+	req, err := http.NewRequest("GET", cfg.ResourcesURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	var resources []Resource
+	if err := do(req, &resources); err != nil {
+		return nil, fmt.Errorf("failed to list accessible sites: %w", err)
+	}
+	return resources, nil
+}
+
+// requestToken posts a token request and sets the token expiry
+func requestToken(cfg Config, params map[string]string) (*Token, error) {
+	params["client_id"] = cfg.ClientID
+	if cfg.ClientSecret != "" {
+		params["client_secret"] = cfg.ClientSecret
+	}
+
+	body, err := json.Marshal(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal token request: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", cfg.TokenURL, strings.NewReader(string(body)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	var token Token
+	if err := do(req, &token); err != nil {
+		return nil, fmt.Errorf("token request failed: %w", err)
+	}
+	if token.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access token")
+	}
+	token.Expiry = time.Now().Add(time.Duration(token.ExpiresIn) * time.Second)
+
+	log.Debug().Str("grant_type", params["grant_type"]).Time("expiry", token.Expiry).Msg("Obtained OAuth token")
+	return &token, nil
+}
+
+// do sends a request and decodes the JSON response into result
+func do(req *http.Request, result interface{}) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
+
+// randomString returns n random bytes encoded as unpadded base64url
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate random value: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// codeChallenge derives the S256 PKCE challenge from a verifier
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// Session is what a login leaves in the secret store
+type Session struct {
+	RefreshToken string `json:"refresh_token"`
+	CloudID      string `json:"cloud_id"`
+	SiteURL      string `json:"site_url"`
+}
+
+// LoadSession reads a session from the secret store, returning ErrNotLoggedIn if there is none
+func LoadSession(store secrets.Store, key string) (*Session, error) {
+	value, err := store.Get(key)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return nil, ErrNotLoggedIn
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal([]byte(value), &session); err != nil {
+		return nil, fmt.Errorf("failed to parse stored session: %w", err)
+	}
+	if session.RefreshToken == "" {
+		return nil, ErrNotLoggedIn
+	}
+	return &session, nil
+}
+
+// SaveSession writes a session to the secret store
+func SaveSession(store secrets.Store, key string, session *Session) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+	return store.Set(key, string(data))
+}
+
+// TokenSource hands out access tokens, refreshing them from the stored session when they expire
+type TokenSource struct {
+	config Config
+	store  secrets.Store
+	key    string
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+// NewTokenSource creates a token source for the session stored under key
+func NewTokenSource(cfg Config, store secrets.Store, key string) *TokenSource {
+	return &TokenSource{config: cfg, store: store, key: key}
+}
+
+// AccessToken returns a valid access token
+func (s *TokenSource) AccessToken() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.accessToken != "" && time.Now().Add(expiryMargin).Before(s.expiry) {
+		return s.accessToken, nil
+	}
+
+	session, err := LoadSession(s.store, s.key)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := Refresh(s.config, session.RefreshToken)
+	if err != nil {
+		return "", err
+	}
+
+	// Keep the rotated refresh token, the old one stops working
+	if token.RefreshToken != "" && token.RefreshToken != session.RefreshToken {
+		session.RefreshToken = token.RefreshToken
+		if err := SaveSession(s.store, s.key, session); err != nil {
+			return "", fmt.Errorf("failed to save refreshed session: %w", err)
+		}
+	}
+
+	s.accessToken = token.AccessToken
+	s.expiry = token.Expiry
+	return s.accessToken, nil
+}
@@ -0,0 +1,226 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"tasklog/internal/secrets"
+)
+
+// fakeAuthServer is a stand-in Atlassian authorization server
+type fakeAuthServer struct {
+	t             *testing.T
+	challenge     string
+	redirectURI   string
+	tokenRequests int
+}
+
+func (f *fakeAuthServer) handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/authorize", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("client_id") != "client-1" {
+			f.t.Errorf("unexpected client_id: %s", query.Get("client_id"))
+		}
+		if query.Get("code_challenge_method") != "S256" {
+			f.t.Errorf("expected S256 code challenge, got %q", query.Get("code_challenge_method"))
+		}
+		f.challenge = query.Get("code_challenge")
+		f.redirectURI = query.Get("redirect_uri")
+
+		redirect, _ := url.Parse(f.redirectURI)
+		params := url.Values{"code": {"code-1"}, "state": {query.Get("state")}}
+		redirect.RawQuery = params.Encode()
+		http.Redirect(w, r, redirect.String(), http.StatusFound)
+	})
+
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		f.tokenRequests++
+
+		var params map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&params); err != nil {
+			f.t.Fatalf("failed to decode token request: %v", err)
+		}
+		if params["client_secret"] != "secret-1" {
+			f.t.Errorf("expected client secret to be sent, got %q", params["client_secret"])
+		}
+
+		var response Token
+		switch params["grant_type"] {
+		case "authorization_code":
+			if params["code"] != "code-1" {
+				f.t.Errorf("unexpected code: %s", params["code"])
+			}
+			if codeChallenge(params["code_verifier"]) != f.challenge {
+				f.t.Error("code verifier does not match the challenge")
+			}
+			if params["redirect_uri"] != f.redirectURI {
+				f.t.Errorf("expected redirect_uri %s, got %s", f.redirectURI, params["redirect_uri"])
+			}
+			response = Token{AccessToken: "access-1", RefreshToken: "refresh-1", ExpiresIn: 3600}
+		case "refresh_token":
+			if params["refresh_token"] != "refresh-1" {
+				w.WriteHeader(http.StatusForbidden)
+				io.WriteString(w, `{"error":"unauthorized_client"}`)
+				return
+			}
+			response = Token{AccessToken: "access-2", RefreshToken: "refresh-2", ExpiresIn: 3600}
+		default:
+			f.t.Errorf("unexpected grant_type: %s", params["grant_type"])
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	})
+
+	mux.HandleFunc("/resources", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer access-1" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode([]Resource{{ID: "cloud-1", URL: "https://example.atlassian.net", Name: "example"}})
+	})
+
+	return mux
+}
+
+func newFakeAuth(t *testing.T) (*fakeAuthServer, Config) {
+	fake := &fakeAuthServer{t: t}
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	return fake, Config{
+		ClientID:     "client-1",
+		ClientSecret: "secret-1",
+		AuthURL:      server.URL + "/authorize",
+		TokenURL:     server.URL + "/oauth/token",
+		ResourcesURL: server.URL + "/resources",
+		RedirectURL:  "http://127.0.0.1:0/callback",
+		Scopes:       JiraScopes,
+	}
+}
+
+// browse follows the authorization URL like a browser would, ending at the loopback callback
+func browse(authURL string) error {
+	go func() {
+		resp, err := http.Get(authURL)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}()
+	return nil
+}
+
+func TestLogin(t *testing.T) {
+	_, cfg := newFakeAuth(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	token, err := Login(ctx, cfg, browse)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if token.AccessToken != "access-1" || token.RefreshToken != "refresh-1" {
+		t.Errorf("unexpected token: %+v", token)
+	}
+	if time.Until(token.Expiry) < 59*time.Minute {
+		t.Errorf("expected expiry about an hour from now, got %v", token.Expiry)
+	}
+
+	resources, err := AccessibleResources(cfg, token.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resources) != 1 || resources[0].ID != "cloud-1" {
+		t.Errorf("unexpected resources: %+v", resources)
+	}
+}
+
+func TestLogin_StateMismatch(t *testing.T) {
+	_, cfg := newFakeAuth(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// Call the loopback directly with a forged state
+	forge := func(authURL string) error {
+		parsed, _ := url.Parse(authURL)
+		redirect := parsed.Query().Get("redirect_uri")
+		go func() {
+			resp, err := http.Get(redirect + "?code=stolen&state=forged")
+			if err == nil {
+				resp.Body.Close()
+			}
+		}()
+		return nil
+	}
+
+	if _, err := Login(ctx, cfg, forge); err == nil {
+		t.Fatal("expected an error for a mismatched state")
+	}
+}
+
+func TestLogin_Timeout(t *testing.T) {
+	_, cfg := newFakeAuth(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := Login(ctx, cfg, func(string) error { return nil })
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+}
+
+func TestTokenSource_RefreshesAndRotates(t *testing.T) {
+	fake, cfg := newFakeAuth(t)
+	store := secrets.NewFileStore(filepath.Join(t.TempDir(), "secrets.json"))
+
+	if _, err := NewTokenSource(cfg, store, "jira").AccessToken(); !errors.Is(err, ErrNotLoggedIn) {
+		t.Fatalf("expected ErrNotLoggedIn without a session, got %v", err)
+	}
+
+	if err := SaveSession(store, "jira", &Session{RefreshToken: "refresh-1", CloudID: "cloud-1"}); err != nil {
+		t.Fatal(err)
+	}
+
+	source := NewTokenSource(cfg, store, "jira")
+	for i := 0; i < 2; i++ {
+		token, err := source.AccessToken()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if token != "access-2" {
+			t.Errorf("expected refreshed access token, got %q", token)
+		}
+	}
+	if fake.tokenRequests != 1 {
+		t.Errorf("expected the access token to be cached, got %d token requests", fake.tokenRequests)
+	}
+
+	session, err := LoadSession(store, "jira")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.RefreshToken != "refresh-2" || session.CloudID != "cloud-1" {
+		t.Errorf("expected rotated refresh token to be stored, got %+v", session)
+	}
+}
+
+func TestCodeChallenge(t *testing.T) {
+	// Example from RFC 7636, appendix B
+	if got := codeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"); got != "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM" {
+		t.Errorf("unexpected code challenge: %s", got)
+	}
+}
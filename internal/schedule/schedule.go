@@ -0,0 +1,230 @@
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxSearchDays bounds how far ahead Next looks for a matching time
+const maxSearchDays = 366 * 5
+
+// Schedule represents a parsed recurring schedule with minute precision
+// It follows standard cron semantics: when both day-of-month and day-of-week
+// are restricted, a day matches if either of them matches
+type Schedule struct {
+	minutes     [60]bool
+	hours       [24]bool
+	daysOfMonth [32]bool // index 1-31
+	months      [13]bool // index 1-12
+	daysOfWeek  [7]bool  // 0 = Sunday
+	domAny      bool
+	dowAny      bool
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "sunday": time.Sunday,
+	"mon": time.Monday, "monday": time.Monday,
+	"tue": time.Tuesday, "tuesday": time.Tuesday,
+	"wed": time.Wednesday, "wednesday": time.Wednesday,
+	"thu": time.Thursday, "thursday": time.Thursday,
+	"fri": time.Friday, "friday": time.Friday,
+	"sat": time.Saturday, "saturday": time.Saturday,
+}
+
+// ParseCron parses a standard 5-field cron expression (minute hour day-of-month month day-of-week)
+// Supports *, lists (1,2), ranges (1-5), steps (*/15, 1-30/5) and weekday names (mon-fri)
+func ParseCron(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("invalid cron expression %q: expected 5 fields, got %d", expr, len(fields))
+	}
+
+	s := &Schedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+
+	if err := parseField(fields[0], 0, 59, nil, s.minutes[:]); err != nil {
+		return nil, fmt.Errorf("invalid minute field: %w", err)
+	}
+	if err := parseField(fields[1], 0, 23, nil, s.hours[:]); err != nil {
+		return nil, fmt.Errorf("invalid hour field: %w", err)
+	}
+	if err := parseField(fields[2], 1, 31, nil, s.daysOfMonth[:]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-month field: %w", err)
+	}
+	if err := parseField(fields[3], 1, 12, nil, s.months[:]); err != nil {
+		return nil, fmt.Errorf("invalid month field: %w", err)
+	}
+
+	// Day of week accepts 0-7 (both 0 and 7 are Sunday) and names
+	var dow [8]bool
+	if err := parseField(fields[4], 0, 7, weekdayNames, dow[:]); err != nil {
+		return nil, fmt.Errorf("invalid day-of-week field: %w", err)
+	}
+	copy(s.daysOfWeek[:], dow[:7])
+	if dow[7] {
+		s.daysOfWeek[time.Sunday] = true
+	}
+
+	return s, nil
+}
+
+// FromWeekdays builds a schedule that runs at a time of day (HH:MM) on the given weekdays
+// An empty weekday list means every day
+func FromWeekdays(days []string, at string) (*Schedule, error) {
+	parsed, err := time.Parse("15:04", strings.TrimSpace(at))
+	if err != nil {
+		return nil, fmt.Errorf("invalid time of day %q (expected HH:MM)", at)
+	}
+
+	s := &Schedule{domAny: true, dowAny: len(days) == 0}
+	s.minutes[parsed.Minute()] = true
+	s.hours[parsed.Hour()] = true
+	for i := 1; i <= 31; i++ {
+		s.daysOfMonth[i] = true
+	}
+	for i := 1; i <= 12; i++ {
+		s.months[i] = true
+	}
+
+	if len(days) == 0 {
+		for i := range s.daysOfWeek {
+			s.daysOfWeek[i] = true
+		}
+		return s, nil
+	}
+
+	for _, day := range days {
+		wd, ok := weekdayNames[strings.ToLower(strings.TrimSpace(day))]
+		if !ok {
+			return nil, fmt.Errorf("invalid weekday %q", day)
+		}
+		s.daysOfWeek[wd] = true
+	}
+
+	return s, nil
+}
+
+// Next returns the first scheduled time strictly after t
+// Returns the zero time if nothing matches within the search window
+func (s *Schedule) Next(t time.Time) time.Time {
+	t = t.Truncate(time.Minute).Add(time.Minute)
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+
+	for i := 0; i < maxSearchDays; i++ {
+		if s.matchesDay(day) {
+			for h := 0; h < 24; h++ {
+				if !s.hours[h] {
+					continue
+				}
+				for m := 0; m < 60; m++ {
+					if !s.minutes[m] {
+						continue
+					}
+					candidate := time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location())
+					if !candidate.Before(t) {
+						return candidate
+					}
+				}
+			}
+		}
+		day = day.AddDate(0, 0, 1)
+	}
+
+	return time.Time{}
+}
+
+// Between returns all scheduled times in the half-open interval (from, to]
+func (s *Schedule) Between(from, to time.Time) []time.Time {
+	var times []time.Time
+	for next := s.Next(from); !next.IsZero() && !next.After(to); next = s.Next(next) {
+		times = append(times, next)
+	}
+	return times
+}
+
+// matchesDay reports whether the schedule can fire on the given day
+func (s *Schedule) matchesDay(day time.Time) bool {
+	if !s.months[day.Month()] {
+		return false
+	}
+
+	domMatch := s.daysOfMonth[day.Day()]
+	dowMatch := s.daysOfWeek[day.Weekday()]
+
+	switch {
+	case s.domAny && s.dowAny:
+		return true
+	case s.domAny:
+		return dowMatch
+	case s.dowAny:
+		return domMatch
+	default:
+		return domMatch || dowMatch
+	}
+}
+
+// parseField parses a single cron field into the set of allowed values
+func parseField(field string, minVal, maxVal int, names map[string]time.Weekday, set []bool) error {
+	for _, part := range strings.Split(field, ",") {
+		if part == "" {
+			return fmt.Errorf("empty value in %q", field)
+		}
+
+		step := 1
+		if idx := strings.Index(part, "/"); idx >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[idx+1:])
+			if err != nil || step <= 0 {
+				return fmt.Errorf("invalid step in %q", part)
+			}
+			part = part[:idx]
+		}
+
+		start, end := minVal, maxVal
+		if part != "*" {
+			bounds := strings.SplitN(part, "-", 2)
+			var err error
+			start, err = parseValue(bounds[0], names)
+			if err != nil {
+				return err
+			}
+			end = start
+			if len(bounds) == 2 {
+				end, err = parseValue(bounds[1], names)
+				if err != nil {
+					return err
+				}
+			} else if step > 1 {
+				// "5/15" means starting at 5 through the maximum
+				end = maxVal
+			}
+		}
+
+		if start < minVal || end > maxVal || start > end {
+			return fmt.Errorf("value out of range in %q (allowed %d-%d)", field, minVal, maxVal)
+		}
+
+		for v := start; v <= end; v += step {
+			set[v] = true
+		}
+	}
+	return nil
+}
+
+// parseValue parses a numeric cron value or a name
+func parseValue(value string, names map[string]time.Weekday) (int, error) {
+	if names != nil {
+		if wd, ok := names[strings.ToLower(value)]; ok {
+			return int(wd), nil
+		}
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", value)
+	}
+	return n, nil
+}
@@ -0,0 +1,149 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"too few fields", "30 9 * *"},
+		{"too many fields", "30 9 * * 1 2"},
+		{"minute out of range", "60 9 * * *"},
+		{"hour out of range", "0 24 * * *"},
+		{"invalid step", "*/0 * * * *"},
+		{"invalid value", "abc * * * *"},
+		{"reversed range", "0 9 * * 5-1"},
+		{"empty list item", "0,,5 * * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseCron(tt.expr); err == nil {
+				t.Errorf("expected error for %q", tt.expr)
+			}
+		})
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	// Wednesday 2025-01-15 10:00
+	base := time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		expr     string
+		from     time.Time
+		expected time.Time
+	}{
+		{
+			name:     "weekday morning later in the week",
+			expr:     "30 9 * * 1-5",
+			from:     base,
+			expected: time.Date(2025, 1, 16, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "same day later",
+			expr:     "0 17 * * *",
+			from:     base,
+			expected: time.Date(2025, 1, 15, 17, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "exact time is exclusive",
+			expr:     "0 10 * * *",
+			from:     base,
+			expected: time.Date(2025, 1, 16, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "friday skips weekend",
+			expr:     "30 9 * * mon-fri",
+			from:     time.Date(2025, 1, 17, 10, 0, 0, 0, time.UTC),
+			expected: time.Date(2025, 1, 20, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			name:     "step minutes",
+			expr:     "*/15 * * * *",
+			from:     time.Date(2025, 1, 15, 10, 7, 0, 0, time.UTC),
+			expected: time.Date(2025, 1, 15, 10, 15, 0, 0, time.UTC),
+		},
+		{
+			name:     "sunday as 7",
+			expr:     "0 8 * * 7",
+			from:     base,
+			expected: time.Date(2025, 1, 19, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:     "day of month",
+			expr:     "0 9 1 * *",
+			from:     base,
+			expected: time.Date(2025, 2, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := ParseCron(tt.expr)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := s.Next(tt.from)
+			if !got.Equal(tt.expected) {
+				t.Errorf("Next(%s) = %s, want %s", tt.from, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFromWeekdays(t *testing.T) {
+	s, err := FromWeekdays([]string{"mon", "Wednesday"}, "09:30")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Tuesday 2025-01-14 12:00 -> Wednesday 09:30
+	got := s.Next(time.Date(2025, 1, 14, 12, 0, 0, 0, time.UTC))
+	expected := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+
+	// Wednesday 10:00 -> next Monday 09:30
+	got = s.Next(time.Date(2025, 1, 15, 10, 0, 0, 0, time.UTC))
+	expected = time.Date(2025, 1, 20, 9, 30, 0, 0, time.UTC)
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestFromWeekdays_Invalid(t *testing.T) {
+	if _, err := FromWeekdays([]string{"funday"}, "09:30"); err == nil {
+		t.Error("expected error for invalid weekday")
+	}
+	if _, err := FromWeekdays(nil, "9.30"); err == nil {
+		t.Error("expected error for invalid time")
+	}
+}
+
+func TestSchedule_Between(t *testing.T) {
+	s, err := ParseCron("0 9 * * *")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	from := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 17, 9, 0, 0, 0, time.UTC)
+
+	times := s.Between(from, to)
+	if len(times) != 2 {
+		t.Fatalf("expected 2 occurrences, got %d: %v", len(times), times)
+	}
+	if !times[0].Equal(time.Date(2025, 1, 16, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected first occurrence: %s", times[0])
+	}
+	if !times[1].Equal(to) {
+		t.Errorf("expected last occurrence to include upper bound, got %s", times[1])
+	}
+}
@@ -0,0 +1,133 @@
+package secrets
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// ErrNotFound is returned when a secret does not exist
+var ErrNotFound = errors.New("secret not found")
+
+// Store keeps credentials outside the config file
+type Store interface {
+	Get(key string) (string, error)
+	Set(key, value string) error
+	Delete(key string) error
+}
+
+// FileStore stores secrets in a JSON file readable only by the current user
+type FileStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileStore creates a secret store backed by the file at path
+func NewFileStore(path string) *FileStore {
+	return &FileStore{path: path}
+}
+
+// Get returns the secret stored under key, or ErrNotFound
+func (s *FileStore) Get(key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	value, ok := values[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return value, nil
+}
+
+// Set stores a secret under key, replacing any existing value
+func (s *FileStore) Set(key, value string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	values[key] = value
+	return s.save(values)
+}
+
+// Delete removes the secret stored under key
+// Deleting a missing secret is not an error
+func (s *FileStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	values, err := s.load()
+	if err != nil {
+		return err
+	}
+	if _, ok := values[key]; !ok {
+		return nil
+	}
+	delete(values, key)
+	return s.save(values)
+}
+
+// load reads all secrets, returning an empty map if the file does not exist
+func (s *FileStore) load() (map[string]string, error) {
+	values := map[string]string{}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return values, nil
+		}
+		return nil, fmt.Errorf("failed to read secrets: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse secrets file %s: %w", s.path, err)
+	}
+	return values, nil
+}
+
+// save writes all secrets through a temporary file so a failed write never truncates the store
+func (s *FileStore) save(values map[string]string) error {
+	data, err := json.MarshalIndent(values, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode secrets: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0750); err != nil {
+		return fmt.Errorf("failed to create secrets directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), ".secrets-*")
+	if err != nil {
+		return fmt.Errorf("failed to write secrets: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write secrets: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write secrets: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write secrets: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to write secrets: %w", err)
+	}
+
+	log.Debug().Str("path", s.path).Msg("Saved secrets")
+	return nil
+}
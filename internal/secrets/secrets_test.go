@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFileStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "secrets.json")
+	store := NewFileStore(path)
+
+	if _, err := store.Get("jira"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("expected ErrNotFound for an empty store, got %v", err)
+	}
+
+	if err := store.Set("jira", "token-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Set("slack", "token-2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// A new store reads what the first one wrote
+	value, err := NewFileStore(path).Get("jira")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if value != "token-1" {
+		t.Errorf("expected token-1, got %q", value)
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Mode().Perm() != 0600 {
+		t.Errorf("expected permissions 0600, got %v", info.Mode().Perm())
+	}
+
+	if err := store.Delete("jira"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := store.Get("jira"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if value, _ := store.Get("slack"); value != "token-2" {
+		t.Errorf("expected other secrets to be kept, got %q", value)
+	}
+	if err := store.Delete("missing"); err != nil {
+		t.Errorf("expected deleting a missing secret to succeed, got %v", err)
+	}
+}
+
+func TestFileStore_InvalidFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "secrets.json")
+	if err := os.WriteFile(path, []byte("not json"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := NewFileStore(path).Get("jira"); err == nil || errors.Is(err, ErrNotFound) {
+		t.Errorf("expected a parse error, got %v", err)
+	}
+}
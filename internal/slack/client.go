@@ -3,36 +3,122 @@ package slack
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
+// DefaultBaseURL is the Slack Web API base URL
+const DefaultBaseURL = "https://slack.com/api"
+
+const (
+	maxAttempts  = 3                // Attempts per API call, including the first
+	retryBackoff = time.Second      // Wait before the first retry, doubled for each retry after
+	maxRetryWait = 30 * time.Second // Longest wait for a Retry-After header
+)
+
+var (
+	// ErrInvalidEmoji is returned when Slack rejects the emoji of a status
+	ErrInvalidEmoji = errors.New("invalid emoji")
+	// ErrRateLimited is returned when Slack still rate limits a call after retrying
+	ErrRateLimited = errors.New("rate limited")
+	// ErrSnoozeNotActive is returned when ending Do Not Disturb that is not turned on
+	ErrSnoozeNotActive = errors.New("snooze not active")
+)
+
+// APIError is returned when Slack answers a call with an error or a non-2xx status
+type APIError struct {
+	Method     string        // API method, e.g. "users.profile.set"
+	StatusCode int           // HTTP status code
+	Code       string        // Slack error code, e.g. "invalid_auth"; empty when the body is not a Slack response
+	Body       string        // Response body when it is not a Slack response
+	RetryAfter time.Duration // Wait asked for by a rate limited response
+}
+
+func (e *APIError) Error() string {
+	if e.Code != "" {
+		return fmt.Sprintf("slack API error: %s", e.Code)
+	}
+	return fmt.Sprintf("slack API request failed with status %d: %s", e.StatusCode, e.Body)
+}
+
+// Is matches the error codes of invalid emoji, rate limits and inactive snoozes to their errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrInvalidEmoji:
+		return e.Code == "invalid_emoji" ||
+			e.Code == "profile_status_set_failed_not_valid_emoji" ||
+			e.Code == "profile_status_set_failed_not_emoji_syntax"
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests || e.Code == "ratelimited"
+	case ErrSnoozeNotActive:
+		return e.Code == "snooze_not_active"
+	}
+	return false
+}
+
+// retryable reports whether a call failing with this error may succeed when retried
+// Rate limited calls were not carried out, so they are always retried; Slack may have carried out
+// a call answered with a gateway error, so those are only retried for calls that are safe to repeat
+func (e *APIError) retryable() bool {
+	if errors.Is(e, ErrRateLimited) {
+		return true
+	}
+	switch e.StatusCode {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent(e.Method)
+	}
+	return false
+}
+
+// idempotent reports whether calling a Slack API method twice has the same effect as calling it once
+// Reading or setting a status and Do Not Disturb is; posting a message is not
+func idempotent(method string) bool {
+	return strings.HasPrefix(method, "users.profile.") || strings.HasPrefix(method, "dnd.")
+}
+
 // Client represents a Slack API client
 type Client struct {
+	baseURL    string
 	userToken  string
 	channelID  string
 	httpClient *http.Client
+	sleep      func(time.Duration) // Waits between retries, replaced in tests
 }
 
 // NewClient creates a new Slack API client
 func NewClient(userToken, channelID string) *Client {
+	return NewClientWithBaseURL(DefaultBaseURL, userToken, channelID)
+}
+
+// NewClientWithBaseURL creates a new Slack API client calling the API at baseURL,
+// e.g. an Enterprise Grid proxy
+func NewClientWithBaseURL(baseURL, userToken, channelID string) *Client {
 	return &Client{
+		baseURL:   strings.TrimSuffix(baseURL, "/"),
 		userToken: userToken,
 		channelID: channelID,
 		httpClient: &http.Client{
 			Timeout: 10 * time.Second,
 		},
+		sleep: time.Sleep,
 	}
 }
 
 // SetStatus sets the user's Slack status
+// A status with no expiration (expirationMinutes <= 0) stays until it is changed or cleared
 func (c *Client) SetStatus(statusText, statusEmoji string, expirationMinutes int) error {
-	url := "https://slack.com/api/users.profile.set"
-
-	expiration := time.Now().Add(time.Duration(expirationMinutes) * time.Minute).Unix()
+	var expiration int64
+	if expirationMinutes > 0 {
+		expiration = time.Now().Add(time.Duration(expirationMinutes) * time.Minute).Unix()
+	}
 
 	profile := map[string]interface{}{
 		"status_text":       statusText,
@@ -49,32 +135,9 @@ func (c *Client) SetStatus(statusText, statusEmoji string, expirationMinutes int
 		return fmt.Errorf("failed to marshal status payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create status request: %w", err)
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.userToken))
-
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
+	if err := c.call("users.profile.set", "application/json", jsonData, nil); err != nil {
 		return fmt.Errorf("failed to set status: %w", err)
 	}
-	defer resp.Body.Close()
-
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode status response: %w", err)
-	}
-
-	if ok, exists := result["ok"].(bool); !exists || !ok {
-		errorMsg := "unknown error"
-		if errStr, exists := result["error"].(string); exists {
-			errorMsg = errStr
-		}
-		return fmt.Errorf("slack API error: %s", errorMsg)
-	}
 
 	log.Debug().
 		Str("status_text", statusText).
@@ -85,56 +148,194 @@ func (c *Client) SetStatus(statusText, statusEmoji string, expirationMinutes int
 	return nil
 }
 
-// PostMessage posts a message to the configured channel
-func (c *Client) PostMessage(text string) error {
-	url := "https://slack.com/api/chat.postMessage"
+// GetStatus returns the text and emoji of the user's Slack status
+// Requires the users.profile:read user scope
+func (c *Client) GetStatus() (string, string, error) {
+	var result struct {
+		Profile struct {
+			StatusText  string `json:"status_text"`
+			StatusEmoji string `json:"status_emoji"`
+		} `json:"profile"`
+	}
+	if err := c.call("users.profile.get", "application/x-www-form-urlencoded", nil, &result); err != nil {
+		return "", "", fmt.Errorf("failed to get status: %w", err)
+	}
+	return result.Profile.StatusText, result.Profile.StatusEmoji, nil
+}
 
+// Block is a Block Kit layout block
+type Block struct {
+	Type string      `json:"type"` // "header", "section" or "divider"
+	Text *TextObject `json:"text,omitempty"`
+}
+
+// TextObject is the text of a Block Kit block
+type TextObject struct {
+	Type string `json:"type"` // "plain_text" or "mrkdwn"
+	Text string `json:"text"`
+}
+
+// HeaderBlock returns a header block with plain text
+func HeaderBlock(text string) Block {
+	return Block{Type: "header", Text: &TextObject{Type: "plain_text", Text: text}}
+}
+
+// SectionBlock returns a section block with mrkdwn text
+func SectionBlock(markdown string) Block {
+	return Block{Type: "section", Text: &TextObject{Type: "mrkdwn", Text: markdown}}
+}
+
+// DividerBlock returns a divider block
+func DividerBlock() Block {
+	return Block{Type: "divider"}
+}
+
+// PostMessage posts a message to the configured channel
+// With blocks, text is only shown in notifications and by clients that cannot show blocks
+func (c *Client) PostMessage(text string, blocks ...Block) error {
 	payload := map[string]interface{}{
 		"channel": c.channelID,
 		"text":    text,
 	}
+	if len(blocks) > 0 {
+		payload["blocks"] = blocks
+	}
 
 	jsonData, err := json.Marshal(payload)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message payload: %w", err)
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	if err := c.call("chat.postMessage", "application/json", jsonData, nil); err != nil {
+		return fmt.Errorf("failed to post message: %w", err)
+	}
+
+	log.Debug().
+		Str("channel", c.channelID).
+		Str("text", text).
+		Msg("Message posted to Slack")
+
+	return nil
+}
+
+// ClearStatus clears the user's Slack status
+func (c *Client) ClearStatus() error {
+	return c.SetStatus("", "", 0)
+}
+
+// SetSnooze turns on Do Not Disturb for the given number of minutes
+// Requires the dnd:write user scope
+func (c *Client) SetSnooze(minutes int) error {
+	params := url.Values{"num_minutes": {strconv.Itoa(minutes)}}
+	if err := c.call("dnd.setSnooze", "application/x-www-form-urlencoded", []byte(params.Encode()), nil); err != nil {
+		return fmt.Errorf("failed to set snooze: %w", err)
+	}
+
+	log.Debug().Int("minutes", minutes).Msg("Slack Do Not Disturb turned on")
+	return nil
+}
+
+// EndSnooze turns off Do Not Disturb
+// Ending a snooze that is not active is not an error
+func (c *Client) EndSnooze() error {
+	err := c.call("dnd.endSnooze", "application/x-www-form-urlencoded", nil, nil)
+	if err != nil && !errors.Is(err, ErrSnoozeNotActive) {
+		return fmt.Errorf("failed to end snooze: %w", err)
+	}
+
+	log.Debug().Msg("Slack Do Not Disturb turned off")
+	return nil
+}
+
+// call calls a Slack API method, retrying rate limited calls and, for idempotent methods, gateway errors
+// Rate limited calls are retried after the Retry-After wait, others with an exponential backoff
+// The response of a successful call is decoded into result when it is not nil
+func (c *Client) call(method, contentType string, body []byte, result interface{}) error {
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		err := c.do(method, contentType, body, result)
+
+		var apiErr *APIError
+		if err == nil || attempt == maxAttempts || !errors.As(err, &apiErr) || !apiErr.retryable() {
+			return err
+		}
+
+		wait := backoff
+		if apiErr.RetryAfter > 0 {
+			wait = min(apiErr.RetryAfter, maxRetryWait)
+		}
+		log.Debug().
+			Str("method", method).
+			Int("status", apiErr.StatusCode).
+			Dur("wait", wait).
+			Int("attempt", attempt).
+			Msg("Slack API call failed, retrying")
+		c.sleep(wait)
+		backoff *= 2
+	}
+}
+
+// do makes a single call to a Slack API method
+func (c *Client) do(method, contentType string, body []byte, result interface{}) error {
+	req, err := http.NewRequest("POST", c.baseURL+"/"+method, bytes.NewReader(body))
 	if err != nil {
-		return fmt.Errorf("failed to create message request: %w", err)
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.userToken))
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to post message: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	var result map[string]interface{}
-	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
-		return fmt.Errorf("failed to decode message response: %w", err)
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var status struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
 	}
+	decodeErr := json.Unmarshal(respBody, &status)
 
-	if ok, exists := result["ok"].(bool); !exists || !ok {
-		errorMsg := "unknown error"
-		if errStr, exists := result["error"].(string); exists {
-			errorMsg = errStr
+	// Slack answers rate limited calls with a 429, or with a 200 and the ratelimited error
+	if resp.StatusCode == http.StatusTooManyRequests || status.Error == "ratelimited" {
+		seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+		return &APIError{
+			Method:     method,
+			StatusCode: resp.StatusCode,
+			Code:       "ratelimited",
+			RetryAfter: time.Duration(seconds) * time.Second,
 		}
-		return fmt.Errorf("slack API error: %s", errorMsg)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		apiErr := &APIError{Method: method, StatusCode: resp.StatusCode, Code: status.Error}
+		if decodeErr != nil || status.Error == "" {
+			apiErr.Code = ""
+			apiErr.Body = strings.TrimSpace(string(respBody))
+		}
+		return apiErr
 	}
 
-	log.Debug().
-		Str("channel", c.channelID).
-		Str("text", text).
-		Msg("Message posted to Slack")
+	if decodeErr != nil {
+		return fmt.Errorf("failed to decode response: %w", decodeErr)
+	}
+	if !status.OK {
+		code := status.Error
+		if code == "" {
+			code = "unknown_error"
+		}
+		return &APIError{Method: method, StatusCode: resp.StatusCode, Code: code}
+	}
 
+	if result != nil {
+		if err := json.Unmarshal(respBody, result); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
 	return nil
 }
-
-// ClearStatus clears the user's Slack status
-func (c *Client) ClearStatus() error {
-	return c.SetStatus("", "", 0)
-}
@@ -1,7 +1,13 @@
 package slack
 
 import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestNewClient(t *testing.T) {
@@ -54,4 +60,213 @@ func TestClient_Structure(t *testing.T) {
 			t.Skip("Skipping API call test - requires valid credentials")
 		}
 	})
+
+	t.Run("SetSnooze method exists", func(t *testing.T) {
+		err := client.SetSnooze(10)
+		// Will fail due to invalid token, but method should exist
+		if err == nil {
+			t.Skip("Skipping API call test - requires valid credentials")
+		}
+	})
+
+	t.Run("EndSnooze method exists", func(t *testing.T) {
+		err := client.EndSnooze()
+		// Will fail due to invalid token, but method should exist
+		if err == nil {
+			t.Skip("Skipping API call test - requires valid credentials")
+		}
+	})
+}
+
+func TestBlocks_JSON(t *testing.T) {
+	blocks := []Block{HeaderBlock("Standup"), SectionBlock("*Today*"), DividerBlock()}
+
+	data, err := json.Marshal(blocks)
+	if err != nil {
+		t.Fatalf("Failed to marshal blocks: %v", err)
+	}
+
+	expected := `[{"type":"header","text":{"type":"plain_text","text":"Standup"}},` +
+		`{"type":"section","text":{"type":"mrkdwn","text":"*Today*"}},` +
+		`{"type":"divider"}]`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
+
+// newTestClient returns a client calling handler, recording the waits between retries
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	var waits []time.Duration
+	client := NewClientWithBaseURL(server.URL+"/", "xoxp-test", "C123")
+	client.sleep = func(d time.Duration) { waits = append(waits, d) }
+	return client, &waits
+}
+
+func TestNewClientWithBaseURL(t *testing.T) {
+	var path string
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`{"ok":true}`))
+	})
+
+	if err := client.PostMessage("hi"); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	if path != "/chat.postMessage" {
+		t.Errorf("Expected /chat.postMessage, got %s", path)
+	}
+
+	if NewClient("token", "channel").baseURL != DefaultBaseURL {
+		t.Error("Expected NewClient to call the default base URL")
+	}
+}
+
+func TestClient_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		target  error
+		message string
+	}{
+		{"invalid emoji", http.StatusOK, `{"ok":false,"error":"profile_status_set_failed_not_valid_emoji"}`, ErrInvalidEmoji, "slack API error: profile_status_set_failed_not_valid_emoji"},
+		{"emoji syntax", http.StatusOK, `{"ok":false,"error":"profile_status_set_failed_not_emoji_syntax"}`, ErrInvalidEmoji, ""},
+		{"rate limited", http.StatusTooManyRequests, ``, ErrRateLimited, "slack API error: ratelimited"},
+		{"proxy error", http.StatusForbidden, `Forbidden`, nil, "status 403: Forbidden"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			})
+
+			err := client.SetStatus("Lunch", ":custom:", 60)
+			if err == nil {
+				t.Fatal("Expected an error")
+			}
+			if tt.target != nil && !errors.Is(err, tt.target) {
+				t.Errorf("Expected %v, got %v", tt.target, err)
+			}
+			if tt.message != "" && !strings.Contains(err.Error(), tt.message) {
+				t.Errorf("Expected %q in %q", tt.message, err.Error())
+			}
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) || apiErr.Method != "users.profile.set" || apiErr.StatusCode != tt.status {
+				t.Errorf("Expected an APIError for users.profile.set with status %d, got %#v", tt.status, err)
+			}
+		})
+	}
+}
+
+func TestClient_RetriesRateLimits(t *testing.T) {
+	calls := 0
+	client, waits := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.Header().Set("Retry-After", "3")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"ok":true}`))
+		}
+	})
+
+	if err := client.SetStatus("Lunch", ":fork_and_knife:", 60); err != nil {
+		t.Fatalf("SetStatus failed: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 calls, got %d", calls)
+	}
+	if len(*waits) != 2 || (*waits)[0] != 3*time.Second || (*waits)[1] != 2*retryBackoff {
+		t.Errorf("Expected to wait for Retry-After then back off, got %v", *waits)
+	}
+}
+
+func TestClient_RetriesRateLimitedResponses(t *testing.T) {
+	calls := 0
+	client, waits := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Write([]byte(`{"ok":false,"error":"ratelimited"}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true}`))
+	})
+
+	if err := client.PostMessage("hi"); err != nil {
+		t.Fatalf("PostMessage failed: %v", err)
+	}
+	if calls != 2 || len(*waits) != 1 {
+		t.Errorf("Expected a ratelimited error with status 200 to be retried once, got %d calls", calls)
+	}
+}
+
+func TestClient_DoesNotRepostOnGatewayErrors(t *testing.T) {
+	calls := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	})
+
+	if err := client.PostMessage("hi"); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected a message not to be posted again after a gateway error, got %d calls", calls)
+	}
+}
+
+func TestClient_GivesUpAfterRetries(t *testing.T) {
+	calls := 0
+	client, waits := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	err := client.SetSnooze(10)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Errorf("Expected ErrRateLimited, got %v", err)
+	}
+	if calls != maxAttempts {
+		t.Errorf("Expected %d calls, got %d", maxAttempts, calls)
+	}
+	for _, wait := range *waits {
+		if wait != maxRetryWait {
+			t.Errorf("Expected waits capped at %s, got %v", maxRetryWait, *waits)
+		}
+	}
+}
+
+func TestClient_DoesNotRetryErrors(t *testing.T) {
+	calls := 0
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"ok":false,"error":"invalid_auth"}`))
+	})
+
+	if err := client.PostMessage("hi"); err == nil {
+		t.Fatal("Expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("Expected 1 call, got %d", calls)
+	}
+}
+
+func TestEndSnooze_NotActive(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"ok":false,"error":"snooze_not_active"}`))
+	})
+
+	if err := client.EndSnooze(); err != nil {
+		t.Errorf("Expected no error for an inactive snooze, got %v", err)
+	}
 }
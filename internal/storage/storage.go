@@ -2,6 +2,7 @@ package storage
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
@@ -16,21 +17,55 @@ type Storage struct {
 
 // TimeEntry represents a time entry in the local cache
 type TimeEntry struct {
-	ID               int64     `json:"id"`
-	IssueKey         string    `json:"issue_key"`
-	IssueSummary     string    `json:"issue_summary"`
-	TimeSpentSeconds int       `json:"time_spent_seconds"`
-	TimeSpent        string    `json:"time_spent"`
-	Label            string    `json:"label"`
-	Comment          string    `json:"comment"`
-	Started          time.Time `json:"started"`
-	CreatedAt        time.Time `json:"created_at"`
-	SyncedToJira     bool      `json:"synced_to_jira"`
-	SyncedToTempo    bool      `json:"synced_to_tempo"`
-	JiraWorklogID    *string   `json:"jira_worklog_id"`
-	TempoWorklogID   *string   `json:"tempo_worklog_id"`
+	ID                int64     `json:"id"`
+	IssueKey          string    `json:"issue_key"`
+	IssueSummary      string    `json:"issue_summary"`
+	TimeSpentSeconds  int       `json:"time_spent_seconds"`
+	TimeSpent         string    `json:"time_spent"`
+	Label             string    `json:"label"`
+	Comment           string    `json:"comment"`
+	Started           time.Time `json:"started"`
+	CreatedAt         time.Time `json:"created_at"`
+	SyncedToJira      bool      `json:"synced_to_jira"`
+	SyncedToTempo     bool      `json:"synced_to_tempo"`
+	JiraWorklogID     *string   `json:"jira_worklog_id"`
+	TempoWorklogID    *string   `json:"tempo_worklog_id"`
+	Shortcut          string    `json:"shortcut"`           // Shortcut used to log the entry (empty if logged manually)
+	Account           string    `json:"account"`            // Tempo account key the work is billed to (empty if none)
+	LabelSynced       bool      `json:"label_synced"`       // Whether the label was sent along with the worklog
+	PendingValidation bool      `json:"pending_validation"` // Whether the issue key was not checked against Jira yet (logged offline)
 }
 
+// Timer represents a running timer on an issue
+// Only one timer can run at a time
+type Timer struct {
+	ID           int64     `json:"id"`
+	IssueKey     string    `json:"issue_key"`
+	IssueSummary string    `json:"issue_summary"`
+	Label        string    `json:"label"`
+	Started      time.Time `json:"started"`
+}
+
+// CachedIssue is a Jira issue kept locally for instant, offline task selection
+type CachedIssue struct {
+	Key        string     `json:"key"`
+	Summary    string     `json:"summary"`
+	Status     string     `json:"status"`
+	Project    string     `json:"project"`     // Project key
+	InProgress bool       `json:"in_progress"` // Whether the issue was in the last in-progress refresh
+	LastUsed   *time.Time `json:"last_used"`   // When time was last logged to the issue (nil if never)
+	UpdatedAt  time.Time  `json:"updated_at"`  // When the issue was last fetched from Jira
+}
+
+// ErrTimerRunning is returned when starting a timer while another one is running
+var ErrTimerRunning = errors.New("a timer is already running")
+
+// entryColumns lists the time_entries columns in the order scanned by scanEntries
+const entryColumns = `
+			id, issue_key, issue_summary, time_spent_seconds, time_spent,
+			label, comment, started, created_at, synced_to_jira, synced_to_tempo,
+			jira_worklog_id, tempo_worklog_id, shortcut, account, label_synced, pending_validation`
+
 // NewStorage creates a new storage instance
 func NewStorage(dbPath string) (*Storage, error) {
 	log.Debug().Str("path", dbPath).Msg("Opening database")
@@ -72,19 +107,92 @@ func (s *Storage) initSchema() error {
 		synced_to_jira BOOLEAN NOT NULL DEFAULT 0,
 		synced_to_tempo BOOLEAN NOT NULL DEFAULT 0,
 		jira_worklog_id TEXT,
-		tempo_worklog_id TEXT
+		tempo_worklog_id TEXT,
+		shortcut TEXT NOT NULL DEFAULT '',
+		account TEXT NOT NULL DEFAULT '',
+		label_synced BOOLEAN NOT NULL DEFAULT 0,
+		pending_validation BOOLEAN NOT NULL DEFAULT 0
 	);
 
 	CREATE INDEX IF NOT EXISTS idx_time_entries_issue_key ON time_entries(issue_key);
 	CREATE INDEX IF NOT EXISTS idx_time_entries_started ON time_entries(started);
 	CREATE INDEX IF NOT EXISTS idx_time_entries_created_at ON time_entries(created_at);
 	CREATE INDEX IF NOT EXISTS idx_time_entries_synced ON time_entries(synced_to_jira, synced_to_tempo);
+
+	CREATE TABLE IF NOT EXISTS issues (
+		key TEXT PRIMARY KEY,
+		summary TEXT NOT NULL DEFAULT '',
+		status TEXT NOT NULL DEFAULT '',
+		project TEXT NOT NULL DEFAULT '',
+		in_progress BOOLEAN NOT NULL DEFAULT 0,
+		last_used DATETIME,
+		updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);
+
+	CREATE INDEX IF NOT EXISTS idx_issues_last_used ON issues(last_used);
+
+	CREATE TABLE IF NOT EXISTS timers (
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		issue_key TEXT NOT NULL,
+		issue_summary TEXT NOT NULL DEFAULT '',
+		label TEXT NOT NULL DEFAULT '',
+		started DATETIME NOT NULL
+	);
 	`
 
 	if _, err := s.db.Exec(schema); err != nil {
 		return fmt.Errorf("failed to create schema: %w", err)
 	}
 
+	// Columns added after the initial schema; existing databases are migrated in place
+	if err := s.ensureColumn("time_entries", "shortcut", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := s.ensureColumn("time_entries", "account", "TEXT NOT NULL DEFAULT ''"); err != nil {
+		return err
+	}
+	if err := s.ensureColumn("time_entries", "label_synced", "BOOLEAN NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+	if err := s.ensureColumn("time_entries", "pending_validation", "BOOLEAN NOT NULL DEFAULT 0"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// ensureColumn adds a column to a table if it does not exist yet
+func (s *Storage) ensureColumn(table, column, definition string) error {
+	rows, err := s.db.Query(fmt.Sprintf("PRAGMA table_info(%s)", table))
+	if err != nil {
+		return fmt.Errorf("failed to inspect table %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			cid       int
+			name      string
+			colType   string
+			notNull   bool
+			dfltValue sql.NullString
+			pk        int
+		)
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return fmt.Errorf("failed to scan table info: %w", err)
+		}
+		if name == column {
+			return nil
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating table info: %w", err)
+	}
+
+	log.Debug().Str("table", table).Str("column", column).Msg("Adding missing column")
+	if _, err := s.db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, column, definition)); err != nil {
+		return fmt.Errorf("failed to add column %s.%s: %w", table, column, err)
+	}
 	return nil
 }
 
@@ -100,8 +208,9 @@ func (s *Storage) AddTimeEntry(entry *TimeEntry) error {
 		INSERT INTO time_entries (
 			issue_key, issue_summary, time_spent_seconds, time_spent,
 			label, comment, started, synced_to_jira, synced_to_tempo,
-			jira_worklog_id, tempo_worklog_id
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			jira_worklog_id, tempo_worklog_id, shortcut, account, label_synced,
+			pending_validation
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := s.db.Exec(
@@ -117,6 +226,10 @@ func (s *Storage) AddTimeEntry(entry *TimeEntry) error {
 		entry.SyncedToTempo,
 		entry.JiraWorklogID,
 		entry.TempoWorklogID,
+		entry.Shortcut,
+		entry.Account,
+		entry.LabelSynced,
+		entry.PendingValidation,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert time entry: %w", err)
@@ -138,19 +251,39 @@ func (s *Storage) UpdateTimeEntry(entry *TimeEntry) error {
 
 	query := `
 		UPDATE time_entries SET
+			issue_key = ?,
+			issue_summary = ?,
+			time_spent_seconds = ?,
+			time_spent = ?,
+			label = ?,
+			comment = ?,
+			started = ?,
 			synced_to_jira = ?,
 			synced_to_tempo = ?,
 			jira_worklog_id = ?,
-			tempo_worklog_id = ?
+			tempo_worklog_id = ?,
+			account = ?,
+			label_synced = ?,
+			pending_validation = ?
 		WHERE id = ?
 	`
 
 	_, err := s.db.Exec(
 		query,
+		entry.IssueKey,
+		entry.IssueSummary,
+		entry.TimeSpentSeconds,
+		entry.TimeSpent,
+		entry.Label,
+		entry.Comment,
+		entry.Started,
 		entry.SyncedToJira,
 		entry.SyncedToTempo,
 		entry.JiraWorklogID,
 		entry.TempoWorklogID,
+		entry.Account,
+		entry.LabelSynced,
+		entry.PendingValidation,
 		entry.ID,
 	)
 	if err != nil {
@@ -161,6 +294,40 @@ func (s *Storage) UpdateTimeEntry(entry *TimeEntry) error {
 	return nil
 }
 
+// DeleteTimeEntry removes a time entry from the local cache
+func (s *Storage) DeleteTimeEntry(id int64) error {
+	result, err := s.db.Exec(`DELETE FROM time_entries WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete time entry: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("time entry %d not found", id)
+	}
+
+	log.Info().Int64("id", id).Msg("Time entry deleted from local cache")
+	return nil
+}
+
+// GetTimeEntry retrieves a time entry by ID, or nil if it does not exist
+func (s *Storage) GetTimeEntry(id int64) (*TimeEntry, error) {
+	rows, err := s.db.Query(`SELECT `+entryColumns+` FROM time_entries WHERE id = ?`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entry: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntries(rows)
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
 // GetTodayEntries retrieves all time entries for today
 func (s *Storage) GetTodayEntries() ([]TimeEntry, error) {
 	log.Debug().Msg("Fetching today's entries")
@@ -170,10 +337,7 @@ func (s *Storage) GetTodayEntries() ([]TimeEntry, error) {
 	endOfDay := startOfDay.AddDate(0, 0, 1)
 
 	query := `
-		SELECT 
-			id, issue_key, issue_summary, time_spent_seconds, time_spent,
-			label, comment, started, created_at, synced_to_jira, synced_to_tempo,
-			jira_worklog_id, tempo_worklog_id
+		SELECT ` + entryColumns + `
 		FROM time_entries
 		WHERE started >= ? AND started < ?
 		ORDER BY started DESC
@@ -185,35 +349,38 @@ func (s *Storage) GetTodayEntries() ([]TimeEntry, error) {
 	}
 	defer rows.Close()
 
-	var entries []TimeEntry
-	for rows.Next() {
-		var entry TimeEntry
-		err := rows.Scan(
-			&entry.ID,
-			&entry.IssueKey,
-			&entry.IssueSummary,
-			&entry.TimeSpentSeconds,
-			&entry.TimeSpent,
-			&entry.Label,
-			&entry.Comment,
-			&entry.Started,
-			&entry.CreatedAt,
-			&entry.SyncedToJira,
-			&entry.SyncedToTempo,
-			&entry.JiraWorklogID,
-			&entry.TempoWorklogID,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("failed to scan time entry: %w", err)
-		}
-		entries = append(entries, entry)
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating time entries: %w", err)
+	log.Debug().Int("count", len(entries)).Msg("Retrieved today's entries")
+	return entries, nil
+}
+
+// GetEntriesBetween retrieves all time entries started in the interval [from, to)
+func (s *Storage) GetEntriesBetween(from, to time.Time) ([]TimeEntry, error) {
+	log.Debug().Time("from", from).Time("to", to).Msg("Fetching entries in range")
+
+	query := `
+		SELECT ` + entryColumns + `
+		FROM time_entries
+		WHERE started >= ? AND started < ?
+		ORDER BY started ASC
+	`
+
+	rows, err := s.db.Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query time entries: %w", err)
 	}
+	defer rows.Close()
 
-	log.Debug().Int("count", len(entries)).Msg("Retrieved today's entries")
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Int("count", len(entries)).Msg("Retrieved entries in range")
 	return entries, nil
 }
 
@@ -222,10 +389,7 @@ func (s *Storage) GetUnsyncedEntries() ([]TimeEntry, error) {
 	log.Debug().Msg("Fetching unsynced entries")
 
 	query := `
-		SELECT 
-			id, issue_key, issue_summary, time_spent_seconds, time_spent,
-			label, comment, started, created_at, synced_to_jira, synced_to_tempo,
-			jira_worklog_id, tempo_worklog_id
+		SELECT ` + entryColumns + `
 		FROM time_entries
 		WHERE synced_to_jira = 0 OR synced_to_tempo = 0
 		ORDER BY started ASC
@@ -237,6 +401,62 @@ func (s *Storage) GetUnsyncedEntries() ([]TimeEntry, error) {
 	}
 	defer rows.Close()
 
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Int("count", len(entries)).Msg("Retrieved unsynced entries")
+	return entries, nil
+}
+
+// GetLabelUnsyncedEntries retrieves entries logged to Jira whose label was not sent with the worklog
+func (s *Storage) GetLabelUnsyncedEntries() ([]TimeEntry, error) {
+	log.Debug().Msg("Fetching entries with unsynced labels")
+
+	query := `
+		SELECT ` + entryColumns + `
+		FROM time_entries
+		WHERE synced_to_jira = 1 AND label_synced = 0 AND label != '' AND jira_worklog_id IS NOT NULL
+		ORDER BY started ASC
+	`
+
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query entries with unsynced labels: %w", err)
+	}
+	defer rows.Close()
+
+	entries, err := scanEntries(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug().Int("count", len(entries)).Msg("Retrieved entries with unsynced labels")
+	return entries, nil
+}
+
+// HasShortcutEntry reports whether an entry was logged with the given shortcut, or on its task, on the given day
+func (s *Storage) HasShortcutEntry(shortcut, issueKey string, day time.Time) (bool, error) {
+	startOfDay := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	endOfDay := startOfDay.AddDate(0, 0, 1)
+
+	var count int
+	query := `
+		SELECT COUNT(*)
+		FROM time_entries
+		WHERE (shortcut = ? OR issue_key = ?) AND started >= ? AND started < ?
+	`
+
+	if err := s.db.QueryRow(query, shortcut, issueKey, startOfDay, endOfDay).Scan(&count); err != nil {
+		return false, fmt.Errorf("failed to check shortcut entries: %w", err)
+	}
+
+	return count > 0, nil
+}
+
+// scanEntries reads time entries selected with entryColumns
+func scanEntries(rows *sql.Rows) ([]TimeEntry, error) {
 	var entries []TimeEntry
 	for rows.Next() {
 		var entry TimeEntry
@@ -254,6 +474,10 @@ func (s *Storage) GetUnsyncedEntries() ([]TimeEntry, error) {
 			&entry.SyncedToTempo,
 			&entry.JiraWorklogID,
 			&entry.TempoWorklogID,
+			&entry.Shortcut,
+			&entry.Account,
+			&entry.LabelSynced,
+			&entry.PendingValidation,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan time entry: %w", err)
@@ -262,10 +486,9 @@ func (s *Storage) GetUnsyncedEntries() ([]TimeEntry, error) {
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("error iterating unsynced entries: %w", err)
+		return nil, fmt.Errorf("error iterating time entries: %w", err)
 	}
 
-	log.Debug().Int("count", len(entries)).Msg("Retrieved unsynced entries")
 	return entries, nil
 }
 
@@ -293,3 +516,242 @@ func (s *Storage) GetTodayTotalSeconds() (int, error) {
 
 	return int(total.Int64), nil
 }
+
+// LatestEntryID returns the ID of the most recently added time entry, or 0 when there are none
+// Comparing it between calls tells whether new entries were added, e.g. by another process
+func (s *Storage) LatestEntryID() (int64, error) {
+	var id int64
+	if err := s.db.QueryRow(`SELECT COALESCE(MAX(id), 0) FROM time_entries`).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get latest entry: %w", err)
+	}
+	return id, nil
+}
+
+// StartTimer starts a timer on an issue
+// Returns ErrTimerRunning if a timer is already running
+func (s *Storage) StartTimer(timer *Timer) error {
+	active, err := s.GetActiveTimer()
+	if err != nil {
+		return err
+	}
+	if active != nil {
+		return ErrTimerRunning
+	}
+
+	query := `INSERT INTO timers (issue_key, issue_summary, label, started) VALUES (?, ?, ?, ?)`
+	result, err := s.db.Exec(query, timer.IssueKey, timer.IssueSummary, timer.Label, timer.Started)
+	if err != nil {
+		return fmt.Errorf("failed to start timer: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get last insert ID: %w", err)
+	}
+	timer.ID = id
+
+	log.Debug().Int64("id", id).Str("issue", timer.IssueKey).Msg("Timer started")
+	return nil
+}
+
+// GetActiveTimer returns the running timer, or nil if no timer is running
+func (s *Storage) GetActiveTimer() (*Timer, error) {
+	query := `SELECT id, issue_key, issue_summary, label, started FROM timers ORDER BY started DESC LIMIT 1`
+
+	var timer Timer
+	err := s.db.QueryRow(query).Scan(&timer.ID, &timer.IssueKey, &timer.IssueSummary, &timer.Label, &timer.Started)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get active timer: %w", err)
+	}
+
+	return &timer, nil
+}
+
+// StopTimer removes the running timer and returns it, or nil if no timer is running
+func (s *Storage) StopTimer() (*Timer, error) {
+	timer, err := s.GetActiveTimer()
+	if err != nil || timer == nil {
+		return timer, err
+	}
+
+	if _, err := s.db.Exec(`DELETE FROM timers WHERE id = ?`, timer.ID); err != nil {
+		return nil, fmt.Errorf("failed to stop timer: %w", err)
+	}
+
+	log.Debug().Int64("id", timer.ID).Str("issue", timer.IssueKey).Msg("Timer stopped")
+	return timer, nil
+}
+
+// issueColumns lists the issues columns in the order scanned by scanIssues
+const issueColumns = `key, summary, status, project, in_progress, last_used, updated_at`
+
+// SetInProgressIssues replaces the cached in-progress issues, keeping when each issue was last used
+func (s *Storage) SetInProgressIssues(issues []CachedIssue) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`UPDATE issues SET in_progress = 0`); err != nil {
+		return fmt.Errorf("failed to reset in-progress issues: %w", err)
+	}
+
+	query := `
+		INSERT INTO issues (key, summary, status, project, in_progress, updated_at)
+		VALUES (?, ?, ?, ?, 1, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			summary = excluded.summary,
+			status = excluded.status,
+			project = excluded.project,
+			in_progress = 1,
+			updated_at = excluded.updated_at
+	`
+	now := time.Now()
+	for _, issue := range issues {
+		if _, err := tx.Exec(query, issue.Key, issue.Summary, issue.Status, issue.Project, now); err != nil {
+			return fmt.Errorf("failed to cache issue %s: %w", issue.Key, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit issue cache: %w", err)
+	}
+
+	log.Debug().Int("count", len(issues)).Msg("Cached in-progress issues")
+	return nil
+}
+
+// SaveIssue caches an issue fetched from Jira, keeping its in-progress flag and last use
+func (s *Storage) SaveIssue(issue CachedIssue) error {
+	query := `
+		INSERT INTO issues (key, summary, status, project, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			summary = excluded.summary,
+			status = excluded.status,
+			project = excluded.project,
+			updated_at = excluded.updated_at
+	`
+	if _, err := s.db.Exec(query, issue.Key, issue.Summary, issue.Status, issue.Project, time.Now()); err != nil {
+		return fmt.Errorf("failed to cache issue %s: %w", issue.Key, err)
+	}
+	return nil
+}
+
+// MarkIssueUsed records that time was logged to an issue, caching it if needed
+func (s *Storage) MarkIssueUsed(key, summary, project string, at time.Time) error {
+	query := `
+		INSERT INTO issues (key, summary, project, last_used, updated_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(key) DO UPDATE SET
+			summary = CASE WHEN excluded.summary != '' THEN excluded.summary ELSE issues.summary END,
+			last_used = excluded.last_used
+	`
+	if _, err := s.db.Exec(query, key, summary, project, at, at); err != nil {
+		return fmt.Errorf("failed to mark issue %s as used: %w", key, err)
+	}
+	return nil
+}
+
+// GetCachedIssue returns a cached issue by key, or nil if it is not cached
+func (s *Storage) GetCachedIssue(key string) (*CachedIssue, error) {
+	rows, err := s.db.Query(`SELECT `+issueColumns+` FROM issues WHERE key = ?`, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cached issue: %w", err)
+	}
+	defer rows.Close()
+
+	issues, err := scanIssues(rows)
+	if err != nil || len(issues) == 0 {
+		return nil, err
+	}
+	return &issues[0], nil
+}
+
+// GetInProgressCachedIssues returns the cached in-progress issues, most recently used first
+func (s *Storage) GetInProgressCachedIssues() ([]CachedIssue, error) {
+	query := `
+		SELECT ` + issueColumns + `
+		FROM issues
+		WHERE in_progress = 1
+		ORDER BY last_used IS NULL, last_used DESC, key ASC
+	`
+	rows, err := s.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cached issues: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIssues(rows)
+}
+
+// GetRecentIssues returns up to limit cached issues that time was logged to, most recent first
+func (s *Storage) GetRecentIssues(limit int) ([]CachedIssue, error) {
+	query := `
+		SELECT ` + issueColumns + `
+		FROM issues
+		WHERE last_used IS NOT NULL
+		ORDER BY last_used DESC
+		LIMIT ?
+	`
+	rows, err := s.db.Query(query, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recent issues: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIssues(rows)
+}
+
+// SearchCachedIssues returns up to limit cached issues whose key or summary contains term
+func (s *Storage) SearchCachedIssues(term string, limit int) ([]CachedIssue, error) {
+	pattern := "%" + term + "%"
+	query := `
+		SELECT ` + issueColumns + `
+		FROM issues
+		WHERE key LIKE ? OR summary LIKE ?
+		ORDER BY last_used IS NULL, last_used DESC, key ASC
+		LIMIT ?
+	`
+	rows, err := s.db.Query(query, pattern, pattern, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search cached issues: %w", err)
+	}
+	defer rows.Close()
+
+	return scanIssues(rows)
+}
+
+// scanIssues scans issue rows selected with issueColumns
+func scanIssues(rows *sql.Rows) ([]CachedIssue, error) {
+	var issues []CachedIssue
+	for rows.Next() {
+		var issue CachedIssue
+		var lastUsed sql.NullTime
+		if err := rows.Scan(
+			&issue.Key,
+			&issue.Summary,
+			&issue.Status,
+			&issue.Project,
+			&issue.InProgress,
+			&lastUsed,
+			&issue.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan cached issue: %w", err)
+		}
+		if lastUsed.Valid {
+			issue.LastUsed = &lastUsed.Time
+		}
+		issues = append(issues, issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating cached issues: %w", err)
+	}
+
+	return issues, nil
+}
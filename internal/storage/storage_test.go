@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"database/sql"
+	"path/filepath"
 	"testing"
 	"time"
 )
@@ -82,6 +84,67 @@ func TestUpdateTimeEntry(t *testing.T) {
 	if err != nil {
 		t.Fatalf("failed to update time entry: %v", err)
 	}
+
+	// Update editable fields
+	entry.TimeSpentSeconds = 5400
+	entry.TimeSpent = "1h 30m"
+	entry.Comment = "Edited"
+	entry.Label = "meeting"
+
+	if err := store.UpdateTimeEntry(entry); err != nil {
+		t.Fatalf("failed to update time entry: %v", err)
+	}
+
+	entries, err := store.GetTodayEntries()
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	got := entries[0]
+	if got.TimeSpentSeconds != 5400 || got.TimeSpent != "1h 30m" || got.Comment != "Edited" || got.Label != "meeting" {
+		t.Errorf("editable fields not updated: %+v", got)
+	}
+	if !got.SyncedToJira || got.JiraWorklogID == nil || *got.JiraWorklogID != "12345" {
+		t.Errorf("sync fields not preserved: %+v", got)
+	}
+}
+
+func TestDeleteTimeEntry(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	entry := &TimeEntry{
+		IssueKey:         "PROJ-123",
+		IssueSummary:     "Test issue",
+		TimeSpentSeconds: 3600,
+		TimeSpent:        "1h",
+		Label:            "development",
+		Started:          time.Now(),
+	}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add time entry: %v", err)
+	}
+
+	if err := store.DeleteTimeEntry(entry.ID); err != nil {
+		t.Fatalf("failed to delete time entry: %v", err)
+	}
+
+	entries, err := store.GetTodayEntries()
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("expected no entries after delete, got %d", len(entries))
+	}
+
+	if err := store.DeleteTimeEntry(entry.ID); err == nil {
+		t.Error("expected error when deleting a missing entry")
+	}
 }
 
 func TestGetTodayEntries(t *testing.T) {
@@ -266,3 +329,378 @@ func TestClose(t *testing.T) {
 		t.Errorf("failed to close storage: %v", err)
 	}
 }
+
+func TestHasShortcutEntry(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	now := time.Now()
+	entry := &TimeEntry{
+		IssueKey:         "PROJ-123",
+		IssueSummary:     "Daily standup",
+		TimeSpentSeconds: 1800,
+		TimeSpent:        "30m",
+		Label:            "meeting",
+		Started:          now,
+		Shortcut:         "daily",
+	}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add time entry: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		shortcut string
+		issueKey string
+		day      time.Time
+		expected bool
+	}{
+		{"logged today", "daily", "PROJ-9", now, true},
+		{"other shortcut", "standup", "PROJ-9", now, false},
+		{"task logged today", "standup", "PROJ-123", now, true},
+		{"other day", "daily", "PROJ-123", now.AddDate(0, 0, -1), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found, err := store.HasShortcutEntry(tt.shortcut, tt.issueKey, tt.day)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if found != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, found)
+			}
+		})
+	}
+}
+
+func TestNewStorage_MigratesExistingDatabase(t *testing.T) {
+	dbPath := filepath.Join(t.TempDir(), "tasklog.db")
+
+	// Create a database with the original schema (without newer columns)
+	db, err := sql.Open("sqlite", dbPath)
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	_, err = db.Exec(`
+		CREATE TABLE time_entries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			issue_key TEXT NOT NULL,
+			issue_summary TEXT NOT NULL,
+			time_spent_seconds INTEGER NOT NULL,
+			time_spent TEXT NOT NULL,
+			label TEXT NOT NULL,
+			comment TEXT,
+			started DATETIME NOT NULL,
+			created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			synced_to_jira BOOLEAN NOT NULL DEFAULT 0,
+			synced_to_tempo BOOLEAN NOT NULL DEFAULT 0,
+			jira_worklog_id TEXT,
+			tempo_worklog_id TEXT
+		);
+		INSERT INTO time_entries (issue_key, issue_summary, time_spent_seconds, time_spent, label, comment, started)
+		VALUES ('PROJ-1', 'Old entry', 3600, '1h', 'development', '', datetime('now'));
+	`)
+	if err != nil {
+		t.Fatalf("failed to create legacy schema: %v", err)
+	}
+	_ = db.Close()
+
+	store, err := NewStorage(dbPath)
+	if err != nil {
+		t.Fatalf("failed to open legacy database: %v", err)
+	}
+	defer store.Close()
+
+	entries, err := store.GetUnsyncedEntries()
+	if err != nil {
+		t.Fatalf("failed to read migrated entries: %v", err)
+	}
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 entry, got %d", len(entries))
+	}
+	if entries[0].Shortcut != "" {
+		t.Errorf("expected empty shortcut for legacy entry, got %q", entries[0].Shortcut)
+	}
+	if entries[0].Account != "" {
+		t.Errorf("expected empty account for legacy entry, got %q", entries[0].Account)
+	}
+	if entries[0].PendingValidation {
+		t.Error("expected legacy entry not to be pending validation")
+	}
+}
+
+func TestTimeEntryAccount(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	entry := &TimeEntry{
+		IssueKey:         "PROJ-123",
+		IssueSummary:     "Client work",
+		TimeSpentSeconds: 3600,
+		TimeSpent:        "1h",
+		Label:            "development",
+		Started:          time.Now(),
+		Account:          "ACME",
+	}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+
+	entry.Account = "INTERNAL"
+	if err := store.UpdateTimeEntry(entry); err != nil {
+		t.Fatalf("failed to update entry: %v", err)
+	}
+
+	entries, err := store.GetTodayEntries()
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Account != "INTERNAL" {
+		t.Errorf("expected the updated account to be stored, got %+v", entries)
+	}
+}
+
+func TestGetEntriesBetween(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	day := time.Date(2025, 1, 15, 0, 0, 0, 0, time.Local)
+	for _, started := range []time.Time{
+		day.Add(-time.Minute),   // previous day
+		day.Add(9 * time.Hour),  // in range
+		day.Add(15 * time.Hour), // in range
+		day.Add(24 * time.Hour), // next day (exclusive)
+	} {
+		entry := &TimeEntry{
+			IssueKey:         "PROJ-123",
+			IssueSummary:     "Test issue",
+			TimeSpentSeconds: 1800,
+			TimeSpent:        "30m",
+			Label:            "development",
+			Started:          started,
+		}
+		if err := store.AddTimeEntry(entry); err != nil {
+			t.Fatalf("failed to add time entry: %v", err)
+		}
+	}
+
+	entries, err := store.GetEntriesBetween(day, day.AddDate(0, 0, 1))
+	if err != nil {
+		t.Fatalf("failed to get entries: %v", err)
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries, got %d", len(entries))
+	}
+	if !entries[0].Started.Before(entries[1].Started) {
+		t.Error("expected entries in chronological order")
+	}
+}
+
+func TestTimer_StartStop(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	active, err := store.GetActiveTimer()
+	if err != nil {
+		t.Fatalf("failed to get active timer: %v", err)
+	}
+	if active != nil {
+		t.Fatalf("expected no active timer, got %+v", active)
+	}
+
+	started := time.Now().Add(-45 * time.Minute)
+	timer := &Timer{IssueKey: "PROJ-123", IssueSummary: "Test issue", Label: "development", Started: started}
+	if err := store.StartTimer(timer); err != nil {
+		t.Fatalf("failed to start timer: %v", err)
+	}
+	if timer.ID == 0 {
+		t.Error("expected timer ID to be set")
+	}
+
+	if err := store.StartTimer(&Timer{IssueKey: "PROJ-456", Started: time.Now()}); err != ErrTimerRunning {
+		t.Errorf("expected ErrTimerRunning, got %v", err)
+	}
+
+	active, err = store.GetActiveTimer()
+	if err != nil {
+		t.Fatalf("failed to get active timer: %v", err)
+	}
+	if active == nil || active.IssueKey != "PROJ-123" || active.Label != "development" {
+		t.Fatalf("unexpected active timer: %+v", active)
+	}
+	if !active.Started.Equal(started) {
+		t.Errorf("expected started %v, got %v", started, active.Started)
+	}
+
+	stopped, err := store.StopTimer()
+	if err != nil {
+		t.Fatalf("failed to stop timer: %v", err)
+	}
+	if stopped == nil || stopped.IssueKey != "PROJ-123" {
+		t.Fatalf("unexpected stopped timer: %+v", stopped)
+	}
+
+	stopped, err = store.StopTimer()
+	if err != nil {
+		t.Fatalf("failed to stop timer: %v", err)
+	}
+	if stopped != nil {
+		t.Errorf("expected no timer to stop, got %+v", stopped)
+	}
+}
+
+func TestGetLabelUnsyncedEntries(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	worklogID := "100"
+	entries := []*TimeEntry{
+		{IssueKey: "PROJ-1", Label: "development", SyncedToJira: true, JiraWorklogID: &worklogID},
+		{IssueKey: "PROJ-2", Label: "development", SyncedToJira: true, JiraWorklogID: &worklogID, LabelSynced: true},
+		{IssueKey: "PROJ-3", Label: "development"}, // Not logged to Jira yet
+		{IssueKey: "PROJ-4", Label: "", SyncedToJira: true, JiraWorklogID: &worklogID},
+	}
+	for _, entry := range entries {
+		entry.IssueSummary = "Task"
+		entry.TimeSpentSeconds = 3600
+		entry.TimeSpent = "1h"
+		entry.Started = time.Now()
+		if err := store.AddTimeEntry(entry); err != nil {
+			t.Fatalf("failed to add entry: %v", err)
+		}
+	}
+
+	unsynced, err := store.GetLabelUnsyncedEntries()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(unsynced) != 1 || unsynced[0].IssueKey != "PROJ-1" {
+		t.Errorf("expected only PROJ-1, got %+v", unsynced)
+	}
+}
+
+func TestIssueCache(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	used := time.Now().Add(-time.Hour)
+	if err := store.MarkIssueUsed("PROJ-1", "Old summary", "PROJ", used); err != nil {
+		t.Fatalf("failed to mark issue used: %v", err)
+	}
+	if err := store.SetInProgressIssues([]CachedIssue{
+		{Key: "PROJ-1", Summary: "First", Status: "In Progress", Project: "PROJ"},
+		{Key: "PROJ-2", Summary: "Second", Status: "In Progress", Project: "PROJ"},
+	}); err != nil {
+		t.Fatalf("failed to set in-progress issues: %v", err)
+	}
+
+	inProgress, err := store.GetInProgressCachedIssues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inProgress) != 2 || inProgress[0].Key != "PROJ-1" || inProgress[1].Key != "PROJ-2" {
+		t.Fatalf("expected PROJ-1 (recently used) then PROJ-2, got %+v", inProgress)
+	}
+	if inProgress[0].Summary != "First" || inProgress[0].LastUsed == nil {
+		t.Errorf("expected refreshed summary and kept last use, got %+v", inProgress[0])
+	}
+
+	// A later refresh drops issues that are no longer in progress
+	if err := store.SetInProgressIssues([]CachedIssue{{Key: "PROJ-2", Summary: "Second", Project: "PROJ"}}); err != nil {
+		t.Fatalf("failed to set in-progress issues: %v", err)
+	}
+	inProgress, err = store.GetInProgressCachedIssues()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(inProgress) != 1 || inProgress[0].Key != "PROJ-2" {
+		t.Errorf("expected only PROJ-2 in progress, got %+v", inProgress)
+	}
+
+	recent, err := store.GetRecentIssues(5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(recent) != 1 || recent[0].Key != "PROJ-1" {
+		t.Errorf("expected PROJ-1 as recent, got %+v", recent)
+	}
+
+	found, err := store.SearchCachedIssues("sec", 10)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(found) != 1 || found[0].Key != "PROJ-2" {
+		t.Errorf("expected search to find PROJ-2, got %+v", found)
+	}
+
+	missing, err := store.GetCachedIssue("PROJ-9")
+	if err != nil || missing != nil {
+		t.Errorf("expected nil for uncached issue, got %+v (%v)", missing, err)
+	}
+}
+
+func TestLatestEntryID(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	id, err := store.LatestEntryID()
+	if err != nil || id != 0 {
+		t.Fatalf("expected 0 for an empty database, got %d (%v)", id, err)
+	}
+
+	entry := &TimeEntry{IssueKey: "PROJ-1", IssueSummary: "Task", TimeSpentSeconds: 3600, TimeSpent: "1h", Started: time.Now()}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+
+	id, err = store.LatestEntryID()
+	if err != nil || id != entry.ID {
+		t.Errorf("expected %d, got %d (%v)", entry.ID, id, err)
+	}
+}
+
+func TestGetTimeEntry(t *testing.T) {
+	store, err := NewStorage(":memory:")
+	if err != nil {
+		t.Fatalf("failed to create storage: %v", err)
+	}
+	defer store.Close()
+
+	entry := &TimeEntry{IssueKey: "PROJ-1", IssueSummary: "Task", TimeSpentSeconds: 1800, TimeSpent: "30m", Started: time.Now()}
+	if err := store.AddTimeEntry(entry); err != nil {
+		t.Fatalf("failed to add entry: %v", err)
+	}
+
+	got, err := store.GetTimeEntry(entry.ID)
+	if err != nil || got == nil || got.IssueKey != "PROJ-1" {
+		t.Fatalf("expected PROJ-1, got %+v (%v)", got, err)
+	}
+
+	missing, err := store.GetTimeEntry(entry.ID + 1)
+	if err != nil || missing != nil {
+		t.Errorf("expected nil for a missing entry, got %+v (%v)", missing, err)
+	}
+}
@@ -5,14 +5,28 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/rs/zerolog/log"
 )
 
+// defaultBaseURL is the Tempo Cloud API v4 base URL
+const defaultBaseURL = "https://api.tempo.io/4"
+
+const (
+	// maxPages guards against servers that never report the last page
+	maxPages = 100
+	// worklogPageSize is the number of worklogs requested per page (the API maximum)
+	worklogPageSize = 1000
+	// dateFormat is the date format used by the Tempo API
+	dateFormat = "2006-01-02"
+)
+
 // Client represents a Tempo API client
 type Client struct {
+	baseURL    string
 	apiToken   string
 	httpClient *http.Client
 }
@@ -20,6 +34,7 @@ type Client struct {
 // NewClient creates a new Tempo API client
 func NewClient(apiToken string) *Client {
 	return &Client{
+		baseURL:  defaultBaseURL,
 		apiToken: apiToken,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
@@ -27,9 +42,14 @@ func NewClient(apiToken string) *Client {
 	}
 }
 
+// SetBaseURL overrides the API base URL (e.g., for a regional endpoint or a test server)
+func (c *Client) SetBaseURL(baseURL string) {
+	c.baseURL = strings.TrimSuffix(baseURL, "/")
+}
+
 // WorklogRequest represents a request to create a worklog in Tempo
 type WorklogRequest struct {
-	IssueID          string             `json:"issueId"` // Numeric issue ID (required in v4)
+	IssueID          int64              `json:"issueId"` // Numeric issue ID (required in v4, sent as a JSON number)
 	TimeSpentSeconds int                `json:"timeSpentSeconds"`
 	StartDate        string             `json:"startDate"` // Format: YYYY-MM-DD
 	StartTime        string             `json:"startTime"` // Format: HH:MM:SS
@@ -38,7 +58,7 @@ type WorklogRequest struct {
 	Attributes       []WorklogAttribute `json:"attributes,omitempty"`
 }
 
-// WorklogAttribute represents a Tempo worklog attribute (for labels)
+// WorklogAttribute represents a Tempo work attribute value (e.g., the label's work type)
 type WorklogAttribute struct {
 	Key   string `json:"key"`
 	Value string `json:"value"`
@@ -57,42 +77,33 @@ type WorklogResponse struct {
 	Author           struct {
 		AccountID string `json:"accountId"`
 	} `json:"author"`
+	Issue struct {
+		ID int64 `json:"id"`
+	} `json:"issue"`
+	Attributes struct {
+		Values []WorklogAttribute `json:"values"`
+	} `json:"attributes"`
 }
 
 // AddWorklog adds a worklog entry to Tempo
-func (c *Client) AddWorklog(issueID, authorAccountID string, timeSpentSeconds int, started time.Time, label, description string) (*WorklogResponse, error) {
+// Tempo creates the matching Jira worklog itself
+func (c *Client) AddWorklog(issueID int64, authorAccountID string, timeSpentSeconds int, started time.Time, description string, attributes []WorklogAttribute) (*WorklogResponse, error) {
 	log.Debug().
-		Str("issue_id", issueID).
+		Int64("issue_id", issueID).
 		Int("seconds", timeSpentSeconds).
-		Str("label", label).
+		Int("attributes", len(attributes)).
 		Msg("Adding worklog to Tempo")
 
-	// Use Tempo API v4 endpoint
-	endpoint := "https://api.tempo.io/4/worklogs"
-
-	// Format date and time for Tempo
-	startDate := started.Format("2006-01-02")
-	startTime := started.Format("15:04:05")
+	endpoint := fmt.Sprintf("%s/worklogs", c.baseURL)
 
 	payload := WorklogRequest{
 		IssueID:          issueID,
 		AuthorAccountID:  authorAccountID,
 		TimeSpentSeconds: timeSpentSeconds,
-		StartDate:        startDate,
-		StartTime:        startTime,
+		StartDate:        started.Format("2006-01-02"),
+		StartTime:        started.Format("15:04:05"),
 		Description:      description,
-	}
-
-	// Add label as an attribute if provided
-	// Note: The attribute key depends on your Tempo configuration
-	// You may need to adjust this based on your Tempo setup
-	if label != "" {
-		// Construct description with label
-		if payload.Description != "" {
-			payload.Description = fmt.Sprintf("[%s] %s", label, payload.Description)
-		} else {
-			payload.Description = fmt.Sprintf("[%s]", label)
-		}
+		Attributes:       attributes,
 	}
 
 	var response WorklogResponse
@@ -101,7 +112,7 @@ func (c *Client) AddWorklog(issueID, authorAccountID string, timeSpentSeconds in
 	}
 
 	log.Info().
-		Str("issue_id", issueID).
+		Int64("issue_id", issueID).
 		Int("tempo_id", response.TempoWorklogID).
 		Str("time", formatSeconds(timeSpentSeconds)).
 		Msg("Worklog added to Tempo successfully")
@@ -109,6 +120,75 @@ func (c *Client) AddWorklog(issueID, authorAccountID string, timeSpentSeconds in
 	return &response, nil
 }
 
+// GetTempoWorklogID returns the ID of the Tempo worklog that Tempo created for a Jira worklog
+// Returns 0 if Tempo has not picked up the Jira worklog yet
+func (c *Client) GetTempoWorklogID(jiraWorklogID int64) (int, error) {
+	endpoint := fmt.Sprintf("%s/worklogs/jira-to-tempo", c.baseURL)
+
+	payload := map[string][]int64{"jiraWorklogIds": {jiraWorklogID}}
+
+	var response struct {
+		Results []struct {
+			JiraWorklogID  int64 `json:"jiraWorklogId"`
+			TempoWorklogID int   `json:"tempoWorklogId"`
+		} `json:"results"`
+	}
+	if err := c.doRequest("POST", endpoint, payload, &response); err != nil {
+		return 0, fmt.Errorf("failed to map Jira worklog to Tempo: %w", err)
+	}
+	for _, result := range response.Results {
+		if result.JiraWorklogID == jiraWorklogID {
+			return result.TempoWorklogID, nil
+		}
+	}
+	return 0, nil
+}
+
+// SetWorklogAttributes sets work attributes on an existing Tempo worklog, keeping its other attributes
+func (c *Client) SetWorklogAttributes(tempoWorklogID int, attributes []WorklogAttribute) error {
+	log.Debug().
+		Int("tempo_id", tempoWorklogID).
+		Int("attributes", len(attributes)).
+		Msg("Updating Tempo worklog attributes")
+
+	endpoint := fmt.Sprintf("%s/worklogs/%d", c.baseURL, tempoWorklogID)
+
+	// Updates replace the whole worklog, so start from the current one
+	var current WorklogResponse
+	if err := c.doRequest("GET", endpoint, nil, &current); err != nil {
+		return fmt.Errorf("failed to fetch Tempo worklog %d: %w", tempoWorklogID, err)
+	}
+
+	merged := make([]WorklogAttribute, 0, len(current.Attributes.Values)+len(attributes))
+	for _, existing := range current.Attributes.Values {
+		replaced := false
+		for _, attribute := range attributes {
+			if attribute.Key == existing.Key {
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			merged = append(merged, existing)
+		}
+	}
+	merged = append(merged, attributes...)
+
+	payload := WorklogRequest{
+		IssueID:          current.Issue.ID,
+		AuthorAccountID:  current.Author.AccountID,
+		TimeSpentSeconds: current.TimeSpentSeconds,
+		StartDate:        current.StartDate,
+		StartTime:        current.StartTime,
+		Description:      current.Description,
+		Attributes:       merged,
+	}
+	if err := c.doRequest("PUT", endpoint, payload, nil); err != nil {
+		return fmt.Errorf("failed to update Tempo worklog %d: %w", tempoWorklogID, err)
+	}
+	return nil
+}
+
 // GetWorklogs retrieves worklogs for a date range
 func (c *Client) GetWorklogs(from, to time.Time, authorAccountID string) ([]WorklogResponse, error) {
 	log.Debug().
@@ -117,38 +197,308 @@ func (c *Client) GetWorklogs(from, to time.Time, authorAccountID string) ([]Work
 		Str("author", authorAccountID).
 		Msg("Fetching worklogs from Tempo")
 
-	// Use Tempo API v4 endpoint with author filter
+	// Filter by author on the server, following pagination
 	endpoint := fmt.Sprintf(
-		"https://api.tempo.io/4/worklogs?from=%s&to=%s&author=%s",
+		"%s/worklogs?from=%s&to=%s&author=%s&limit=%d",
+		c.baseURL,
 		from.Format("2006-01-02"),
 		to.Format("2006-01-02"),
 		authorAccountID,
+		worklogPageSize,
 	)
 
-	var response struct {
-		Results []WorklogResponse `json:"results"`
-	}
+	var results []WorklogResponse
+	for page := 0; page < maxPages && endpoint != ""; page++ {
+		var response struct {
+			Metadata struct {
+				Next string `json:"next"`
+			} `json:"metadata"`
+			Results []WorklogResponse `json:"results"`
+		}
 
-	if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
-		return nil, fmt.Errorf("failed to fetch worklogs from Tempo: %w", err)
+		if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to fetch worklogs from Tempo: %w", err)
+		}
+		results = append(results, response.Results...)
+		endpoint = response.Metadata.Next
 	}
 
 	// Filter by author client-side as an extra safeguard
 	filtered := []WorklogResponse{}
-	for _, wl := range response.Results {
+	for _, wl := range results {
 		if wl.Author.AccountID == authorAccountID {
 			filtered = append(filtered, wl)
 		}
 	}
 
 	log.Debug().
-		Int("total", len(response.Results)).
+		Int("total", len(results)).
 		Int("filtered", len(filtered)).
 		Msg("Retrieved worklogs from Tempo")
 
 	return filtered, nil
 }
 
+// WorkAttribute represents a Tempo work attribute definition
+type WorkAttribute struct {
+	Key      string            `json:"key"`  // e.g., "_WorkType_"
+	Name     string            `json:"name"` // e.g., "Work Type"
+	Type     string            `json:"type"` // e.g., "STATIC_LIST", "INPUT_FIELD", "ACCOUNT"
+	Required bool              `json:"required"`
+	Values   []string          `json:"values"` // Values of STATIC_LIST attributes
+	Names    map[string]string `json:"names"`  // Display name per value
+}
+
+// StaticListType is the type of work attributes with a fixed list of values
+const StaticListType = "STATIC_LIST"
+
+// GetWorkAttributes retrieves all work attribute definitions, following pagination
+func (c *Client) GetWorkAttributes() ([]WorkAttribute, error) {
+	log.Debug().Msg("Fetching work attributes from Tempo")
+
+	var attributes []WorkAttribute
+	endpoint := fmt.Sprintf("%s/work-attributes", c.baseURL)
+	for page := 0; page < maxPages && endpoint != ""; page++ {
+		var response struct {
+			Metadata struct {
+				Next string `json:"next"`
+			} `json:"metadata"`
+			Results []WorkAttribute `json:"results"`
+		}
+		if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to fetch work attributes from Tempo: %w", err)
+		}
+		attributes = append(attributes, response.Results...)
+		endpoint = response.Metadata.Next
+	}
+
+	log.Debug().Int("count", len(attributes)).Msg("Retrieved work attributes from Tempo")
+	return attributes, nil
+}
+
+// Account is a Tempo account that billable work is logged against (e.g., a client contract)
+type Account struct {
+	ID     int64  `json:"id"`
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Status string `json:"status"` // OPEN, CLOSED or ARCHIVED
+}
+
+// AccountLink is an account linked to a Jira project
+type AccountLink struct {
+	Account Account
+	Default bool // Whether the account is the project's default account
+}
+
+// GetAccounts retrieves all open accounts, following pagination
+func (c *Client) GetAccounts() ([]Account, error) {
+	log.Debug().Msg("Fetching accounts from Tempo")
+
+	var accounts []Account
+	endpoint := fmt.Sprintf("%s/accounts?status=OPEN", c.baseURL)
+	for page := 0; page < maxPages && endpoint != ""; page++ {
+		var response struct {
+			Metadata struct {
+				Next string `json:"next"`
+			} `json:"metadata"`
+			Results []Account `json:"results"`
+		}
+		if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to fetch accounts from Tempo: %w", err)
+		}
+		accounts = append(accounts, response.Results...)
+		endpoint = response.Metadata.Next
+	}
+
+	log.Debug().Int("count", len(accounts)).Msg("Retrieved accounts from Tempo")
+	return accounts, nil
+}
+
+// GetProjectAccounts retrieves the open accounts linked to a Jira project by its numeric ID
+func (c *Client) GetProjectAccounts(projectID string) ([]AccountLink, error) {
+	log.Debug().Str("project", projectID).Msg("Fetching project accounts from Tempo")
+
+	endpoint := fmt.Sprintf("%s/account-links/project/%s", c.baseURL, url.PathEscape(projectID))
+
+	var response struct {
+		Results []struct {
+			Account struct {
+				ID int64 `json:"id"`
+			} `json:"account"`
+			Default bool `json:"default"`
+		} `json:"results"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch account links from Tempo: %w", err)
+	}
+	if len(response.Results) == 0 {
+		return nil, nil
+	}
+
+	// Links only reference accounts, so resolve them against the open accounts
+	accounts, err := c.GetAccounts()
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[int64]Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	var links []AccountLink
+	for _, result := range response.Results {
+		account, ok := byID[result.Account.ID]
+		if !ok {
+			continue
+		}
+		links = append(links, AccountLink{Account: account, Default: result.Default})
+	}
+
+	log.Debug().Int("count", len(links)).Msg("Retrieved project accounts from Tempo")
+	return links, nil
+}
+
+// Plan is a Tempo Planner allocation of a user to a plan item
+type Plan struct {
+	ID                   int64  `json:"id"`
+	StartDate            string `json:"startDate"` // YYYY-MM-DD
+	EndDate              string `json:"endDate"`   // YYYY-MM-DD, inclusive
+	PlannedSecondsPerDay int    `json:"plannedSecondsPerDay"`
+	// TotalPlannedSecondsInScope is the planned time between the requested dates
+	TotalPlannedSecondsInScope int    `json:"totalPlannedSecondsInScope"`
+	Description                string `json:"description"`
+	PlanItem                   struct {
+		ID   int64  `json:"id"`   // Issue or project ID
+		Type string `json:"type"` // ISSUE or PROJECT
+	} `json:"planItem"`
+}
+
+// IssuePlanType is the plan item type of plans for a single issue
+const IssuePlanType = "ISSUE"
+
+// GetPlans retrieves a user's plans between the given dates, following pagination
+func (c *Client) GetPlans(accountID string, from, to time.Time) ([]Plan, error) {
+	log.Debug().
+		Str("from", from.Format(dateFormat)).
+		Str("to", to.Format(dateFormat)).
+		Msg("Fetching plans from Tempo")
+
+	var plans []Plan
+	endpoint := fmt.Sprintf("%s/plans/user/%s?from=%s&to=%s", c.baseURL, url.PathEscape(accountID), from.Format(dateFormat), to.Format(dateFormat))
+	for page := 0; page < maxPages && endpoint != ""; page++ {
+		var response struct {
+			Metadata struct {
+				Next string `json:"next"`
+			} `json:"metadata"`
+			Results []Plan `json:"results"`
+		}
+		if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+			return nil, fmt.Errorf("failed to fetch plans from Tempo: %w", err)
+		}
+		plans = append(plans, response.Results...)
+		endpoint = response.Metadata.Next
+	}
+
+	log.Debug().Int("count", len(plans)).Msg("Retrieved plans from Tempo")
+	return plans, nil
+}
+
+// Period is a timesheet period
+type Period struct {
+	From string `json:"from"` // YYYY-MM-DD
+	To   string `json:"to"`   // YYYY-MM-DD, inclusive
+}
+
+// ScheduleDay is the required working time of a day in the user's work schedule
+type ScheduleDay struct {
+	Date            string `json:"date"` // YYYY-MM-DD
+	RequiredSeconds int    `json:"requiredSeconds"`
+	Type            string `json:"type"` // WORKING_DAY, NON_WORKING_DAY, HOLIDAY, ...
+}
+
+// Approval is the approval state of a user's timesheet for a period
+type Approval struct {
+	Period           Period `json:"period"`
+	RequiredSeconds  int    `json:"requiredSeconds"`
+	TimeSpentSeconds int    `json:"timeSpentSeconds"`
+	Status           struct {
+		Key       string `json:"key"` // OPEN, IN_REVIEW, APPROVED
+		Comment   string `json:"comment"`
+		UpdatedAt string `json:"updatedAt"`
+	} `json:"status"`
+	Reviewer *struct {
+		AccountID string `json:"accountId"`
+	} `json:"reviewer"`
+}
+
+// GetPeriods retrieves the timesheet periods overlapping the given dates
+func (c *Client) GetPeriods(from, to time.Time) ([]Period, error) {
+	endpoint := fmt.Sprintf("%s/periods?from=%s&to=%s", c.baseURL, from.Format(dateFormat), to.Format(dateFormat))
+
+	var response struct {
+		Periods []Period `json:"periods"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch periods from Tempo: %w", err)
+	}
+	return response.Periods, nil
+}
+
+// GetUserSchedule retrieves the token owner's required working time per day
+func (c *Client) GetUserSchedule(from, to time.Time) ([]ScheduleDay, error) {
+	endpoint := fmt.Sprintf("%s/user-schedule?from=%s&to=%s", c.baseURL, from.Format(dateFormat), to.Format(dateFormat))
+
+	var response struct {
+		Results []ScheduleDay `json:"results"`
+	}
+	if err := c.doRequest("GET", endpoint, nil, &response); err != nil {
+		return nil, fmt.Errorf("failed to fetch user schedule from Tempo: %w", err)
+	}
+	return response.Results, nil
+}
+
+// GetApproval retrieves the timesheet approval of a user for a period
+func (c *Client) GetApproval(accountID string, period Period) (*Approval, error) {
+	endpoint := fmt.Sprintf("%s/timesheet-approvals/user/%s?from=%s&to=%s", c.baseURL, url.PathEscape(accountID), period.From, period.To)
+
+	var approval Approval
+	if err := c.doRequest("GET", endpoint, nil, &approval); err != nil {
+		return nil, fmt.Errorf("failed to fetch timesheet approval from Tempo: %w", err)
+	}
+	return &approval, nil
+}
+
+// SubmitApproval submits a user's timesheet for the period to a reviewer
+func (c *Client) SubmitApproval(accountID string, period Period, reviewerAccountID, comment string) (*Approval, error) {
+	log.Debug().
+		Str("from", period.From).
+		Str("to", period.To).
+		Str("reviewer", reviewerAccountID).
+		Msg("Submitting timesheet to Tempo")
+
+	endpoint := fmt.Sprintf("%s/timesheet-approvals/user/%s/submit?from=%s&to=%s", c.baseURL, url.PathEscape(accountID), period.From, period.To)
+
+	payload := map[string]string{
+		"reviewerAccountId": reviewerAccountID,
+	}
+	if comment != "" {
+		payload["comment"] = comment
+	}
+
+	var approval Approval
+	if err := c.doRequest("POST", endpoint, payload, &approval); err != nil {
+		return nil, fmt.Errorf("failed to submit timesheet to Tempo: %w", err)
+	}
+
+	log.Info().
+		Str("from", period.From).
+		Str("to", period.To).
+		Str("status", approval.Status.Key).
+		Msg("Timesheet submitted successfully")
+
+	return &approval, nil
+}
+
 // GetTodayWorklogs retrieves today's worklogs for a specific author
 func (c *Client) GetTodayWorklogs(authorAccountID string) ([]WorklogResponse, error) {
 	today := time.Now()
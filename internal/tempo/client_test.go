@@ -1,6 +1,9 @@
 package tempo
 
 import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 )
@@ -51,7 +54,7 @@ func TestFormatSeconds(t *testing.T) {
 
 func TestWorklogRequestStructure(t *testing.T) {
 	req := WorklogRequest{
-		IssueID:          "12345",
+		IssueID:          12345,
 		AuthorAccountID:  "account-123",
 		TimeSpentSeconds: 7200,
 		StartDate:        "2024-11-11",
@@ -59,7 +62,7 @@ func TestWorklogRequestStructure(t *testing.T) {
 		Description:      "Test work",
 	}
 
-	if req.IssueID != "12345" {
+	if req.IssueID != 12345 {
 		t.Error("issue ID not set correctly")
 	}
 
@@ -103,3 +106,344 @@ func TestWorklogAttributeStructure(t *testing.T) {
 		t.Error("attribute value not set correctly")
 	}
 }
+
+func TestAddWorklog(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/4/worklogs" {
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer tempo-token" {
+			t.Errorf("unexpected authorization: %q", auth)
+		}
+
+		var payload map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Fatalf("failed to decode request body: %v", err)
+		}
+		if payload["issueId"] != float64(10001) {
+			t.Errorf("expected numeric issueId, got %v", payload["issueId"])
+		}
+		if payload["description"] != "Fixed login" {
+			t.Errorf("expected description without label, got %v", payload["description"])
+		}
+		if payload["startDate"] != "2025-01-15" || payload["startTime"] != "09:30:00" {
+			t.Errorf("unexpected start: %v %v", payload["startDate"], payload["startTime"])
+		}
+		attributes, _ := payload["attributes"].([]interface{})
+		if len(attributes) != 1 {
+			t.Fatalf("expected 1 attribute, got %v", payload["attributes"])
+		}
+		attribute := attributes[0].(map[string]interface{})
+		if attribute["key"] != "_WorkType_" || attribute["value"] != "Development" {
+			t.Errorf("unexpected attribute: %v", attribute)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(WorklogResponse{TempoWorklogID: 42, JiraWorklogID: 100})
+	}))
+	defer server.Close()
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL + "/4/")
+
+	started := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+	response, err := client.AddWorklog(10001, "account-1", 3600, started, "Fixed login", []WorklogAttribute{{Key: "_WorkType_", Value: "Development"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.TempoWorklogID != 42 || response.JiraWorklogID != 100 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+}
+
+func TestGetWorkAttributes(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Query().Get("offset") {
+		case "":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"metadata": map[string]interface{}{"next": serverURL + "/work-attributes?offset=1"},
+				"results": []WorkAttribute{
+					{Key: "_WorkType_", Name: "Work Type", Type: StaticListType, Values: []string{"Development", "Meeting"}},
+				},
+			})
+		case "1":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"metadata": map[string]interface{}{},
+				"results":  []WorkAttribute{{Key: "_Ticket_", Name: "Ticket", Type: "INPUT_FIELD"}},
+			})
+		default:
+			t.Errorf("unexpected request: %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+
+	attributes, err := client.GetWorkAttributes()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(attributes) != 2 {
+		t.Fatalf("expected 2 attributes across pages, got %d", len(attributes))
+	}
+	if attributes[0].Key != "_WorkType_" || len(attributes[0].Values) != 2 {
+		t.Errorf("unexpected first attribute: %+v", attributes[0])
+	}
+}
+
+func TestGetWorklogs_Pages(t *testing.T) {
+	var serverURL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("offset") == "" {
+			if r.URL.Query().Get("author") != "account-1" || r.URL.Query().Get("limit") != "1000" {
+				t.Errorf("unexpected query: %s", r.URL.RawQuery)
+			}
+			response := map[string]interface{}{
+				"metadata": map[string]interface{}{"next": serverURL + "/worklogs?offset=1"},
+				"results":  []map[string]interface{}{{"tempoWorklogId": 1, "author": map[string]string{"accountId": "account-1"}}},
+			}
+			json.NewEncoder(w).Encode(response)
+			return
+		}
+		response := map[string]interface{}{
+			"metadata": map[string]interface{}{},
+			"results": []map[string]interface{}{
+				{"tempoWorklogId": 2, "author": map[string]string{"accountId": "account-1"}},
+				{"tempoWorklogId": 3, "author": map[string]string{"accountId": "someone-else"}},
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+	serverURL = server.URL
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+
+	worklogs, err := client.GetWorklogs(time.Now(), time.Now(), "account-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(worklogs) != 2 || worklogs[1].TempoWorklogID != 2 {
+		t.Errorf("expected the author's worklogs from both pages, got %+v", worklogs)
+	}
+}
+
+func TestApproval(t *testing.T) {
+	period := Period{From: "2025-01-06", To: "2025-01-19"}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") != period.From || r.URL.Query().Get("to") != period.To {
+			t.Errorf("unexpected period: %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "GET" && r.URL.Path == "/timesheet-approvals/user/account-1":
+			response := map[string]interface{}{
+				"period":           period,
+				"requiredSeconds":  288000,
+				"timeSpentSeconds": 280800,
+				"status":           map[string]string{"key": "OPEN"},
+				"reviewer":         map[string]string{"accountId": "manager-1"},
+			}
+			json.NewEncoder(w).Encode(response)
+		case r.Method == "POST" && r.URL.Path == "/timesheet-approvals/user/account-1/submit":
+			var payload map[string]string
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if payload["reviewerAccountId"] != "manager-1" || payload["comment"] != "All done" {
+				t.Errorf("unexpected submit payload: %v", payload)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"period": period, "status": map[string]string{"key": "IN_REVIEW"}})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+
+	approval, err := client.GetApproval("account-1", period)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approval.Status.Key != "OPEN" || approval.Reviewer == nil || approval.Reviewer.AccountID != "manager-1" || approval.RequiredSeconds != 288000 {
+		t.Errorf("unexpected approval: %+v", approval)
+	}
+
+	approval, err = client.SubmitApproval("account-1", period, "manager-1", "All done")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if approval.Status.Key != "IN_REVIEW" {
+		t.Errorf("expected IN_REVIEW after submit, got %s", approval.Status.Key)
+	}
+}
+
+func TestGetProjectAccounts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch r.URL.Path {
+		case "/account-links/project/10000":
+			response := map[string]interface{}{
+				"results": []map[string]interface{}{
+					{"account": map[string]interface{}{"id": 1}, "default": true},
+					{"account": map[string]interface{}{"id": 2}},
+					{"account": map[string]interface{}{"id": 3}}, // Closed, not returned by /accounts
+				},
+			}
+			json.NewEncoder(w).Encode(response)
+		case "/accounts":
+			if r.URL.Query().Get("status") != "OPEN" {
+				t.Errorf("expected only open accounts to be requested, got %s", r.URL.RawQuery)
+			}
+			response := map[string]interface{}{
+				"metadata": map[string]interface{}{},
+				"results": []map[string]interface{}{
+					{"id": 1, "key": "ACME", "name": "Acme Corp", "status": "OPEN"},
+					{"id": 2, "key": "INTERNAL", "name": "Internal", "status": "OPEN"},
+				},
+			}
+			json.NewEncoder(w).Encode(response)
+		default:
+			t.Errorf("unexpected request: %s", r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+
+	links, err := client.GetProjectAccounts("10000")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(links) != 2 {
+		t.Fatalf("expected 2 open linked accounts, got %d", len(links))
+	}
+	if links[0].Account.Key != "ACME" || !links[0].Default {
+		t.Errorf("expected ACME as the default account, got %+v", links[0])
+	}
+	if links[1].Account.Key != "INTERNAL" || links[1].Default {
+		t.Errorf("unexpected second account: %+v", links[1])
+	}
+}
+
+func TestGetPlans(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/plans/user/account-1" {
+			t.Errorf("unexpected path: %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("from") != "2025-01-13" || r.URL.Query().Get("to") != "2025-01-19" {
+			t.Errorf("unexpected dates: %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		response := map[string]interface{}{
+			"metadata": map[string]interface{}{},
+			"results": []map[string]interface{}{
+				{
+					"id":                         7,
+					"startDate":                  "2025-01-13",
+					"endDate":                    "2025-01-17",
+					"plannedSecondsPerDay":       14400,
+					"totalPlannedSecondsInScope": 72000,
+					"planItem":                   map[string]interface{}{"id": 10001, "type": "ISSUE"},
+				},
+			},
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+
+	from := time.Date(2025, 1, 13, 0, 0, 0, 0, time.Local)
+	plans, err := client.GetPlans("account-1", from, from.AddDate(0, 0, 6))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("expected 1 plan, got %d", len(plans))
+	}
+	if plans[0].PlanItem.ID != 10001 || plans[0].PlanItem.Type != IssuePlanType || plans[0].TotalPlannedSecondsInScope != 72000 {
+		t.Errorf("unexpected plan: %+v", plans[0])
+	}
+}
+
+func TestSetWorklogAttributes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/worklogs/jira-to-tempo":
+			var payload map[string][]int64
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if len(payload["jiraWorklogIds"]) != 1 || payload["jiraWorklogIds"][0] != 100 {
+				t.Errorf("unexpected Jira worklog IDs: %v", payload)
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"results": []map[string]int64{{"jiraWorklogId": 100, "tempoWorklogId": 42}},
+			})
+		case r.Method == "GET" && r.URL.Path == "/worklogs/42":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"tempoWorklogId":   42,
+				"timeSpentSeconds": 3600,
+				"startDate":        "2025-01-15",
+				"startTime":        "09:30:00",
+				"description":      "Fixed login",
+				"author":           map[string]string{"accountId": "account-1"},
+				"issue":            map[string]int64{"id": 10001},
+				"attributes": map[string]interface{}{
+					"values": []map[string]string{
+						{"key": "_Account_", "value": "ACME"},
+						{"key": "_WorkType_", "value": "Meeting"},
+					},
+				},
+			})
+		case r.Method == "PUT" && r.URL.Path == "/worklogs/42":
+			var payload WorklogRequest
+			if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode request body: %v", err)
+			}
+			if payload.AuthorAccountID != "account-1" || payload.TimeSpentSeconds != 3600 || payload.Description != "Fixed login" {
+				t.Errorf("expected the worklog to be kept, got %+v", payload)
+			}
+			want := []WorklogAttribute{{Key: "_Account_", Value: "ACME"}, {Key: "_WorkType_", Value: "Development"}}
+			if len(payload.Attributes) != 2 || payload.Attributes[0] != want[0] || payload.Attributes[1] != want[1] {
+				t.Errorf("expected merged attributes %v, got %v", want, payload.Attributes)
+			}
+			json.NewEncoder(w).Encode(map[string]int{"tempoWorklogId": 42})
+		default:
+			t.Errorf("unexpected request: %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient("tempo-token")
+	client.SetBaseURL(server.URL)
+
+	tempoID, err := client.GetTempoWorklogID(100)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tempoID != 42 {
+		t.Fatalf("expected Tempo worklog 42, got %d", tempoID)
+	}
+
+	if err := client.SetWorklogAttributes(tempoID, []WorklogAttribute{{Key: "_WorkType_", Value: "Development"}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
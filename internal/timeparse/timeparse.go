@@ -49,6 +49,16 @@ func Parse(input string) (int, error) {
 	return int(roundedMinutes * 60), nil
 }
 
+// RoundSeconds rounds a duration in seconds to the nearest 5 minutes
+// Durations that would round to zero are rounded up to 5 minutes
+func RoundSeconds(seconds int) int {
+	rounded := int(roundToNearest5(float64(seconds)/60) * 60)
+	if rounded <= 0 && seconds > 0 {
+		return 5 * 60
+	}
+	return rounded
+}
+
 // roundToNearest5 rounds a number to the nearest 5
 func roundToNearest5(minutes float64) float64 {
 	return math.Round(minutes/5) * 5
@@ -75,3 +75,25 @@ func TestFormat(t *testing.T) {
 		})
 	}
 }
+
+func TestRoundSeconds(t *testing.T) {
+	tests := []struct {
+		name     string
+		seconds  int
+		expected int
+	}{
+		{"exact", 1800, 1800},
+		{"round down", 1860, 1800},
+		{"round up", 1980, 2100},
+		{"tiny duration becomes 5 minutes", 30, 300},
+		{"zero stays zero", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RoundSeconds(tt.seconds); got != tt.expected {
+				t.Errorf("RoundSeconds(%d) = %d, want %d", tt.seconds, got, tt.expected)
+			}
+		})
+	}
+}
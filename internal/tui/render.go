@@ -0,0 +1,195 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"tasklog/internal/storage"
+	"tasklog/internal/timeparse"
+)
+
+const (
+	styleBold    = "\x1b[1m"
+	styleDim     = "\x1b[2m"
+	styleReverse = "\x1b[7m"
+	styleReset   = "\x1b[0m"
+)
+
+// fixedLines is the number of dashboard lines not used by the entry and issue lists
+const fixedLines = 19
+
+// weekBarWidth is the width of a full day in the week totals
+const weekBarWidth = 20
+
+// helpLine lists the key bindings
+const helpLine = "↑↓ move  tab switch  l log  e edit  d delete  t timer  s sync  b break  r refresh  q quit"
+
+// view holds the dashboard state that is not part of the data
+type view struct {
+	pane    Pane
+	cursor  [2]int // Cursor per pane
+	message string
+}
+
+// render returns the dashboard lines for the given terminal size
+func render(data *Data, v view, now time.Time, width, height int) []string {
+	var lines []string
+	add := func(format string, args ...interface{}) {
+		lines = append(lines, fmt.Sprintf(format, args...))
+	}
+
+	// Header
+	var todayTotal int
+	for _, entry := range data.Entries {
+		todayTotal += entry.TimeSpentSeconds
+	}
+	header := fmt.Sprintf("%stasklog%s  %s  Today: %s", styleBold, styleReset, now.Format("Mon Jan 2"), timeparse.Format(todayTotal))
+	if data.TempoToday != "" {
+		header += fmt.Sprintf(" (Tempo: %s)", data.TempoToday)
+	}
+	add("%s", header)
+	add("")
+
+	// Timer
+	if data.Timer != nil {
+		elapsed := int(now.Sub(data.Timer.Started).Seconds())
+		if elapsed < 0 {
+			elapsed = 0
+		}
+		add("⏱  %s%s %s%s  %s", styleBold, timeparse.Format(elapsed), data.Timer.IssueKey, styleReset, data.Timer.IssueSummary)
+	} else {
+		add("%s⏱  No timer running%s", styleDim, styleReset)
+	}
+	add("")
+
+	// Split the remaining height between the two lists
+	available := height - fixedLines
+	entryRows, issueRows := splitRows(max(len(data.Entries), 1), max(len(data.Issues), 1), available)
+
+	// Today's entries
+	add("%s", paneTitle(fmt.Sprintf("Today's entries (%d)", len(data.Entries)), v.pane == PaneEntries))
+	if len(data.Entries) == 0 {
+		add("  %sNothing logged yet%s", styleDim, styleReset)
+	}
+	start := scrollStart(v.cursor[PaneEntries], entryRows)
+	for i := start; i < len(data.Entries) && i < start+entryRows; i++ {
+		entry := data.Entries[i]
+		row := fmt.Sprintf("%s %s  %-7s [%s] %s  %s",
+			syncSymbol(entry),
+			entry.Started.Format("15:04"),
+			entry.TimeSpent,
+			entry.Label,
+			entry.IssueKey,
+			entry.IssueSummary,
+		)
+		add("%s", listRow(row, v.pane == PaneEntries && i == v.cursor[PaneEntries], width))
+	}
+	add("")
+
+	// In-progress issues
+	add("%s", paneTitle(fmt.Sprintf("In progress (%d)", len(data.Issues)), v.pane == PaneIssues))
+	switch {
+	case data.IssuesErr != nil:
+		add("  ✗ %s", truncate(data.IssuesErr.Error(), width-4))
+	case len(data.Issues) == 0:
+		add("  %sNo issues in progress%s", styleDim, styleReset)
+	}
+	start = scrollStart(v.cursor[PaneIssues], issueRows)
+	for i := start; i < len(data.Issues) && i < start+issueRows; i++ {
+		issue := data.Issues[i]
+		row := fmt.Sprintf("%s [%s] %s", issue.Key, issue.Fields.Status.Name, issue.Fields.Summary)
+		add("%s", listRow(row, v.pane == PaneIssues && i == v.cursor[PaneIssues], width))
+	}
+	add("")
+
+	// Week totals
+	weekTotal := 0
+	maxDay := 8 * 3600
+	for _, day := range data.Week {
+		weekTotal += day.Seconds
+		if day.Seconds > maxDay {
+			maxDay = day.Seconds
+		}
+	}
+	add("%sThis week: %s%s", styleBold, timeparse.Format(weekTotal), styleReset)
+	for _, day := range data.Week {
+		marker := " "
+		if sameDay(day.Day, now) {
+			marker = "•"
+		}
+		bar := strings.Repeat("█", day.Seconds*weekBarWidth/maxDay)
+		add("%s %s  %-7s %s", marker, day.Day.Format("Mon 02"), timeparse.Format(day.Seconds), bar)
+	}
+	add("")
+
+	// Footer
+	add("%s", truncate(v.message, width))
+	add("%s%s%s", styleDim, truncate(helpLine, width), styleReset)
+
+	return lines
+}
+
+// splitRows divides the available rows between two lists, giving unused rows of one list to the other
+func splitRows(a, b, available int) (int, int) {
+	if available < 2 {
+		available = 2
+	}
+	rowsA := min(a, max(available/2, available-b))
+	rowsB := min(b, available-rowsA)
+	return max(rowsA, 1), max(rowsB, 1)
+}
+
+// scrollStart returns the first visible row so that the cursor stays visible
+func scrollStart(cursor, rows int) int {
+	if cursor < rows {
+		return 0
+	}
+	return cursor - rows + 1
+}
+
+// paneTitle formats a pane title, highlighting the focused pane
+func paneTitle(title string, focused bool) string {
+	if focused {
+		return styleBold + "▸ " + title + styleReset
+	}
+	return "  " + title
+}
+
+// listRow formats a list row, highlighting the selected row
+func listRow(row string, selected bool, width int) string {
+	row = truncate("  "+row, width)
+	if selected {
+		return styleReverse + row + styleReset
+	}
+	return row
+}
+
+// syncSymbol returns the sync state marker of an entry
+func syncSymbol(entry storage.TimeEntry) string {
+	switch {
+	case entry.SyncedToJira && entry.SyncedToTempo:
+		return "✓"
+	case entry.SyncedToJira || entry.SyncedToTempo:
+		return "⚠"
+	default:
+		return "✗"
+	}
+}
+
+// truncate shortens s to at most width runes
+func truncate(s string, width int) string {
+	runes := []rune(s)
+	if width <= 0 || len(runes) <= width {
+		return s
+	}
+	if width == 1 {
+		return "…"
+	}
+	return string(runes[:width-1]) + "…"
+}
+
+// sameDay reports whether a and b fall on the same calendar day
+func sameDay(a, b time.Time) bool {
+	return a.Year() == b.Year() && a.YearDay() == b.YearDay()
+}
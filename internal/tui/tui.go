@@ -0,0 +1,343 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+)
+
+// Pane identifies a selectable list on the dashboard
+type Pane int
+
+const (
+	PaneEntries Pane = iota // Today's entries
+	PaneIssues              // In-progress issues
+)
+
+// DayTotal is the time logged on a single day
+type DayTotal struct {
+	Day     time.Time
+	Seconds int
+}
+
+// Data is a snapshot of everything shown on the dashboard
+type Data struct {
+	Entries    []storage.TimeEntry // Today's local entries
+	Issues     []jira.Issue        // In-progress issues
+	IssuesErr  error               // Set if in-progress issues could not be fetched
+	Timer      *storage.Timer      // Running timer, nil if none
+	Week       []DayTotal          // Totals from Monday to Sunday of the current week
+	TempoToday string              // Today's total according to Tempo (empty if Tempo is disabled)
+}
+
+// Actions are the callbacks behind the dashboard key bindings
+// Except for Load, they run with the terminal restored to normal mode, so they may prompt and print
+// A nil action disables its key binding
+type Actions struct {
+	Load   func() (*Data, error)
+	Log    func(issue *jira.Issue) error // issue is nil unless an in-progress issue is selected
+	Edit   func(entry storage.TimeEntry) error
+	Delete func(entry storage.TimeEntry) error
+	Sync   func() error
+	Break  func() error
+	Timer  func(issue *jira.Issue) error // Stops the running timer, or starts one on issue (nil if none selected)
+}
+
+// Dashboard is a full-screen terminal dashboard
+type Dashboard struct {
+	actions Actions
+	data    *Data
+	view    view
+	in      *os.File
+	out     io.Writer
+}
+
+// command is the result of a key press
+type command struct {
+	quit   bool
+	reload bool
+	run    func() error // Runs with the terminal restored
+}
+
+// New creates a dashboard reading keys from stdin and drawing to stdout
+func New(actions Actions) *Dashboard {
+	return &Dashboard{
+		actions: actions,
+		data:    &Data{},
+		in:      os.Stdin,
+		out:     os.Stdout,
+	}
+}
+
+// Run shows the dashboard until the user quits
+func (d *Dashboard) Run() error {
+	fd := int(d.in.Fd())
+	if !term.IsTerminal(fd) {
+		return fmt.Errorf("the dashboard requires an interactive terminal")
+	}
+
+	if err := d.reload(); err != nil {
+		return err
+	}
+
+	state, err := d.enterScreen(fd)
+	if err != nil {
+		return err
+	}
+	defer d.leaveScreen(fd, state)
+
+	type keyResult struct {
+		key string
+		err error
+	}
+	keys := make(chan keyResult)
+	// Keys are read one at a time so no read is pending while an action prompts
+	readNext := func() {
+		go func() {
+			key, err := readKey(d.in)
+			keys <- keyResult{key, err}
+		}()
+	}
+	readNext()
+
+	// Redraw every second to keep the running timer up to date
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for {
+		width, height, err := term.GetSize(fd)
+		if err != nil {
+			width, height = 80, 24
+		}
+		d.draw(width, height)
+
+		select {
+		case <-ticker.C:
+			continue
+		case kr := <-keys:
+			if kr.err != nil {
+				return kr.err
+			}
+
+			cmd := d.handleKey(kr.key)
+			switch {
+			case cmd.quit:
+				return nil
+			case cmd.run != nil:
+				d.leaveScreen(fd, state)
+				d.runAction(cmd.run)
+				if state, err = d.enterScreen(fd); err != nil {
+					return err
+				}
+			case cmd.reload:
+				if err := d.reload(); err != nil {
+					d.view.message = "✗ " + err.Error()
+				} else {
+					d.view.message = "✓ Refreshed"
+				}
+			}
+			readNext()
+		}
+	}
+}
+
+// runAction runs an action in normal terminal mode and waits for the user before returning
+func (d *Dashboard) runAction(action func() error) {
+	fmt.Fprintln(d.out)
+	if err := action(); err != nil {
+		d.view.message = "✗ " + err.Error()
+		fmt.Fprintf(d.out, "\n✗ %v\n", err)
+	} else {
+		d.view.message = "✓ Done"
+	}
+
+	fmt.Fprint(d.out, "\nPress Enter to return to the dashboard...")
+	buf := make([]byte, 64)
+	_, _ = d.in.Read(buf)
+
+	if err := d.reload(); err != nil {
+		d.view.message = "✗ " + err.Error()
+	}
+}
+
+// reload refreshes the dashboard data and keeps the cursors in range
+func (d *Dashboard) reload() error {
+	data, err := d.actions.Load()
+	if err != nil {
+		return err
+	}
+	d.data = data
+	d.clampCursors()
+	return nil
+}
+
+// enterScreen switches to the alternate screen in raw mode
+func (d *Dashboard) enterScreen(fd int) (*term.State, error) {
+	state, err := term.MakeRaw(fd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable raw mode: %w", err)
+	}
+	fmt.Fprint(d.out, "\x1b[?1049h\x1b[?25l")
+	return state, nil
+}
+
+// leaveScreen restores the normal screen and terminal mode
+func (d *Dashboard) leaveScreen(fd int, state *term.State) {
+	fmt.Fprint(d.out, "\x1b[?25h\x1b[?1049l")
+	_ = term.Restore(fd, state)
+}
+
+// draw renders the dashboard to the terminal
+func (d *Dashboard) draw(width, height int) {
+	lines := render(d.data, d.view, time.Now(), width, height)
+
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+	for i, line := range lines {
+		b.WriteString(line)
+		b.WriteString("\x1b[K")
+		if i < len(lines)-1 {
+			b.WriteString("\r\n")
+		}
+	}
+	b.WriteString("\x1b[J")
+	fmt.Fprint(d.out, b.String())
+}
+
+// handleKey updates the view for a key press and returns what to do next
+func (d *Dashboard) handleKey(key string) command {
+	d.view.message = ""
+
+	switch key {
+	case "q", "ctrl+c":
+		return command{quit: true}
+	case "up", "k":
+		d.moveCursor(-1)
+	case "down", "j":
+		d.moveCursor(1)
+	case "tab":
+		if d.view.pane == PaneEntries {
+			d.view.pane = PaneIssues
+		} else {
+			d.view.pane = PaneEntries
+		}
+	case "r":
+		return command{reload: true}
+	case "l":
+		if d.actions.Log != nil {
+			issue := d.selectedIssue()
+			return command{run: func() error { return d.actions.Log(issue) }}
+		}
+	case "t":
+		if d.actions.Timer != nil {
+			issue := d.selectedIssue()
+			return command{run: func() error { return d.actions.Timer(issue) }}
+		}
+	case "e", "d":
+		entry := d.selectedEntry()
+		if entry == nil {
+			d.view.message = "Select an entry in today's entries first"
+			return command{}
+		}
+		if key == "e" && d.actions.Edit != nil {
+			return command{run: func() error { return d.actions.Edit(*entry) }}
+		}
+		if key == "d" && d.actions.Delete != nil {
+			return command{run: func() error { return d.actions.Delete(*entry) }}
+		}
+	case "s":
+		if d.actions.Sync != nil {
+			return command{run: d.actions.Sync}
+		}
+	case "b":
+		if d.actions.Break != nil {
+			return command{run: d.actions.Break}
+		}
+	}
+
+	return command{}
+}
+
+// moveCursor moves the cursor of the focused pane by delta
+func (d *Dashboard) moveCursor(delta int) {
+	d.view.cursor[d.view.pane] += delta
+	d.clampCursors()
+}
+
+// clampCursors keeps the pane cursors within their lists
+func (d *Dashboard) clampCursors() {
+	lengths := [2]int{len(d.data.Entries), len(d.data.Issues)}
+	for pane, n := range lengths {
+		if d.view.cursor[pane] >= n {
+			d.view.cursor[pane] = n - 1
+		}
+		if d.view.cursor[pane] < 0 {
+			d.view.cursor[pane] = 0
+		}
+	}
+}
+
+// selectedEntry returns the selected entry if the entries pane is focused
+func (d *Dashboard) selectedEntry() *storage.TimeEntry {
+	if d.view.pane != PaneEntries || len(d.data.Entries) == 0 {
+		return nil
+	}
+	return &d.data.Entries[d.view.cursor[PaneEntries]]
+}
+
+// selectedIssue returns the selected issue if the issues pane is focused
+func (d *Dashboard) selectedIssue() *jira.Issue {
+	if d.view.pane != PaneIssues || len(d.data.Issues) == 0 {
+		return nil
+	}
+	return &d.data.Issues[d.view.cursor[PaneIssues]]
+}
+
+// readKey reads a single key press from a terminal in raw mode
+func readKey(r io.Reader) (string, error) {
+	buf := make([]byte, 16)
+	n, err := r.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return parseKey(buf[:n]), nil
+}
+
+// parseKey converts the bytes of a key press into a key name
+func parseKey(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+
+	switch {
+	case b[0] == 3:
+		return "ctrl+c"
+	case b[0] == '\t':
+		return "tab"
+	case b[0] == '\r' || b[0] == '\n':
+		return "enter"
+	case b[0] == 27 && len(b) == 1:
+		return "esc"
+	case b[0] == 27 && len(b) >= 3 && (b[1] == '[' || b[1] == 'O'):
+		switch b[2] {
+		case 'A':
+			return "up"
+		case 'B':
+			return "down"
+		case 'C':
+			return "right"
+		case 'D':
+			return "left"
+		}
+		return ""
+	}
+
+	return string(b)
+}
@@ -0,0 +1,198 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"tasklog/internal/jira"
+	"tasklog/internal/storage"
+)
+
+func testData() *Data {
+	now := time.Now()
+	return &Data{
+		Entries: []storage.TimeEntry{
+			{ID: 1, IssueKey: "PROJ-1", IssueSummary: "First", TimeSpent: "1h", TimeSpentSeconds: 3600, Label: "dev", Started: now, SyncedToJira: true, SyncedToTempo: true},
+			{ID: 2, IssueKey: "PROJ-2", IssueSummary: "Second", TimeSpent: "30m", TimeSpentSeconds: 1800, Label: "dev", Started: now},
+		},
+		Issues: []jira.Issue{
+			{Key: "PROJ-3", Fields: jira.IssueFields{Summary: "Third", Status: jira.IssueStatus{Name: "In Progress"}}},
+		},
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := []struct {
+		input    []byte
+		expected string
+	}{
+		{[]byte{3}, "ctrl+c"},
+		{[]byte{'\t'}, "tab"},
+		{[]byte{'\r'}, "enter"},
+		{[]byte{27}, "esc"},
+		{[]byte{27, '[', 'A'}, "up"},
+		{[]byte{27, '[', 'B'}, "down"},
+		{[]byte{27, 'O', 'A'}, "up"},
+		{[]byte("q"), "q"},
+		{nil, ""},
+	}
+
+	for _, tt := range tests {
+		if got := parseKey(tt.input); got != tt.expected {
+			t.Errorf("parseKey(%v) = %q, want %q", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestHandleKey_Navigation(t *testing.T) {
+	d := New(Actions{})
+	d.data = testData()
+
+	d.handleKey("down")
+	d.handleKey("down")
+	if d.view.cursor[PaneEntries] != 1 {
+		t.Errorf("expected cursor to stop at last entry, got %d", d.view.cursor[PaneEntries])
+	}
+
+	d.handleKey("up")
+	if d.view.cursor[PaneEntries] != 0 {
+		t.Errorf("expected cursor 0, got %d", d.view.cursor[PaneEntries])
+	}
+
+	d.handleKey("tab")
+	if d.view.pane != PaneIssues {
+		t.Errorf("expected issues pane to be focused")
+	}
+
+	if cmd := d.handleKey("q"); !cmd.quit {
+		t.Error("expected q to quit")
+	}
+}
+
+func TestHandleKey_Actions(t *testing.T) {
+	var loggedIssue *jira.Issue
+	var edited storage.TimeEntry
+	d := New(Actions{
+		Log: func(issue *jira.Issue) error {
+			loggedIssue = issue
+			return nil
+		},
+		Edit: func(entry storage.TimeEntry) error {
+			edited = entry
+			return nil
+		},
+	})
+	d.data = testData()
+
+	// Edit the second entry
+	d.handleKey("down")
+	cmd := d.handleKey("e")
+	if cmd.run == nil {
+		t.Fatal("expected edit action")
+	}
+	if err := cmd.run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if edited.ID != 2 {
+		t.Errorf("expected entry 2 to be edited, got %d", edited.ID)
+	}
+
+	// Log without a selected issue
+	if err := d.handleKey("l").run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loggedIssue != nil {
+		t.Errorf("expected no issue when entries pane is focused, got %s", loggedIssue.Key)
+	}
+
+	// Log to the selected issue
+	d.handleKey("tab")
+	if err := d.handleKey("l").run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loggedIssue == nil || loggedIssue.Key != "PROJ-3" {
+		t.Errorf("expected PROJ-3 to be logged, got %v", loggedIssue)
+	}
+
+	// Editing requires a selected entry
+	if cmd := d.handleKey("e"); cmd.run != nil || d.view.message == "" {
+		t.Error("expected edit to be refused when issues pane is focused")
+	}
+
+	// Unset actions are ignored
+	if cmd := d.handleKey("s"); cmd.run != nil {
+		t.Error("expected sync to be disabled without a Sync action")
+	}
+}
+
+func TestRender(t *testing.T) {
+	data := testData()
+	now := time.Now()
+	data.Timer = &storage.Timer{IssueKey: "PROJ-3", IssueSummary: "Third", Started: now.Add(-45 * time.Minute)}
+	data.TempoToday = "1h"
+	monday := now.AddDate(0, 0, -((int(now.Weekday()) + 6) % 7))
+	for i := 0; i < 7; i++ {
+		data.Week = append(data.Week, DayTotal{Day: monday.AddDate(0, 0, i), Seconds: i * 3600})
+	}
+
+	lines := render(data, view{message: "✓ Done"}, now, 120, 40)
+	output := strings.Join(lines, "\n")
+
+	for _, want := range []string{"Today: 1h 30m (Tempo: 1h)", "45m PROJ-3", "Today's entries (2)", "✓", "✗", "In progress (1)", "PROJ-3 [In Progress] Third", "This week: 21h", "✓ Done"} {
+		if !strings.Contains(output, want) {
+			t.Errorf("expected output to contain %q\n%s", want, output)
+		}
+	}
+
+	if len(lines) > 40 {
+		t.Errorf("expected at most 40 lines, got %d", len(lines))
+	}
+}
+
+func TestRender_IssuesError(t *testing.T) {
+	data := &Data{IssuesErr: fmt.Errorf("connection refused")}
+
+	output := strings.Join(render(data, view{}, time.Now(), 80, 24), "\n")
+	if !strings.Contains(output, "connection refused") {
+		t.Errorf("expected issues error to be shown\n%s", output)
+	}
+	if !strings.Contains(output, "Nothing logged yet") {
+		t.Errorf("expected empty entries placeholder\n%s", output)
+	}
+}
+
+func TestRender_ScrollsToCursor(t *testing.T) {
+	data := &Data{}
+	for i := 0; i < 30; i++ {
+		data.Entries = append(data.Entries, storage.TimeEntry{IssueKey: fmt.Sprintf("PROJ-%d", i+100), Started: time.Now()})
+	}
+
+	output := strings.Join(render(data, view{cursor: [2]int{29, 0}}, time.Now(), 80, 30), "\n")
+	if !strings.Contains(output, "PROJ-129") {
+		t.Errorf("expected selected entry to be visible\n%s", output)
+	}
+	if strings.Contains(output, "PROJ-100") {
+		t.Errorf("expected first entries to be scrolled out\n%s", output)
+	}
+}
+
+func TestSplitRows(t *testing.T) {
+	tests := []struct {
+		a, b, available int
+		wantA, wantB    int
+	}{
+		{10, 2, 10, 8, 2},
+		{2, 10, 10, 2, 8},
+		{10, 10, 10, 5, 5},
+		{1, 1, 0, 1, 1},
+	}
+
+	for _, tt := range tests {
+		gotA, gotB := splitRows(tt.a, tt.b, tt.available)
+		if gotA != tt.wantA || gotB != tt.wantB {
+			t.Errorf("splitRows(%d, %d, %d) = %d, %d, want %d, %d", tt.a, tt.b, tt.available, gotA, gotB, tt.wantA, tt.wantB)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"tasklog/internal/jira"
@@ -11,17 +12,38 @@ import (
 )
 
 // SelectTask presents the user with task selection options
-func SelectTask(inProgressIssues []jira.Issue) (*jira.Issue, error) {
-	if len(inProgressIssues) == 0 {
+// Suggested issues (e.g., detected from the current git branch) are listed first
+func SelectTask(suggestedIssues, inProgressIssues []jira.Issue) (*jira.Issue, error) {
+	if len(suggestedIssues) == 0 && len(inProgressIssues) == 0 {
 		// No in-progress tasks, prompt for search or manual entry
 		return selectTaskWithoutInProgress()
 	}
 
-	// Build options from in-progress tasks
-	options := make([]string, 0, len(inProgressIssues)+2)
+	// Build options from suggested and in-progress tasks, skipping duplicates
+	options := make([]string, 0, len(suggestedIssues)+len(inProgressIssues)+2)
+	issuesByOption := make(map[string]jira.Issue, len(suggestedIssues)+len(inProgressIssues))
+	seen := make(map[string]bool)
+
+	for _, issue := range suggestedIssues {
+		if seen[issue.Key] {
+			continue
+		}
+		seen[issue.Key] = true
+		option := fmt.Sprintf("%s - %s (suggested)", issue.Key, issue.Fields.Summary)
+		options = append(options, option)
+		issuesByOption[option] = issue
+	}
+
 	for _, issue := range inProgressIssues {
-		options = append(options, fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary))
+		if seen[issue.Key] {
+			continue
+		}
+		seen[issue.Key] = true
+		option := fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary)
+		options = append(options, option)
+		issuesByOption[option] = issue
 	}
+
 	options = append(options, "Search for a task", "Enter task key manually")
 
 	var selected string
@@ -44,10 +66,8 @@ func SelectTask(inProgressIssues []jira.Issue) (*jira.Issue, error) {
 	}
 
 	// Find the selected issue
-	for _, issue := range inProgressIssues {
-		if fmt.Sprintf("%s - %s", issue.Key, issue.Fields.Summary) == selected {
-			return &issue, nil
-		}
+	if issue, ok := issuesByOption[selected]; ok {
+		return &issue, nil
 	}
 
 	return nil, fmt.Errorf("task not found")
@@ -246,3 +266,116 @@ func PromptStartTime() (time.Time, error) {
 	// So we can import timeparse here.
 	return timeparse.ParseDateTime(whenStr)
 }
+
+// ReviewItem is a proposed time entry shown in a review list
+type ReviewItem struct {
+	Title     string // Short description shown in the list (e.g., "PROJ-123 - Login fix")
+	Details   string // Extra context shown next to the title (e.g., "09:10, 3 commits")
+	TimeSpent string // Proposed time spent, editable by the user
+	Comment   string // Proposed worklog comment, editable by the user
+}
+
+// ReviewItems lets the user choose which proposed entries to accept and edit their time and comment
+// Returns the indexes of the accepted items; edits are applied to the items in place
+func ReviewItems(items []ReviewItem) ([]int, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+
+	options := make([]string, len(items))
+	for i, item := range items {
+		options[i] = fmt.Sprintf("%s (%s, %s)", item.Title, item.Details, item.TimeSpent)
+	}
+
+	var selected []int
+	prompt := &survey.MultiSelect{
+		Message:  "Select entries to log:",
+		Options:  options,
+		Default:  options,
+		PageSize: 15,
+	}
+
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return nil, err
+	}
+
+	for _, idx := range selected {
+		item := &items[idx]
+		fmt.Printf("\n%s (%s)\n", item.Title, item.Details)
+
+		if err := EditItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	return selected, nil
+}
+
+// EditItem prompts for the time spent and comment of an item, using the current values as defaults
+func EditItem(item *ReviewItem) error {
+	timePrompt := &survey.Input{
+		Message: "Time spent:",
+		Default: item.TimeSpent,
+		Help:    "Formats: 2h 30m, 2.5h, 150m (will be rounded to nearest 5 minutes)",
+	}
+	if err := survey.AskOne(timePrompt, &item.TimeSpent, survey.WithValidator(func(ans interface{}) error {
+		return timeparse.Validate(fmt.Sprint(ans))
+	})); err != nil {
+		return err
+	}
+
+	commentPrompt := &survey.Input{
+		Message: "Comment (optional):",
+		Default: item.Comment,
+	}
+	return survey.AskOne(commentPrompt, &item.Comment)
+}
+
+// PromptIssueKey prompts the user for an issue key, defaulting to the current one
+func PromptIssueKey(current string) (string, error) {
+	var key string
+	prompt := &survey.Input{
+		Message: "Task key:",
+		Default: current,
+	}
+
+	if err := survey.AskOne(prompt, &key, survey.WithValidator(survey.Required)); err != nil {
+		return "", err
+	}
+
+	return strings.ToUpper(strings.TrimSpace(key)), nil
+}
+
+// SelectOption prompts the user to pick one of the given options
+func SelectOption(message string, options []string) (string, error) {
+	var selected string
+	prompt := &survey.Select{
+		Message:  message,
+		Options:  options,
+		PageSize: 10,
+	}
+
+	if err := survey.AskOne(prompt, &selected); err != nil {
+		return "", err
+	}
+
+	return selected, nil
+}
+
+// EditText opens text in the user's editor ($VISUAL or $EDITOR) and returns the edited text
+func EditText(message, text string) (string, error) {
+	var edited string
+	prompt := &survey.Editor{
+		Message:       message,
+		Default:       text,
+		HideDefault:   true,
+		AppendDefault: true,
+		FileName:      "*.txt",
+	}
+
+	if err := survey.AskOne(prompt, &edited); err != nil {
+		return "", err
+	}
+
+	return edited, nil
+}